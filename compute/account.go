@@ -2,8 +2,6 @@ package compute
 
 import (
 	"encoding/xml"
-	"fmt"
-	"log"
 	"net/http"
 )
 
@@ -65,16 +63,24 @@ func (client *Client) GetAccount() (*Account, error) {
 		return nil, err
 	}
 
-	if statusCode == 401 {
-		return nil, fmt.Errorf("cannot connect to compute API (invalid credentials)")
+	if statusCode == http.StatusUnauthorized {
+		return nil, &APIError{
+			Message:    "cannot connect to compute API (invalid credentials)",
+			StatusCode: statusCode,
+			Response: &APIResponseV1{
+				Result:         "ERROR",
+				Message:        "Invalid credentials.",
+				HTTPStatusCode: statusCode,
+			},
+		}
 	}
 
 	account := &Account{}
 	err = xml.Unmarshal(responseBody, account)
 	if err != nil {
-		log.Printf("Received invalid XML response from the CloudControl account-details API: %s\n'%s'",
-			err,
-			string(responseBody),
+		client.logError("Received invalid XML response from the CloudControl account-details API.",
+			logField(LogFieldError, err),
+			logField(LogFieldBody, redactBody(responseBody)),
 		)
 
 		return nil, err
@@ -1,9 +1,5 @@
 package compute
 
-import (
-	"log"
-)
-
 func (client *Client) CheckAddressExists(addresslistId string,
 	begin string, network string) (exists bool) {
 
@@ -13,7 +9,7 @@ func (client *Client) CheckAddressExists(addresslistId string,
 	addresses := addressList.Addresses
 
 	for _, addr := range addresses {
-		log.Printf("Address begin: %s, network: %s, addr.Begin: %s", begin, network, addr.Begin)
+		client.logDebug("Checking address.", logField("begin", begin), logField("network", network), logField("address_begin", addr.Begin))
 		if begin == addr.Begin || network == addr.Begin {
 			return true
 		}
@@ -73,7 +69,7 @@ func (client *Client) AddAddress(addresslistId string,
 		return nil, err
 	}
 
-	log.Printf("Appended address %+v\n to addresslist '%s'.", newAddress, addresslistId)
+	client.logDebug("Appended address to address list.", logField("address", newAddress), logField(LogFieldResourceID, addresslistId))
 
 	return &newAddress, nil
 }
@@ -108,7 +104,7 @@ func (client *Client) DeleteAddress(addresslistId string,
 		return nil, err
 	}
 
-	log.Printf("Deleted address %+v\n from addresslist '%s'.", ipAddress, addresslistId)
+	client.logDebug("Deleted address from address list.", logField("address", ipAddress), logField(LogFieldResourceID, addresslistId))
 
 	return nil, nil
 }
@@ -3,7 +3,6 @@ package compute
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 )
@@ -85,7 +84,7 @@ func (client *Client) GetIPAddressList(id string) (addressList *IPAddressList, e
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +125,7 @@ func (client *Client) ListIPAddressLists(networkDomainID string) (addressLists *
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +165,7 @@ func (client *Client) CreateIPAddressList(name string, description string, ipVer
 	requestURI := fmt.Sprintf("%s/network/createIpAddressList",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &createIPAddressList{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &createIPAddressList{
 		Name:            name,
 		Description:     description,
 		IPVersion:       ipVersion,
@@ -174,6 +173,9 @@ func (client *Client) CreateIPAddressList(name string, description string, ipVer
 		ChildListIDs:    childListIDs,
 		NetworkDomainID: networkDomainID,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -212,7 +214,10 @@ func (client *Client) EditIPAddressList(edit EditIPAddressList) error {
 	requestURI := fmt.Sprintf("%s/network/editIpAddressList",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, edit)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, edit)
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -223,7 +228,7 @@ func (client *Client) EditIPAddressList(edit EditIPAddressList) error {
 		return err
 	}
 
-	log.Printf("response code: %s", apiResponse.ResponseCode)
+	client.logDebug("Received response to edit IP address list.", logField(LogFieldResponseCode, apiResponse.ResponseCode))
 	if apiResponse.ResponseCode != ResponseCodeOK {
 		return apiResponse.ToError("Request to edit IP address list failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
@@ -244,7 +249,10 @@ func (client *Client) DeleteIPAddressList(id string) (err error) {
 	requestURI := fmt.Sprintf("%s/network/deleteIpAddressList",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &deleteIPAddressList{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteIPAddressList{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -276,7 +284,7 @@ func (client *Client) GetIPAddressListByName(name string, networkDomainID string
 		url.QueryEscape(networkDomainID),
 		url.QueryEscape(name),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
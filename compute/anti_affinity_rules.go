@@ -101,7 +101,7 @@ func (client *Client) GetServerAntiAffinityRule(ruleID string, networkDomainID s
 		url.QueryEscape(ruleID),
 		url.QueryEscape(networkDomainID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -145,9 +145,9 @@ func (client *Client) ListServerAntiAffinityRules(networkDomainID string, paging
 	requestURI := fmt.Sprintf("%s/server/antiAffinityRule?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -177,6 +177,17 @@ func (client *Client) ListServerAntiAffinityRules(networkDomainID string, paging
 	return rules, nil
 }
 
+// AllServerAntiAffinityRules retrieves all server anti-affinity rules in the specified network domain (across all pages of results).
+func (client *Client) AllServerAntiAffinityRules(networkDomainID string) (rules []ServerAntiAffinityRule, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListServerAntiAffinityRules(networkDomainID, paging)
+	}, func(page PagedResults) {
+		rules = append(rules, page.(*ServerAntiAffinityRules).Items...)
+	})
+
+	return
+}
+
 // CreateServerAntiAffinityRule creates an anti-affinity rule for the 2 specified servers.
 // server1Id is the Id of the first server.
 // server2Id is the Id of the second server.
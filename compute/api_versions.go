@@ -0,0 +1,239 @@
+package compute
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// MinAPIMinorVersion is the oldest CloudControl 2.x minor version (v2.2) used by the client.
+	MinAPIMinorVersion = 2
+
+	// MaxAPIMinorVersion is the newest CloudControl 2.x minor version (v2.13) used by the client.
+	MaxAPIMinorVersion = 13
+)
+
+// APIVersions maps compute API operations to the CloudControl 2.x minor version (e.g. 4 for v2.4) used to call them.
+//
+// Operations are identified by their organisation-relative path, without resource Ids or query parameters (e.g. "server/deployServer" or "network/vlan").
+// Where different calls to the same end-point use different API versions, the less common ones are identified by a suffix (e.g. "server/server#list").
+type APIVersions map[string]int
+
+// defaultAPIVersions is the default mapping of compute API operations to CloudControl 2.x minor versions.
+var defaultAPIVersions = APIVersions{
+	"image/customerImage": 5,
+	"image/exportImage":   4,
+	"image/importImage":   4,
+	"image/osImage":       9,
+	"image/osImage#get":   5,
+
+	"infrastructure/datacenter": 4,
+
+	"network/addPublicIpBlock":            13,
+	"network/createFirewallRule":          2,
+	"network/createIpAddressList":         2,
+	"network/createNatRule":               13,
+	"network/createPortList":              2,
+	"network/createStaticRoute":           9,
+	"network/deleteFirewallRule":          2,
+	"network/deleteIpAddressList":         2,
+	"network/deleteNatRule":               2,
+	"network/deleteNetworkDomain":         4,
+	"network/deletePortList":              2,
+	"network/deleteStaticRoute":           9,
+	"network/deleteVlan":                  9,
+	"network/deployNetworkDomain":         4,
+	"network/deployVlan":                  9,
+	"network/editFirewallRule":            2,
+	"network/editIpAddressList":           2,
+	"network/editNetworkDomain":           4,
+	"network/editPortList":                2,
+	"network/editVlan":                    9,
+	"network/firewallRule":                2,
+	"network/ipAddressList":               2,
+	"network/natRule":                     9,
+	"network/natRule#list":                2,
+	"network/networkDomain":               4,
+	"network/portList":                    2,
+	"network/publicIpBlock":               13,
+	"network/removePublicIpBlock":         13,
+	"network/reserveIpv6Address":          9,
+	"network/reservePrivateIpv4Address":   9,
+	"network/reservedIpv6Address":         9,
+	"network/reservedPrivateIpv4Address":  9,
+	"network/reservedPublicIpv4Address":   13,
+	"network/restoreStaticRoutes":         4,
+	"network/staticRoute":                 9,
+	"network/unreserveIpv6Address":        9,
+	"network/unreservePrivateIpv4Address": 9,
+	"network/vlan":                        9,
+	"network/vlan#byName":                 2,
+
+	"networkDomainVip/addPoolMember":              2,
+	"networkDomainVip/createNode":                 2,
+	"networkDomainVip/createPool":                 2,
+	"networkDomainVip/createSslOffloadProfile":    6,
+	"networkDomainVip/createVirtualListener":      6,
+	"networkDomainVip/defaultHealthMonitor":       2,
+	"networkDomainVip/defaultIrule":               2,
+	"networkDomainVip/defaultPersistenceProfile":  2,
+	"networkDomainVip/deleteNode":                 2,
+	"networkDomainVip/deletePool":                 2,
+	"networkDomainVip/deleteSslCertificateChain":  6,
+	"networkDomainVip/deleteSslDomainCertificate": 6,
+	"networkDomainVip/deleteSslOffloadProfile":    6,
+	"networkDomainVip/deleteVirtualListener":      6,
+	"networkDomainVip/editNode":                   2,
+	"networkDomainVip/editPool":                   2,
+	"networkDomainVip/editPoolMember":             2,
+	"networkDomainVip/editSslOffloadProfile":      6,
+	"networkDomainVip/editVirtualListener":        6,
+	"networkDomainVip/importSslCertificateChain":  6,
+	"networkDomainVip/importSslDomainCertificate": 6,
+	"networkDomainVip/node":                       2,
+	"networkDomainVip/pool":                       2,
+	"networkDomainVip/poolMember":                 2,
+	"networkDomainVip/removePoolMember":           2,
+	"networkDomainVip/sslCertificateChain":        6,
+	"networkDomainVip/sslDomainCertificate":       6,
+	"networkDomainVip/sslOffloadProfile":          6,
+	"networkDomainVip/virtualListener":            6,
+
+	"server/addDisk":                  10,
+	"server/addNic":                   9,
+	"server/addScsiController":        10,
+	"server/antiAffinityRule":         2,
+	"server/changeDiskIops":           10,
+	"server/changeDiskSpeed":          10,
+	"server/changeNetworkAdapter":     9,
+	"server/cloneServer":              4,
+	"server/deleteServer":             2,
+	"server/deployServer":             3,
+	"server/deployUncustomizedServer": 5,
+	"server/editServerMetadata":       3,
+	"server/expandDisk":               5,
+	"server/notifyNicIpChange":        9,
+	"server/powerOffServer":           2,
+	"server/rebootServer":             2,
+	"server/reconfigureServer":        2,
+	"server/removeDisk":               5,
+	"server/removeDisk#fromServer":    2,
+	"server/removeNic":                9,
+	"server/removeScsiController":     5,
+	"server/resetServer":              2,
+	"server/server":                   10,
+	"server/server#list":              5,
+	"server/shutdownServer":           2,
+	"server/startServer":              2,
+	"server/updateVmwareTools":        4,
+	"server/upgradeVirtualHardware":   4,
+
+	"snapshot/createSnapshotPreviewServer": 10,
+	"snapshot/disableSnapshotService":      10,
+	"snapshot/enableSnapshotService":       10,
+	"snapshot/initiateManualSnapshot":      10,
+	"snapshot/restoreFiles":                10,
+	"snapshot/servicePlan":                 10,
+	"snapshot/snapshot":                    10,
+	"snapshot/window":                      10,
+
+	"tag/applyTags":    13,
+	"tag/createTagKey": 5,
+	"tag/deleteTagKey": 5,
+	"tag/removeTags":   13,
+	"tag/tag":          13,
+	"tag/tag#byType":   5,
+	"tag/tagKey":       5,
+}
+
+// DefaultAPIVersions creates a copy of the default mapping of compute API operations to CloudControl 2.x minor versions.
+func DefaultAPIVersions() APIVersions {
+	return defaultAPIVersions.clone()
+}
+
+// clone creates a copy of the APIVersions.
+func (versions APIVersions) clone() APIVersions {
+	clone := make(APIVersions, len(versions))
+	for operation, minorVersion := range versions {
+		clone[operation] = minorVersion
+	}
+
+	return clone
+}
+
+// APIVersion determines the CloudControl 2.x minor version that the client uses for the specified operation (e.g. "server/deployServer").
+//
+// Per-operation overrides (see Client.SetAPIVersionOverrides) take precedence over the client's pinned version (see Client.SetAPIVersion), which takes precedence over DefaultAPIVersions.
+func (client *Client) APIVersion(operation string) int {
+	holder, ok := client.apiVersionOverrides.Load().(apiVersionOverridesHolder)
+	if ok {
+		minorVersion, ok := holder.overrides[operation]
+		if ok {
+			return minorVersion
+		}
+	}
+
+	pinnedMinorVersion := atomic.LoadInt32(&client.pinnedAPIVersion)
+	if pinnedMinorVersion != 0 {
+		return int(pinnedMinorVersion)
+	}
+
+	minorVersion, ok := defaultAPIVersions[operation]
+	if ok {
+		return minorVersion
+	}
+
+	return MinAPIMinorVersion
+}
+
+// SetAPIVersion configures the client to use the specified CloudControl 2.x minor version (e.g. 4 for v2.4) for all operations that do not have a per-operation override.
+// This is useful when working with regions that run an older build of the API.
+//
+// Set minorVersion to 0 (the default) to use DefaultAPIVersions.
+func (client *Client) SetAPIVersion(minorVersion int) {
+	atomic.StoreInt32(&client.pinnedAPIVersion, int32(minorVersion))
+}
+
+// SetAPIVersionOverrides configures the CloudControl 2.x minor versions that the client uses for specific operations (e.g. APIVersions{"server/deployServer": 4}).
+//
+// Set overrides to nil (the default) to remove all per-operation overrides.
+func (client *Client) SetAPIVersionOverrides(overrides APIVersions) {
+	client.apiVersionOverrides.Store(apiVersionOverridesHolder{
+		overrides: overrides.clone(),
+	})
+}
+
+// apiVersionOverridesHolder enables storage of (possibly nil) APIVersions in an atomic.Value.
+type apiVersionOverridesHolder struct {
+	overrides APIVersions
+}
+
+// getAPIOperation determines the compute API operation (e.g. "server/server") for a relative request URI (e.g. "{orgId}/server/server/{id}?...").
+func getAPIOperation(relativeURI string) string {
+	relativePath := strings.SplitN(relativeURI, "?", 2)[0]
+
+	pathSegments := strings.SplitN(relativePath, "/", 4)
+	if len(pathSegments) < 3 {
+		return ""
+	}
+
+	return pathSegments[1] + "/" + pathSegments[2]
+}
+
+// getAPIMinorVersion extracts the CloudControl 2.x minor version (e.g. 4 for v2.4) from a request path.
+//
+// Returns 0 if the request path does not target v2.x of the compute API.
+func getAPIMinorVersion(requestPath string) int {
+	apiVersion := getAPIVersion(requestPath)
+	if !strings.HasPrefix(apiVersion, "2.") {
+		return 0
+	}
+
+	minorVersion, err := strconv.Atoi(strings.TrimPrefix(apiVersion, "2."))
+	if err != nil {
+		return 0
+	}
+
+	return minorVersion
+}
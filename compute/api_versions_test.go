@@ -0,0 +1,109 @@
+package compute
+
+import (
+	"net/http"
+	"testing"
+)
+
+// Per-operation overrides take precedence over the pinned API version, which takes precedence over the default API versions.
+func TestClient_APIVersion(test *testing.T) {
+	expect := expect(test)
+
+	expect.EqualsString("getAPIOperation", "server/server", getAPIOperation("org-id/server/server/5a32d6e4-9707-4813-a269-56ab4d989f4d?x=y"))
+	expect.EqualsString("getAPIOperation", "network/deployVlan", getAPIOperation("org-id/network/deployVlan"))
+	expect.EqualsInt("getAPIMinorVersion", 10, getAPIMinorVersion("/caas/2.10/org-id/server/server"))
+	expect.EqualsInt("getAPIMinorVersion", 0, getAPIMinorVersion("/oec/0.9/myaccount"))
+
+	client := NewClientWithOptions("au", "user1", "password",
+		WithAPIVersionOverrides(APIVersions{"network/vlan": 5}),
+	)
+	expect.EqualsInt("APIVersion(server/server)", 10, client.APIVersion("server/server"))
+	expect.EqualsInt("APIVersion(network/vlan)", 5, client.APIVersion("network/vlan"))
+	expect.EqualsInt("APIVersion(unknown)", MinAPIMinorVersion, client.APIVersion("unknown/operation"))
+
+	client.SetAPIVersion(4)
+	expect.EqualsInt("APIVersion(server/server) (pinned)", 4, client.APIVersion("server/server"))
+	expect.EqualsInt("APIVersion(network/vlan) (pinned)", 5, client.APIVersion("network/vlan"))
+
+	client.SetAPIVersionOverrides(nil)
+	expect.EqualsInt("APIVersion(network/vlan) (no overrides)", 4, client.APIVersion("network/vlan"))
+
+	versions := DefaultAPIVersions()
+	versions["server/server"] = 2
+	expect.EqualsInt("DefaultAPIVersions is a copy", 10, DefaultAPIVersions()["server/server"])
+}
+
+// Calls to the same end-point that use different API versions are configured using separate operation keys.
+func TestClient_APIVersion_OperationVariants(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			expect.EqualsInt("APIVersion(server/server#list)", 5, client.APIVersion("server/server#list"))
+
+			_, err := client.ListServersInNetworkDomain("484174a2-ae74-4658-9e56-50fc90e086cf", nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Path", "/caas/2.5/my-organization-id/server/server", request.URL.Path)
+
+			return http.StatusOK, `{"server": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 50}`
+		},
+	})
+}
+
+// Servers retrieved using older API versions are decoded into the same shape as those retrieved using the latest version.
+func TestClient_GetServer_OlderAPIVersion(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			client.SetAPIVersionOverrides(APIVersions{"server/server": 3})
+
+			server, err := client.GetServer("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.NotNil("Server", server)
+			expect.EqualsInt("Server.SCSIControllers size", 1, len(server.SCSIControllers))
+			expect.EqualsInt("Server.SCSIControllers[0].Disks size", 2, len(server.SCSIControllers[0].Disks))
+			expect.EqualsInt("Server.SCSIControllers[0].Disks[1].SizeGB", 20, server.SCSIControllers[0].Disks[1].SizeGB)
+			expect.EqualsString("Server.Network.PrimaryAdapter.ID", "5e869800-df7b-4626-bcbf-8643b8be11fd", server.Network.PrimaryAdapter.GetID())
+			expect.IsTrue("Server.Network.PrimaryAdapter.MACAddress is nil", server.Network.PrimaryAdapter.MACAddress == nil)
+			expect.IsTrue("Server.Network.PrimaryAdapter.AdapterKey is nil", server.Network.PrimaryAdapter.AdapterKey == nil)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Path", "/caas/2.3/my-organization-id/server/server/5a32d6e4-9707-4813-a269-56ab4d989f4d", request.URL.Path)
+
+			return http.StatusOK, getServerV23TestResponse
+		},
+	})
+}
+
+var getServerV23TestResponse = `
+{
+	"id": "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+	"name": "Server 1",
+	"disk": [
+		{ "id": "c2e1f199-116e-4dbc-9960-68720b832b0a", "scsiId": 0, "sizeGb": 10, "speed": "STANDARD", "state": "NORMAL" },
+		{ "id": "98c6a0ef-9d8c-4e31-b7cf-b1cc4e0b13b9", "scsiId": 1, "sizeGb": 20, "speed": "STANDARD", "state": "NORMAL" }
+	],
+	"networkInfo": {
+		"primaryNic": {
+			"id": "5e869800-df7b-4626-bcbf-8643b8be11fd",
+			"privateIpv4": "10.0.3.4",
+			"vlanId": "0e56433f-d808-4669-821d-812769517ff8",
+			"key": 4000,
+			"state": "NORMAL"
+		},
+		"additionalNic": [],
+		"networkDomainId": "484174a2-ae74-4658-9e56-50fc90e086cf"
+	},
+	"state": "NORMAL",
+	"deployed": true,
+	"started": true
+}
+`
@@ -245,7 +245,6 @@ func (client *Client) GetServerBackupDetails(serverID string) (*ServerBackupDeta
 		url.QueryEscape(serverID),
 	)
 	request, err := client.newRequestV1(requestURI, http.MethodGet, nil)
-
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create request for retrieving backup details of server '%s'", serverID)
 	}
@@ -0,0 +1,301 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+)
+
+// BulkWaitTarget identifies a resource whose pending operation is being waited on by WaitForAll.
+type BulkWaitTarget struct {
+	// The resource type.
+	ResourceType ResourceType
+
+	// The resource Id.
+	ID string
+
+	// The Id of the network domain that contains the resource.
+	//
+	// Resources of the same type in the same network domain are polled together, using a single list operation (e.g. ListServersInNetworkDomain) per poll.
+	// If not specified (or the resource type cannot be listed by network domain), the resource is polled individually.
+	NetworkDomainID string
+
+	// A short description of the pending operation (e.g. "Deploy"); defaults to "Deploy", or "Delete" if IsDelete is true.
+	ActionDescription string
+
+	// Is the pending operation a delete (i.e. the operation is complete once the resource no longer exists)?
+	IsDelete bool
+}
+
+// BulkWaitResult represents the outcome of a pending operation being waited on by WaitForAll.
+type BulkWaitResult struct {
+	// The resource that was being waited on.
+	Target BulkWaitTarget
+
+	// The resource, as it was when its pending operation completed (nil if the resource was deleted, or Err is not nil).
+	Resource Resource
+
+	// The error (if any) that caused the operation to fail (e.g. ResourceFailedError).
+	Err error
+}
+
+// BulkWaitCallback is a function that is called by WaitForAll as each pending operation completes.
+type BulkWaitCallback func(result BulkWaitResult)
+
+// WaitForAll waits for the pending operations on multiple resources to complete, polling the resources as configured by the client's Waiter (see Client.SetWaiter).
+//
+// Resources of the same type in the same network domain are polled together using a single list request per poll, which greatly reduces the number of API calls compared to calling WaitForXXX for each resource.
+// onComplete (if not nil) is called as each pending operation completes.
+//
+// Returns a result for each target (in the same order as targets); if the wait times out or is cancelled, or the resources cannot be polled, an error is returned (and results only includes the pending operations that have completed).
+func (client *Client) WaitForAll(targets []BulkWaitTarget, timeout time.Duration, onComplete BulkWaitCallback) (results []BulkWaitResult, err error) {
+	waitTimeout := time.NewTimer(timeout)
+	defer waitTimeout.Stop()
+
+	waiter := client.getWaiter()
+	startTime := time.Now()
+
+	operationDescription := fmt.Sprintf("Wait for pending operations on %d resources", len(targets))
+
+	pending := make(map[int]BulkWaitTarget)
+	resultsByIndex := make(map[int]BulkWaitResult)
+	for index, target := range targets {
+		if target.ActionDescription == "" {
+			target.ActionDescription = "Deploy"
+			if target.IsDelete {
+				target.ActionDescription = "Delete"
+			}
+		}
+		pending[index] = target
+	}
+
+	complete := func(index int, resource Resource, err error) {
+		result := BulkWaitResult{
+			Target:   pending[index],
+			Resource: resource,
+			Err:      err,
+		}
+		delete(pending, index)
+		resultsByIndex[index] = result
+
+		if onComplete != nil {
+			onComplete(result)
+		}
+	}
+	collectResults := func() []BulkWaitResult {
+		collected := make([]BulkWaitResult, 0, len(resultsByIndex))
+		for index := range targets {
+			if result, ok := resultsByIndex[index]; ok {
+				collected = append(collected, result)
+			}
+		}
+
+		return collected
+	}
+
+	for iteration := 1; len(pending) > 0; iteration++ {
+		pollTimer := time.NewTimer(waiter.pollInterval(iteration))
+
+		select {
+		case <-client.Context().Done():
+			pollTimer.Stop()
+
+			return collectResults(), client.checkCancellation(operationDescription)
+
+		case <-waitTimeout.C:
+			pollTimer.Stop()
+
+			return collectResults(), fmt.Errorf("Timed out after waiting %d seconds for pending operations on %d resources to complete (%d operations still pending)",
+				timeout/time.Second,
+				len(targets),
+				len(pending),
+			)
+
+		case <-pollTimer.C:
+			err = client.checkCancellation(operationDescription)
+			if err != nil {
+				return collectResults(), err
+			}
+
+			resourcesByTarget, err := client.pollBulkWaitTargets(pending, iteration)
+			if err != nil {
+				return collectResults(), err
+			}
+
+			for index, target := range pending {
+				resource := resourcesByTarget[index]
+				resourceDescription, _ := GetResourceDescription(target.ResourceType)
+
+				if resource == nil || resource.IsDeleted() {
+					if target.IsDelete {
+						complete(index, nil, nil)
+					} else {
+						complete(index, nil, fmt.Errorf("No %s was found with Id '%s'", resourceDescription, target.ID))
+					}
+
+					continue
+				}
+
+				switch resource.GetState() {
+				case ResourceStatusNormal:
+					complete(index, resource, nil)
+
+				case ResourceStatusPendingAdd, ResourceStatusPendingChange, ResourceStatusPendingDelete:
+					waiter.reportProgress(target.ResourceType, target.ID, target.ActionDescription, iteration, startTime, resource, resource.GetState())
+
+				default:
+					client.logError("Unexpected resource status.", logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, target.ID), logField(LogFieldState, resource.GetState()))
+
+					complete(index, resource, newResourceFailedError(target.ResourceType, target.ID, resource, target.ActionDescription))
+				}
+			}
+
+			if len(pending) > 0 {
+				client.logInfo("Pending operations are still in progress...", logField(LogFieldPendingCount, len(pending)))
+			}
+		}
+	}
+
+	return collectResults(), nil
+}
+
+// pollBulkWaitTargets retrieves the current state of the specified resources (keyed by their index in the targets passed to WaitForAll).
+//
+// Resources that were not found are absent from the results.
+func (client *Client) pollBulkWaitTargets(targets map[int]BulkWaitTarget, iteration int) (resourcesByTarget map[int]Resource, err error) {
+	type bulkWaitGroup struct {
+		ResourceType    ResourceType
+		NetworkDomainID string
+	}
+
+	resourcesByTarget = make(map[int]Resource)
+	groups := make(map[bulkWaitGroup][]int)
+	for index, target := range targets {
+		if target.NetworkDomainID == "" || !canListResourcesInNetworkDomain(target.ResourceType) {
+			// Poll individually.
+			resourceDescription, err := GetResourceDescription(target.ResourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			pollClient, finishPoll := client.startPoll(resourceDescription, target.ID, iteration)
+			resource, err := pollClient.GetResource(target.ID, target.ResourceType)
+			finishPoll(resource, err)
+			if err != nil {
+				return nil, err
+			}
+			if resource != nil && !resource.IsDeleted() {
+				resourcesByTarget[index] = resource
+			}
+
+			continue
+		}
+
+		group := bulkWaitGroup{target.ResourceType, target.NetworkDomainID}
+		groups[group] = append(groups[group], index)
+	}
+
+	for group, indexes := range groups {
+		resourceDescription, err := GetResourceDescription(group.ResourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		client.logDebug("Polling resource status (bulk)...",
+			logField(LogFieldResourceType, resourceDescription),
+			logField(LogFieldNetworkDomainID, group.NetworkDomainID),
+			logField(LogFieldPendingCount, len(indexes)),
+		)
+
+		pollClient, finishPoll := client.startPoll(resourceDescription, group.NetworkDomainID, iteration)
+		resources, err := pollClient.listResourcesInNetworkDomain(group.ResourceType, group.NetworkDomainID)
+		finishPoll(nil, err)
+		if err != nil {
+			return nil, err
+		}
+
+		resourcesByID := make(map[string]Resource, len(resources))
+		for _, resource := range resources {
+			resourcesByID[resource.GetID()] = resource
+		}
+		for _, index := range indexes {
+			if resource, ok := resourcesByID[targets[index].ID]; ok {
+				resourcesByTarget[index] = resource
+			}
+		}
+	}
+
+	return resourcesByTarget, nil
+}
+
+// canListResourcesInNetworkDomain determines whether listResourcesInNetworkDomain supports the specified resource type.
+func canListResourcesInNetworkDomain(resourceType ResourceType) bool {
+	switch resourceType {
+	case ResourceTypeServer, ResourceTypeVLAN, ResourceTypePublicIPBlock, ResourceTypeFirewallRule, ResourceTypeVIPNode, ResourceTypeVIPPool, ResourceTypeVirtualListener:
+		return true
+	default:
+		return false
+	}
+}
+
+// listResourcesInNetworkDomain retrieves all resources of the specified type in a network domain.
+func (client *Client) listResourcesInNetworkDomain(resourceType ResourceType, networkDomainID string) (resources []Resource, err error) {
+	switch resourceType {
+	case ResourceTypeServer:
+		servers, err := client.AllServersInNetworkDomain(networkDomainID)
+		for index := range servers {
+			resources = append(resources, &servers[index])
+		}
+
+		return resources, err
+
+	case ResourceTypeVLAN:
+		vlans, err := client.AllVLANs(networkDomainID)
+		for index := range vlans {
+			resources = append(resources, &vlans[index])
+		}
+
+		return resources, err
+
+	case ResourceTypePublicIPBlock:
+		blocks, err := client.AllPublicIPBlocks(networkDomainID)
+		for index := range blocks {
+			resources = append(resources, &blocks[index])
+		}
+
+		return resources, err
+
+	case ResourceTypeFirewallRule:
+		rules, err := client.AllFirewallRules(networkDomainID)
+		for index := range rules {
+			resources = append(resources, &rules[index])
+		}
+
+		return resources, err
+
+	case ResourceTypeVIPNode:
+		nodes, err := client.AllVIPNodesInNetworkDomain(networkDomainID)
+		for index := range nodes {
+			resources = append(resources, &nodes[index])
+		}
+
+		return resources, err
+
+	case ResourceTypeVIPPool:
+		pools, err := client.AllVIPPoolsInNetworkDomain(networkDomainID)
+		for index := range pools {
+			resources = append(resources, &pools[index])
+		}
+
+		return resources, err
+
+	case ResourceTypeVirtualListener:
+		listeners, err := client.AllVirtualListenersInNetworkDomain(networkDomainID)
+		for index := range listeners {
+			resources = append(resources, &listeners[index])
+		}
+
+		return resources, err
+	}
+
+	return nil, fmt.Errorf("resource type (value = %d) cannot be listed by network domain", resourceType)
+}
@@ -0,0 +1,134 @@
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Servers in the same network domain are polled using a single list request per poll, while other resources are polled individually.
+func TestClient_WaitForAll(test *testing.T) {
+	expect := expect(test)
+
+	networkDomainID := "484174a2-ae74-4658-9e56-50fc90e086cf"
+	serverIDs := []string{
+		"5a32d6e4-9707-4813-a269-56ab4d989f4d",
+		"b66e3d25-8ecf-4a3a-92c4-a6f3f3d3a3f0",
+		"ce4f5d1c-3b0b-4b8e-8a5e-7e6f2ad6b5a1",
+	}
+	vlanID := "0e56433f-d808-4669-821d-812769517ff8"
+
+	var stateLock sync.Mutex
+	listCount := 0
+	getCount := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		stateLock.Lock()
+		defer stateLock.Unlock()
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		if !strings.HasSuffix(request.URL.Path, "/server/server") {
+			getCount++
+			fmt.Fprint(writer, getVLANTestResponse)
+
+			return
+		}
+
+		expect.EqualsString("Request.networkDomainId", networkDomainID, request.URL.Query().Get("networkDomainId"))
+
+		listCount++
+		serverStates := []string{
+			ResourceStatusNormal,
+			ResourceStatusPendingAdd,
+			ResourceStatusPendingAdd,
+		}
+		if listCount >= 2 {
+			serverStates[1] = ResourceStatusFailedAdd
+		}
+		if listCount >= 3 {
+			serverStates[2] = ResourceStatusNormal
+		}
+
+		servers := make([]string, len(serverIDs))
+		for index, serverID := range serverIDs {
+			servers[index] = fmt.Sprintf(`{"id": "%s", "name": "server%d", "state": "%s"}`, serverID, index+1, serverStates[index])
+		}
+		fmt.Fprintf(writer, `{"server": [%s], "pageNumber": 1, "pageCount": %d, "totalCount": %d, "pageSize": 250}`,
+			strings.Join(servers, ","), len(servers), len(servers),
+		)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithWaiter(&Waiter{InitialPollInterval: 5 * time.Millisecond}),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	targets := []BulkWaitTarget{
+		{ResourceType: ResourceTypeVLAN, ID: vlanID},
+	}
+	for _, serverID := range serverIDs {
+		targets = append(targets, BulkWaitTarget{
+			ResourceType:    ResourceTypeServer,
+			ID:              serverID,
+			NetworkDomainID: networkDomainID,
+		})
+	}
+
+	var completedIDs []string
+	results, err := client.WaitForAll(targets, 10*time.Second, func(result BulkWaitResult) {
+		completedIDs = append(completedIDs, result.Target.ID)
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.EqualsInt("Result count", 4, len(results))
+	for index, result := range results {
+		expect.EqualsString(fmt.Sprintf("Results[%d].Target.ID", index), targets[index].ID, result.Target.ID)
+		expect.EqualsString(fmt.Sprintf("Results[%d].Target.ActionDescription", index), "Deploy", result.Target.ActionDescription)
+	}
+	expect.IsTrue("Results[0].Err is nil", results[0].Err == nil)
+	expect.IsTrue("Results[1].Err is nil", results[1].Err == nil)
+	expect.IsTrue("Results[2] IsResourceFailedError", IsResourceFailedError(results[2].Err))
+	expect.IsTrue("Results[3].Err is nil", results[3].Err == nil)
+	expect.EqualsString("Results[3].Resource.State", ResourceStatusNormal, results[3].Resource.GetState())
+
+	expect.EqualsInt("Completion callback count", 4, len(completedIDs))
+	expect.EqualsString("Last completed", serverIDs[2], completedIDs[3])
+
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	expect.EqualsInt("List request count", 3, listCount)
+	expect.EqualsInt("Get request count", 1, getCount)
+}
+
+// Waiting for resources times out if their pending operations do not complete.
+func TestClient_WaitForAll_Timeout(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			client.SetWaiter(&Waiter{InitialPollInterval: 5 * time.Millisecond})
+
+			results, err := client.WaitForAll([]BulkWaitTarget{
+				{ResourceType: ResourceTypeServer, ID: "5a32d6e4-9707-4813-a269-56ab4d989f4d", NetworkDomainID: "484174a2-ae74-4658-9e56-50fc90e086cf"},
+			}, 50*time.Millisecond, nil)
+
+			expect.IsTrue("Timed out", err != nil && strings.HasPrefix(err.Error(), "Timed out"))
+			expect.EqualsInt("Result count", 0, len(results))
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			return http.StatusOK, `{"server": [{"id": "5a32d6e4-9707-4813-a269-56ab4d989f4d", "state": "PENDING_ADD"}], "pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 250}`
+		},
+	})
+}
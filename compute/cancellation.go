@@ -12,6 +12,9 @@ func IsOperationCancelledError(err error) bool {
 // OperationCancelledError is the error returned when an operation cancelled.
 type OperationCancelledError struct {
 	OperationDescription string
+
+	// The reason (if any) that the operation was cancelled (e.g. context.Canceled or context.DeadlineExceeded).
+	Reason error
 }
 
 // Get a string representation of the error.
@@ -21,4 +24,9 @@ func (err OperationCancelledError) Error() string {
 	)
 }
 
+// Unwrap retrieves the reason (if any) that the operation was cancelled.
+func (err OperationCancelledError) Unwrap() error {
+	return err.Reason
+}
+
 var _ error = &OperationCancelledError{}
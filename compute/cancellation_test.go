@@ -0,0 +1,116 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Cancelled context (request should not be sent).
+func TestClient_WithContext_Cancelled_GetServer(test *testing.T) {
+	expect := expect(test)
+
+	requestCount := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, getServerTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithBaseAddress(testServer.URL, "user1", "password")
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	server, err := client.WithContext(ctx).GetServer("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+	expect.IsTrue("IsOperationCancelledError", IsOperationCancelledError(err))
+	expect.IsTrue("errors.Is(err, context.Canceled)", errors.Is(err, context.Canceled))
+	expect.IsNil("Server", server)
+	expect.EqualsInt("RequestCount", 0, requestCount)
+
+	// Cancellation of the context must not affect the original client.
+	server, err = client.GetServer("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	verifyGetServerTestResponse(test, server)
+	expect.EqualsInt("RequestCount", 1, requestCount)
+}
+
+// Context deadline expires while waiting for deployment.
+func TestClient_WithContext_DeadlineExceeded_WaitForDeploy(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		test.Fatalf("Unexpected request to '%s'.", request.URL.String())
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithBaseAddress(testServer.URL, "user1", "password")
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resource, err := client.WithContext(ctx).WaitForDeploy(ResourceTypeServer, "5a32d6e4-9707-4813-a269-56ab4d989f4d", 1*time.Minute)
+	expect.IsTrue("IsOperationCancelledError", IsOperationCancelledError(err))
+	expect.IsTrue("errors.Is(err, context.DeadlineExceeded)", errors.Is(err, context.DeadlineExceeded))
+	expect.IsTrue("Resource is nil", resource == nil)
+}
+
+// Client is cancelled (via Cancel) while operations are in progress on other goroutines.
+func TestClient_Cancel_Concurrent(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, getServerTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithBaseAddress(testServer.URL, "user1", "password")
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		for index := 0; index < 10; index++ {
+			client.WithContext(context.Background()).GetServer("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+		}
+	}()
+
+	client.Cancel()
+	<-done
+
+	_, err := client.GetServer("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+	expect.IsTrue("IsOperationCancelledError", IsOperationCancelledError(err))
+
+	client.Reset()
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	_, err = client.GetServer("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+	if err != nil {
+		test.Fatal(err)
+	}
+}
@@ -3,15 +3,17 @@ package compute
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute/requests"
@@ -20,16 +22,37 @@ import (
 
 // Client is the client for Dimension Data's cloud compute API.
 type Client struct {
+	*clientState
+
+	// The context (if any) to which the client's operations are bound.
+	context context.Context
+
+	// Additional resources (if any) that the client's mutating operations affect (see Client.WithLockKeys).
+	lockKeys []LockKey
+}
+
+// clientState represents the state shared between a Client and any context-bound copies of it (see Client.WithContext).
+type clientState struct {
 	baseAddress              string
-	username                 string
-	password                 string
-	maxRetryCount            int
-	retryDelay               time.Duration
+	credentialsProvider      CredentialsProvider
+	retryPolicy              atomic.Value // retryPolicyHolder (read without stateLock, since GetAccount holds it while executing requests)
 	stateLock                *sync.Mutex
 	httpClient               *http.Client
 	account                  *Account
-	isCancellationRequested  bool
-	isExtendedLoggingEnabled bool
+	isCancellationRequested  int32        // Accessed atomically (read without stateLock, since GetAccount holds it while executing requests).
+	isExtendedLoggingEnabled int32        // Accessed atomically.
+	logger                   atomic.Value // loggerHolder
+	rateLimiters             atomic.Value // rateLimitersHolder
+	instrumentation          atomic.Value // instrumentationHolder
+	lockManager              atomic.Value // lockManagerHolder
+	waiter                   atomic.Value // waiterHolder
+	responseCache            atomic.Value // responseCacheHolder
+	apiVersionOverrides      atomic.Value // apiVersionOverridesHolder
+	pinnedAPIVersion         int32        // Accessed atomically.
+	dryRun                   atomic.Value // dryRunHolder
+	shutdownGracePeriod      int64        // Accessed atomically.
+	userAgent                string
+	defaultPageSize          int
 }
 
 // NewClient creates a new cloud compute API client.
@@ -43,28 +66,110 @@ func NewClient(region string, username string, password string) *Client {
 // NewClientWithBaseAddress creates a new cloud compute API client using a custom end-point base address.
 // baseAddress is the base URL of the CloudControl API end-point.
 func NewClientWithBaseAddress(baseAddress string, username string, password string) *Client {
+	return NewClientWithOptions("", username, password,
+		WithBaseAddress(baseAddress),
+	)
+}
+
+// NewClientWithOptions creates a new cloud compute API client, customised using the specified options.
+// region is the cloud compute region identifier (ignored if the WithBaseAddress option is specified).
+// username and password are ignored if the WithCredentialsProvider option is specified.
+func NewClientWithOptions(region string, username string, password string, options ...Option) *Client {
+	clientOptions := &clientOptions{
+		baseAddress: fmt.Sprintf("https://api-%s.dimensiondata.com", region),
+	}
+	for _, option := range options {
+		option(clientOptions)
+	}
+
+	credentialsProvider := clientOptions.credentialsProvider
+	if credentialsProvider == nil {
+		credentialsProvider = NewStaticCredentialsProvider(username, password)
+	}
+
+	client := &Client{
+		clientState: &clientState{
+			baseAddress:         clientOptions.baseAddress,
+			credentialsProvider: credentialsProvider,
+			stateLock:           &sync.Mutex{},
+			httpClient:          clientOptions.newHTTPClient(),
+			userAgent:           clientOptions.userAgent(),
+			defaultPageSize:     clientOptions.defaultPageSize,
+		},
+	}
+	if clientOptions.retryPolicy != nil {
+		client.SetRetryPolicy(clientOptions.retryPolicy)
+	}
+	if clientOptions.logger != nil {
+		client.SetLogger(clientOptions.logger)
+	}
+	if clientOptions.instrumentation != nil {
+		client.SetInstrumentation(clientOptions.instrumentation)
+	}
+	if clientOptions.lockManager != nil {
+		client.SetLockManager(clientOptions.lockManager)
+	}
+	if clientOptions.waiter != nil {
+		client.SetWaiter(clientOptions.waiter)
+	}
+	if clientOptions.responseCache != nil {
+		client.SetResponseCache(clientOptions.responseCache)
+	}
+	if clientOptions.apiVersion != 0 {
+		client.SetAPIVersion(clientOptions.apiVersion)
+	}
+	if clientOptions.apiVersionOverrides != nil {
+		client.SetAPIVersionOverrides(clientOptions.apiVersionOverrides)
+	}
+	if clientOptions.dryRunPlan != nil {
+		client.SetDryRun(clientOptions.dryRunPlan)
+	}
+	if clientOptions.shutdownGracePeriod > 0 {
+		client.SetShutdownGracePeriod(clientOptions.shutdownGracePeriod)
+	}
+	if clientOptions.rateLimiters != nil {
+		client.rateLimiters.Store(*clientOptions.rateLimiters)
+	}
+
 	_, isExtendedLoggingEnabled := os.LookupEnv("MCP_EXTENDED_LOGGING")
+	if isExtendedLoggingEnabled {
+		client.EnableExtendedLogging()
+	}
+
+	return client
+}
+
+// WithContext creates a copy of the client whose operations are bound to the specified context.
+//
+// The copy shares configuration and cached state (such as account details) with the original client, but cancellation of its context (or expiry of the context's deadline) only affects operations performed using the copy.
+func (client *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
 
 	return &Client{
-		baseAddress,
-		username,
-		password,
-		0,
-		0 * time.Second,
-		&sync.Mutex{},
-		&http.Client{},
-		nil,
-		false, // isCancellationRequested
-		isExtendedLoggingEnabled,
+		clientState: client.clientState,
+		context:     ctx,
+		lockKeys:    client.lockKeys,
+	}
+}
+
+// Context retrieves the context to which the client's operations are bound.
+//
+// Returns context.Background() if the client is not bound to a context.
+func (client *Client) Context() context.Context {
+	if client.context == nil {
+		return context.Background()
 	}
+
+	return client.context
 }
 
 // Cancel cancels all pending WaitForXXX or HTTP request operations.
+//
+// This affects all operations performed using the client (and any copies created using WithContext) until Reset is called; to cancel individual operations, use WithContext instead.
 func (client *Client) Cancel() {
-	client.stateLock.Lock()
-	defer client.stateLock.Unlock()
-
-	client.isCancellationRequested = true
+	atomic.StoreInt32(&client.isCancellationRequested, 1)
 }
 
 // Reset clears all cached data from the Client and resets cancellation (if required).
@@ -73,46 +178,94 @@ func (client *Client) Reset() {
 	defer client.stateLock.Unlock()
 
 	client.account = nil
-	client.isCancellationRequested = false
+	atomic.StoreInt32(&client.isCancellationRequested, 0)
 }
 
-// EnableExtendedLogging enables logging of HTTP requests and responses.
+// EnableExtendedLogging enables logging of HTTP requests and responses (at LogLevelDebug, with sensitive data redacted).
 func (client *Client) EnableExtendedLogging() {
-	client.stateLock.Lock()
-	defer client.stateLock.Unlock()
-
-	client.isExtendedLoggingEnabled = true
+	atomic.StoreInt32(&client.isExtendedLoggingEnabled, 1)
 }
 
 // DisableExtendedLogging disables logging of HTTP requests and responses.
 func (client *Client) DisableExtendedLogging() {
-	client.stateLock.Lock()
-	defer client.stateLock.Unlock()
-
-	client.isExtendedLoggingEnabled = false
+	atomic.StoreInt32(&client.isExtendedLoggingEnabled, 0)
 }
 
 // IsExtendedLoggingEnabled determines if logging of HTTP requests and responses is enabled.
 func (client *Client) IsExtendedLoggingEnabled() bool {
-	return client.isExtendedLoggingEnabled
+	return atomic.LoadInt32(&client.isExtendedLoggingEnabled) != 0
 }
 
-// ConfigureRetry configures the client's retry facility.
+// ConfigureRetry configures the client's retry facility to use an ExponentialBackoffRetryPolicy.
 // Set maxRetryCount to 0 (the default) to disable retry.
+//
+// retryDelay is the delay before the first retry (subsequent retries use an exponentially-increasing delay).
+//
+// Note that, if a request is retried but CloudControl still responds with a transient error (e.g. RESOURCE_BUSY), the operation returns a RetryError instead of handling the response itself.
 func (client *Client) ConfigureRetry(maxRetryCount int, retryDelay time.Duration) {
-	client.stateLock.Lock()
-	defer client.stateLock.Unlock()
+	if maxRetryCount <= 0 {
+		client.SetRetryPolicy(nil)
 
-	if maxRetryCount < 0 {
-		maxRetryCount = 0
+		return
 	}
 
 	if retryDelay < 0*time.Second {
 		retryDelay = 5 * time.Second
 	}
 
-	client.maxRetryCount = maxRetryCount
-	client.retryDelay = retryDelay
+	client.SetRetryPolicy(
+		NewExponentialBackoffRetryPolicy(maxRetryCount, retryDelay),
+	)
+}
+
+// SetRetryPolicy configures the policy used to determine whether (and when) failed requests are retried.
+// Set retryPolicy to nil (the default) to disable retry.
+//
+// Note that, if a request is retried but CloudControl still responds with a transient error (e.g. RESOURCE_BUSY), the operation returns a RetryError instead of handling the response itself.
+func (client *Client) SetRetryPolicy(retryPolicy RetryPolicy) {
+	client.retryPolicy.Store(retryPolicyHolder{retryPolicy})
+}
+
+// getRetryPolicy retrieves the client's retry policy (if any).
+func (client *Client) getRetryPolicy() RetryPolicy {
+	holder, ok := client.retryPolicy.Load().(retryPolicyHolder)
+	if !ok {
+		return nil
+	}
+
+	return holder.policy
+}
+
+// retryPolicyHolder enables storage of a (possibly nil) RetryPolicy in an atomic.Value.
+type retryPolicyHolder struct {
+	policy RetryPolicy
+}
+
+// checkCancellation determines whether cancellation has been requested (either via Cancel or via the client's context).
+//
+// Returns an OperationCancelledError if cancellation has been requested; otherwise, nil.
+func (client *Client) checkCancellation(operationDescription string) error {
+	if atomic.LoadInt32(&client.isCancellationRequested) != 0 {
+		client.logInfo("Client indicates that cancellation of pending requests has been requested.")
+
+		return &OperationCancelledError{
+			OperationDescription: operationDescription,
+		}
+	}
+
+	contextErr := client.Context().Err()
+	if contextErr != nil {
+		client.logInfo("Client context indicates that cancellation of pending requests has been requested.",
+			logField(LogFieldError, contextErr),
+		)
+
+		return &OperationCancelledError{
+			OperationDescription: operationDescription,
+			Reason:               contextErr,
+		}
+	}
+
+	return nil
 }
 
 // getOrganizationID gets the current user's organisation Id.
@@ -126,9 +279,26 @@ func (client *Client) getOrganizationID() (organizationID string, err error) {
 }
 
 // executeRequest performs the specified request and returns the entire response body, together with the HTTP status code.
+//
+// If the client has a response cache, cacheable responses may be returned from it (see Client.SetResponseCache).
 func (client *Client) executeRequest(request *http.Request) (responseBody []byte, statusCode int, err error) {
-	haveRequestBody := request.Body != nil
+	dryRunPlan := client.getDryRunPlan()
+	if dryRunPlan != nil && isMutatingRequest(request) {
+		client.logInfo("Dry run; recording request instead of performing it.", logField(LogFieldMethod, request.Method), logField(LogFieldURL, request.URL.String()))
+
+		return dryRunPlan.record(request)
+	}
+
+	responseCache := client.getResponseCache()
+	if responseCache != nil {
+		return responseCache.execute(client, request)
+	}
 
+	return client.executeUncachedRequest(request)
+}
+
+// executeUncachedRequest performs the specified request (bypassing the client's response cache, if any) and returns the entire response body, together with the HTTP status code.
+func (client *Client) executeUncachedRequest(request *http.Request) (responseBody []byte, statusCode int, err error) {
 	// Cache request to enable retry.
 	var snapshot *requests.Snapshot
 	snapshot, err = requests.CreateSnapshotAndClose(request)
@@ -137,107 +307,139 @@ func (client *Client) executeRequest(request *http.Request) (responseBody []byte
 	}
 
 	if client.IsExtendedLoggingEnabled() {
-		var requestBody []byte
-		requestBody = snapshot.GetCachedRequestBody()
-
-		log.Printf("Invoking '%s' request to '%s'...",
-			request.Method,
-			request.URL.String(),
+		client.logDebug("Invoking request...",
+			logField(LogFieldMethod, request.Method),
+			logField(LogFieldURL, request.URL.String()),
+			logField(LogFieldHeaders, redactHeaders(request.Header)),
+			logField(LogFieldBody, redactBody(snapshot.GetCachedRequestBody())),
 		)
-
-		if len(requestBody) > 0 {
-			log.Printf("Request body: '%s'", string(requestBody))
-		} else {
-			switch request.Method {
-			case http.MethodGet:
-			case http.MethodHead:
-				break
-			default:
-				log.Printf("Request body is empty.")
-			}
-		}
 	}
 
-	request, err = snapshot.Copy()
+	operationDescription := fmt.Sprintf("%s of '%s'",
+		request.Method,
+		request.URL.String(),
+	)
+	retryPolicy := client.getRetryPolicy()
+
+	// Serialise mutating operations on the same resource (if required).
+	var unlockResources func(statusCode int, responseBody []byte, err error)
+	unlockResources, err = client.lockResources(request.Method, request.URL.Path, snapshot.GetCachedRequestBody(), operationDescription)
 	if err != nil {
 		return
 	}
-	if haveRequestBody {
-		defer request.Body.Close()
-	}
-
-	response, err := client.httpClient.Do(request)
-	if err != nil {
-		log.Printf("Unexpected error while performing '%s' request to '%s': %s.",
-			request.Method,
-			request.URL.String(),
-			err,
-		)
+	defer func() {
+		unlockResources(statusCode, responseBody, err)
+	}()
 
-		for retryCount := 0; retryCount < client.maxRetryCount; retryCount++ {
-			if client.IsExtendedLoggingEnabled() {
-				log.Printf("Retrying '%s' request to '%s' (%d retries remaining)...",
-					request.Method,
-					request.URL.String(),
-					retryCount-client.maxRetryCount,
-				)
-			}
+	var failure *RequestFailure
+	for attempt := 1; ; attempt++ {
+		err = client.checkCancellation(operationDescription)
+		if err != nil {
+			return
+		}
 
-			if client.isCancellationRequested {
-				log.Printf("Client indicates that cancellation of pending requests has been requested.")
+		var releaseRateLimit func()
+		releaseRateLimit, err = client.waitForRateLimit(request.Method, operationDescription)
+		if err != nil {
+			return
+		}
 
-				err = &OperationCancelledError{
-					OperationDescription: fmt.Sprintf("%s of '%s'",
-						request.Method,
-						request.RequestURI,
-					),
-				}
+		responseBody, statusCode, err = client.performRequest(snapshot, attempt)
+		releaseRateLimit()
+		if err != nil {
+			// Request failed because its context was cancelled or timed out; don't retry.
+			if cancellationErr := client.checkCancellation(operationDescription); cancellationErr != nil {
+				err = cancellationErr
 
 				return
 			}
 
-			// Try again with a fresh request.
-			request, err = snapshot.Copy()
-			if err != nil {
-				return
-			}
-			if haveRequestBody {
-				defer request.Body.Close()
-			}
+			client.logError("Unexpected error while performing request.",
+				logField(LogFieldMethod, request.Method),
+				logField(LogFieldURL, request.URL.String()),
+				logField(LogFieldAttempt, attempt),
+				logField(LogFieldError, err),
+			)
+		}
 
-			response, err = client.httpClient.Do(request)
-			if err != nil {
-				if client.IsExtendedLoggingEnabled() {
-					log.Printf("Still failing - '%s' request to '%s': %s.",
-						request.Method,
-						request.URL.String(),
-						err,
-					)
-				}
-
-				continue
-			}
+		if retryPolicy == nil {
+			break
+		}
 
-			if client.IsExtendedLoggingEnabled() {
-				log.Printf("'%s' request to '%s' succeeded.",
-					request.Method,
-					request.URL.String(),
-				)
-			}
+		failure = newRequestFailure(request, attempt, statusCode, responseBody, err)
+		if failure == nil {
+			break
+		}
 
+		retryDelay, shouldRetry := retryPolicy.ShouldRetry(failure)
+		if !shouldRetry {
 			break
 		}
 
-		if err != nil {
-			err = errors.Wrapf(err, "Unexpected error while performing '%s' request to '%s': %s",
-				request.Method,
-				request.URL.String(),
-				err,
-			)
+		client.logWarn("Retrying request...",
+			logField(LogFieldMethod, request.Method),
+			logField(LogFieldURL, request.URL.String()),
+			logField(LogFieldStatus, statusCode),
+			logField(LogFieldAttempt, attempt),
+			logField(LogFieldDuration, retryDelay),
+			logField(LogFieldError, failure.Err),
+		)
 
+		err = client.waitForRetry(retryDelay, operationDescription)
+		if err != nil {
 			return
 		}
 	}
+
+	if err != nil {
+		err = errors.Wrapf(err, "Unexpected error while performing '%s' request to '%s': %s",
+			request.Method,
+			request.URL.String(),
+			err,
+		)
+		if failure != nil && failure.Attempt > 1 {
+			err = &RetryError{
+				Attempts: failure.Attempt,
+				Err:      err,
+			}
+		}
+
+		return
+	}
+
+	// Request was retried, but CloudControl is still responding with a transient error (e.g. RESOURCE_BUSY).
+	if failure != nil && failure.Attempt > 1 && failure.IsTransient() {
+		err = &RetryError{
+			Attempts: failure.Attempt,
+			Err:      failure.Err,
+		}
+	}
+
+	return
+}
+
+// performRequest performs a single attempt at the request represented by the specified snapshot and returns the entire response body, together with the HTTP status code.
+// attempt is the attempt number (used for logging).
+func (client *Client) performRequest(snapshot *requests.Snapshot, attempt int) (responseBody []byte, statusCode int, err error) {
+	request, err := snapshot.CopyWithContext(client.Context())
+	if err != nil {
+		return
+	}
+	if request.Body != nil {
+		defer request.Body.Close()
+	}
+
+	ctx, finishRequest := client.startRequest(request, attempt)
+	defer func() {
+		finishRequest(statusCode, responseBody, err)
+	}()
+	request = request.WithContext(ctx)
+
+	startTime := time.Now()
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return
+	}
 	defer response.Body.Close()
 
 	statusCode = response.StatusCode
@@ -245,19 +447,91 @@ func (client *Client) executeRequest(request *http.Request) (responseBody []byte
 	responseBody, err = ioutil.ReadAll(response.Body)
 	if err != nil {
 		err = errors.Wrapf(err, "error reading response body for '%s'", request.URL.String())
+
+		return
 	}
 
-	if client.IsExtendedLoggingEnabled() {
-		log.Printf("Response from '%s' (%d): '%s'",
-			request.URL.String(),
-			statusCode,
-			string(responseBody),
-		)
+	if client.getLogger().IsEnabled(LogLevelDebug) {
+		fields := []LogField{
+			logField(LogFieldMethod, request.Method),
+			logField(LogFieldURL, request.URL.String()),
+			logField(LogFieldStatus, statusCode),
+			logField(LogFieldAttempt, attempt),
+			logField(LogFieldDuration, time.Since(startTime)),
+		}
+		if requestID := getResponseRequestID(responseBody); requestID != "" {
+			fields = append(fields, logField(LogFieldRequestID, requestID))
+		}
+		if client.IsExtendedLoggingEnabled() {
+			fields = append(fields, logField(LogFieldBody, redactBody(responseBody)))
+		}
+
+		client.logDebug("Received response.", fields...)
 	}
 
 	return
 }
 
+// waitForRetry waits for the specified delay before retrying a request.
+//
+// Returns an OperationCancelledError if cancellation is requested while waiting.
+func (client *Client) waitForRetry(delay time.Duration, operationDescription string) error {
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-client.Context().Done():
+		}
+	}
+
+	return client.checkCancellation(operationDescription)
+}
+
+// newRequestFailure creates a RequestFailure representing the outcome of an attempt to perform the specified request.
+//
+// Returns nil if the attempt was successful.
+func newRequestFailure(request *http.Request, attempt int, statusCode int, responseBody []byte, err error) *RequestFailure {
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		return nil
+	}
+
+	failure := &RequestFailure{
+		Method:     request.Method,
+		URL:        request.URL.String(),
+		Attempt:    attempt,
+		StatusCode: statusCode,
+		Err:        err,
+	}
+	if err != nil {
+		return failure
+	}
+
+	var apiResponse APIResponse
+	if strings.Contains(request.Header.Get("Accept"), "xml") {
+		apiResponse, err = readAPIResponseV1(responseBody, statusCode)
+	} else {
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+	}
+	if err != nil {
+		failure.Err = fmt.Errorf("request failed with status code %d", statusCode)
+
+		return failure
+	}
+
+	failure.Err = newAPIError(
+		fmt.Sprintf("Request failed with status code %d (%s): %s",
+			statusCode,
+			apiResponse.GetResponseCode(),
+			apiResponse.GetMessage(),
+		),
+		apiResponse,
+	)
+
+	return failure
+}
+
 // Create a basic request for the compute API (V1, XML).
 func (client *Client) newRequestV1(relativeURI string, method string, body interface{}) (*http.Request, error) {
 	requestURI := fmt.Sprintf("%s/oec/0.9/%s", client.baseAddress, relativeURI)
@@ -273,13 +547,19 @@ func (client *Client) newRequestV1(relativeURI string, method string, body inter
 		return nil, err
 	}
 
-	request, err = http.NewRequest(method, requestURI, bodyReader)
+	credentials, err := client.getCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err = http.NewRequestWithContext(client.Context(), method, requestURI, bodyReader)
 	if err != nil {
 		return nil, err
 	}
 
-	request.SetBasicAuth(client.username, client.password)
+	request.SetBasicAuth(credentials.Username, credentials.Password)
 	request.Header.Set("Accept", "text/xml; charset=utf-8")
+	request.Header.Set("User-Agent", client.userAgent)
 
 	if bodyReader != nil {
 		request.Header.Set("Content-Type", "text/xml")
@@ -288,44 +568,14 @@ func (client *Client) newRequestV1(relativeURI string, method string, body inter
 	return request, nil
 }
 
-// Create a basic request for the compute API (V2.2, JSON).
-func (client *Client) newRequestV22(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	return client.newRequestV2x(2, relativeURI, method, body)
-}
-
-// Create a basic request for the compute API (V2.3, JSON).
-func (client *Client) newRequestV23(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	return client.newRequestV2x(3, relativeURI, method, body)
-}
-
-// Create a basic request for the compute API (V2.4, JSON).
-func (client *Client) newRequestV24(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	return client.newRequestV2x(4, relativeURI, method, body)
-}
-
-// Create a basic request for the compute API (V2.5, JSON).
-func (client *Client) newRequestV25(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	return client.newRequestV2x(5, relativeURI, method, body)
+// Create a basic request for the compute API (V2.x, JSON), using the API version configured for the target operation (see Client.APIVersion).
+func (client *Client) newRequestV2(relativeURI string, method string, body interface{}) (*http.Request, error) {
+	return client.newRequestV2ForOperation(getAPIOperation(relativeURI), relativeURI, method, body)
 }
 
-// Create a basic request for the compute API (V2.5, JSON).
-func (client *Client) newRequestV26(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	return client.newRequestV2x(6, relativeURI, method, body)
-}
-
-// Create a basic request for the compute API (V2.9, JSON).
-func (client *Client) newRequestV29(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	return client.newRequestV2x(9, relativeURI, method, body)
-}
-
-// Create a basic request for the compute API (V2.9, JSON).
-func (client *Client) newRequestV210(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	return client.newRequestV2x(10, relativeURI, method, body)
-}
-
-// Create a basic request for the compute API (V2.13, JSON).
-func (client *Client) newRequestV213(relativeURI string, method string, body interface{}) (*http.Request, error) {
-	return client.newRequestV2x(13, relativeURI, method, body)
+// Create a basic request for the compute API (V2.x, JSON), using the API version configured for the specified operation (e.g. "server/server#list").
+func (client *Client) newRequestV2ForOperation(operation string, relativeURI string, method string, body interface{}) (*http.Request, error) {
+	return client.newRequestV2x(client.APIVersion(operation), relativeURI, method, body)
 }
 
 // Create a basic request for the compute API (V2.x, JSON).
@@ -343,13 +593,19 @@ func (client *Client) newRequestV2x(minorVersion int, relativeURI string, method
 		return nil, err
 	}
 
-	request, err = http.NewRequest(method, requestURI, bodyReader)
+	credentials, err := client.getCredentials()
 	if err != nil {
 		return nil, err
 	}
 
-	request.SetBasicAuth(client.username, client.password)
+	request, err = http.NewRequestWithContext(client.Context(), method, requestURI, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	request.SetBasicAuth(credentials.Username, credentials.Password)
 	request.Header.Add("Accept", "application/json")
+	request.Header.Set("User-Agent", client.userAgent)
 
 	if bodyReader != nil {
 		request.Header.Set("Content-Type", "application/json")
@@ -368,6 +624,8 @@ func readAPIResponseV1(responseBody []byte, statusCode int) (apiResponse *APIRes
 		return
 	}
 
+	apiResponse.HTTPStatusCode = statusCode
+
 	if len(apiResponse.Result) == 0 {
 		apiResponse.Result = "UNKNOWN_RESULT"
 	}
@@ -389,6 +647,8 @@ func readAPIResponseAsJSON(responseBody []byte, statusCode int) (apiResponse *AP
 		return
 	}
 
+	apiResponse.HTTPStatusCode = statusCode
+
 	if len(apiResponse.ResponseCode) == 0 {
 		apiResponse.ResponseCode = "UNKNOWN_RESPONSE_CODE"
 	}
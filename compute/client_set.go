@@ -0,0 +1,266 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Well-known CloudControl region identifiers.
+const (
+	// RegionAustralia is the identifier for the Australian CloudControl region.
+	RegionAustralia = "au"
+
+	// RegionNorthAmerica is the identifier for the North American CloudControl region.
+	RegionNorthAmerica = "na"
+
+	// RegionEurope is the identifier for the European CloudControl region.
+	RegionEurope = "eu"
+
+	// RegionAsiaPacific is the identifier for the Asia-Pacific CloudControl region.
+	RegionAsiaPacific = "ap"
+
+	// RegionMiddleEastAfrica is the identifier for the Middle East and Africa CloudControl region.
+	RegionMiddleEastAfrica = "mea"
+)
+
+// AllRegions creates a list of the identifiers for all well-known CloudControl regions.
+func AllRegions() []string {
+	return []string{
+		RegionAustralia,
+		RegionNorthAmerica,
+		RegionEurope,
+		RegionAsiaPacific,
+		RegionMiddleEastAfrica,
+	}
+}
+
+// ClientSet holds a Client for each of several CloudControl regions (sharing the same credentials, transport and options), and performs operations across all of them.
+//
+// Options that create per-client state (e.g. WithRateLimit) are applied separately to each region's client.
+type ClientSet struct {
+	clientsByRegion map[string]*Client
+}
+
+// NewClientSet creates a new ClientSet with a Client for each of the specified regions (see AllRegions).
+//
+// username and password are ignored if the WithCredentialsProvider option is specified, and the WithBaseAddress option is ignored.
+func NewClientSet(regions []string, username string, password string, options ...Option) *ClientSet {
+	baseAddresses := make(map[string]string, len(regions))
+	for _, region := range regions {
+		baseAddresses[region] = fmt.Sprintf("https://api-%s.dimensiondata.com", region)
+	}
+
+	return NewClientSetWithBaseAddresses(baseAddresses, username, password, options...)
+}
+
+// NewClientSetWithBaseAddresses creates a new ClientSet with a Client for each of the specified regions, using custom end-point base addresses (keyed by region).
+//
+// username and password are ignored if the WithCredentialsProvider option is specified, and the WithBaseAddress option is ignored.
+func NewClientSetWithBaseAddresses(baseAddresses map[string]string, username string, password string, options ...Option) *ClientSet {
+	// All clients share the same credentials provider (unless another one is specified via options).
+	sharedOptions := []Option{
+		WithCredentialsProvider(NewStaticCredentialsProvider(username, password)),
+	}
+	sharedOptions = append(sharedOptions, options...)
+
+	clientSet := &ClientSet{
+		clientsByRegion: make(map[string]*Client, len(baseAddresses)),
+	}
+	for region, baseAddress := range baseAddresses {
+		regionOptions := append(sharedOptions[:len(sharedOptions):len(sharedOptions)], WithBaseAddress(baseAddress))
+		clientSet.clientsByRegion[region] = NewClientWithOptions(region, "", "", regionOptions...)
+	}
+
+	return clientSet
+}
+
+// Regions retrieves the identifiers for the regions in the client set (in alphabetical order).
+func (clientSet *ClientSet) Regions() []string {
+	regions := make([]string, 0, len(clientSet.clientsByRegion))
+	for region := range clientSet.clientsByRegion {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	return regions
+}
+
+// Client retrieves the Client for the specified region (or nil, if the client set does not include that region).
+func (clientSet *ClientSet) Client(region string) *Client {
+	return clientSet.clientsByRegion[region]
+}
+
+// WithContext creates a copy of the client set whose clients are bound to the specified context (see Client.WithContext).
+func (clientSet *ClientSet) WithContext(ctx context.Context) *ClientSet {
+	clientSetWithContext := &ClientSet{
+		clientsByRegion: make(map[string]*Client, len(clientSet.clientsByRegion)),
+	}
+	for region, client := range clientSet.clientsByRegion {
+		clientSetWithContext.clientsByRegion[region] = client.WithContext(ctx)
+	}
+
+	return clientSetWithContext
+}
+
+// ForEachRegion concurrently calls the specified function with the Client for each region in the client set.
+//
+// Returns a RegionErrors containing the errors (if any) returned for each region.
+func (clientSet *ClientSet) ForEachRegion(action func(region string, client *Client) error) error {
+	var (
+		waitGroup sync.WaitGroup
+		stateLock sync.Mutex
+		errs      RegionErrors
+	)
+	for region, client := range clientSet.clientsByRegion {
+		waitGroup.Add(1)
+		go func(region string, client *Client) {
+			defer waitGroup.Done()
+
+			err := action(region, client)
+			if err != nil {
+				stateLock.Lock()
+				errs = append(errs, &RegionError{Region: region, Err: err})
+				stateLock.Unlock()
+			}
+		}(region, client)
+	}
+	waitGroup.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(index1 int, index2 int) bool {
+		return errs[index1].Region < errs[index2].Region
+	})
+
+	return errs
+}
+
+// AllDatacenters retrieves all data centres in every region (keyed by region).
+//
+// If any regions fail, the results for the remaining regions are returned, together with a RegionErrors.
+func (clientSet *ClientSet) AllDatacenters() (datacentersByRegion map[string][]Datacenter, err error) {
+	var stateLock sync.Mutex
+	datacentersByRegion = make(map[string][]Datacenter)
+
+	err = clientSet.ForEachRegion(func(region string, client *Client) error {
+		datacenters, err := client.AllDatacenters()
+		if err != nil {
+			return err
+		}
+
+		stateLock.Lock()
+		defer stateLock.Unlock()
+		datacentersByRegion[region] = datacenters
+
+		return nil
+	})
+
+	return
+}
+
+// AllNetworkDomains retrieves all network domains in every region (keyed by region).
+//
+// If any regions fail, the results for the remaining regions are returned, together with a RegionErrors.
+func (clientSet *ClientSet) AllNetworkDomains() (networkDomainsByRegion map[string][]NetworkDomain, err error) {
+	var stateLock sync.Mutex
+	networkDomainsByRegion = make(map[string][]NetworkDomain)
+
+	err = clientSet.ForEachRegion(func(region string, client *Client) error {
+		networkDomains, err := client.AllNetworkDomains()
+		if err != nil {
+			return err
+		}
+
+		stateLock.Lock()
+		defer stateLock.Unlock()
+		networkDomainsByRegion[region] = networkDomains
+
+		return nil
+	})
+
+	return
+}
+
+// AllServers retrieves all servers (in all network domains) in every region (keyed by region).
+//
+// This performs one request per page of network domains, plus one request per page of servers in each network domain.
+// If any regions fail, the results for the remaining regions are returned, together with a RegionErrors.
+func (clientSet *ClientSet) AllServers() (serversByRegion map[string][]Server, err error) {
+	var stateLock sync.Mutex
+	serversByRegion = make(map[string][]Server)
+
+	err = clientSet.ForEachRegion(func(region string, client *Client) error {
+		networkDomains, err := client.AllNetworkDomains()
+		if err != nil {
+			return err
+		}
+
+		var servers []Server
+		for _, networkDomain := range networkDomains {
+			networkDomainServers, err := client.AllServersInNetworkDomain(networkDomain.ID)
+			if err != nil {
+				return err
+			}
+			servers = append(servers, networkDomainServers...)
+		}
+
+		stateLock.Lock()
+		defer stateLock.Unlock()
+		serversByRegion[region] = servers
+
+		return nil
+	})
+
+	return
+}
+
+// RegionError represents an error encountered while performing an operation in a specific region.
+type RegionError struct {
+	// The region identifier.
+	Region string
+
+	// The underlying error.
+	Err error
+}
+
+// Error creates a string representation of the error.
+func (err *RegionError) Error() string {
+	return fmt.Sprintf("region '%s': %s", err.Region, err.Err)
+}
+
+// Unwrap retrieves the underlying error.
+func (err *RegionError) Unwrap() error {
+	return err.Err
+}
+
+var _ error = &RegionError{}
+
+// RegionErrors represents the errors encountered while performing an operation across multiple regions (see ClientSet.ForEachRegion).
+type RegionErrors []*RegionError
+
+// Error creates a string representation of the errors.
+func (errs RegionErrors) Error() string {
+	messages := make([]string, len(errs))
+	for index, err := range errs {
+		messages[index] = err.Error()
+	}
+
+	return fmt.Sprintf("%d region(s) failed: %s", len(errs), strings.Join(messages, "; "))
+}
+
+// ForRegion retrieves the error (if any) encountered in the specified region.
+func (errs RegionErrors) ForRegion(region string) error {
+	for _, err := range errs {
+		if err.Region == region {
+			return err.Err
+		}
+	}
+
+	return nil
+}
+
+var _ error = RegionErrors{}
@@ -0,0 +1,118 @@
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Cross-region queries are performed concurrently, returning results for regions that succeed and errors for regions that fail.
+func TestClientSet_AllServers(test *testing.T) {
+	expect := expect(test)
+
+	auServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		if strings.HasSuffix(request.URL.Path, "/network/networkDomain") {
+			fmt.Fprint(writer, clientSetTestListNetworkDomainsResponse)
+
+			return
+		}
+
+		expect.EqualsString("Request.networkDomainId", "75ab2a57-b75e-4ec6-945a-e8c60164fdf6", request.URL.Query().Get("networkDomainId"))
+		fmt.Fprint(writer, clientSetTestListServersResponse)
+	}))
+	defer auServer.Close()
+
+	naServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusBadRequest)
+
+		fmt.Fprint(writer, clientSetTestErrorResponse)
+	}))
+	defer naServer.Close()
+
+	clientSet := NewClientSetWithBaseAddresses(map[string]string{
+		RegionAustralia:    auServer.URL,
+		RegionNorthAmerica: naServer.URL,
+	}, "user1", "password")
+
+	regions := clientSet.Regions()
+	expect.EqualsInt("Regions size", 2, len(regions))
+	expect.EqualsString("Regions[0]", RegionAustralia, regions[0])
+	expect.EqualsString("Regions[1]", RegionNorthAmerica, regions[1])
+	expect.IsTrue("Client(eu) is nil", clientSet.Client(RegionEurope) == nil)
+
+	for _, region := range regions {
+		clientSet.Client(region).setAccount(&Account{
+			OrganizationID: "dummy-organization-id",
+		})
+	}
+	expect.EqualsString("Client(au).baseAddress", auServer.URL, clientSet.Client(RegionAustralia).baseAddress)
+
+	serversByRegion, err := clientSet.AllServers()
+	expect.IsTrue("Error is not nil", err != nil)
+
+	regionErrors, ok := err.(RegionErrors)
+	expect.IsTrue("Error is RegionErrors", ok)
+	expect.EqualsInt("RegionErrors size", 1, len(regionErrors))
+	expect.EqualsString("RegionErrors[0].Region", RegionNorthAmerica, regionErrors[0].Region)
+	expect.IsTrue("RegionErrors.ForRegion(na) is not nil", regionErrors.ForRegion(RegionNorthAmerica) != nil)
+	expect.IsTrue("RegionErrors.ForRegion(au) is nil", regionErrors.ForRegion(RegionAustralia) == nil)
+
+	expect.EqualsInt("ServersByRegion size", 1, len(serversByRegion))
+	auServers := serversByRegion[RegionAustralia]
+	expect.EqualsInt("ServersByRegion[au] size", 1, len(auServers))
+	expect.EqualsString("ServersByRegion[au][0].Name", "Server 1", auServers[0].Name)
+}
+
+var clientSetTestListNetworkDomainsResponse = `
+{
+	"networkDomain": [
+		{
+			"name": "Domain 1",
+			"description": "This is test domain 1",
+			"type": "ESSENTIALS",
+			"snatIpv4Address": "168.128.17.63",
+			"createTime": "2016-01-12T22:33:05.000Z",
+			"state": "NORMAL",
+			"id": "75ab2a57-b75e-4ec6-945a-e8c60164fdf6",
+			"datacenterId": "AU9"
+		}
+	],
+	"pageNumber": 1,
+	"pageCount": 1,
+	"totalCount": 1,
+	"pageSize": 250
+}
+`
+
+var clientSetTestListServersResponse = `
+{
+	"server": [
+		{
+			"id": "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+			"name": "Server 1",
+			"datacenterId": "AU9",
+			"state": "NORMAL",
+			"started": true
+		}
+	],
+	"pageNumber": 1,
+	"pageCount": 1,
+	"totalCount": 1,
+	"pageSize": 250
+}
+`
+
+var clientSetTestErrorResponse = `
+{
+	"operation": "LIST_NETWORK_DOMAINS",
+	"responseCode": "UNEXPECTED_ERROR",
+	"message": "An unexpected error has occurred.",
+	"requestId": "na9/2016-01-18T08:56:16/62f06368-c3fb-11e3-b29c-001517c4643e"
+}
+`
@@ -6,7 +6,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
 )
 
 /*
@@ -74,3 +79,70 @@ func readRequestBodyAsXML(request *http.Request, target interface{}) error {
 
 	return xml.Unmarshal(requestBody, target)
 }
+
+// serverOperationsTestServer is a fake CloudControl end-point for tests that perform a sequence of operations on a single server.
+type serverOperationsTestServer struct {
+	*httptest.Server
+
+	stateLock  sync.Mutex
+	operations []string
+}
+
+// newServerOperationsTestServer creates a new serverOperationsTestServer, and a client (customised using the specified options) that uses it.
+//
+// GET requests return the server as rendered by getServer.
+// POST requests are recorded (by operation name, e.g. "addDisk") and passed to performOperation, which updates the test's server state and returns the CloudControl response code (ResponseCodeInProgress, unless the operation is rejected).
+// getServer and performOperation are called while holding the test server's state lock.
+func newServerOperationsTestServer(test *testing.T, getServer func() string, performOperation func(operation string, requestBody []byte) string, options ...Option) (*serverOperationsTestServer, *Client) {
+	testServer := &serverOperationsTestServer{}
+	testServer.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		testServer.stateLock.Lock()
+		defer testServer.stateLock.Unlock()
+
+		writer.Header().Set("Content-Type", "application/json")
+
+		if request.Method == http.MethodGet {
+			writer.WriteHeader(http.StatusOK)
+			fmt.Fprint(writer, getServer())
+
+			return
+		}
+
+		requestBody, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			test.Error(err)
+		}
+		operation := request.URL.Path[strings.LastIndex(request.URL.Path, "/")+1:]
+		testServer.operations = append(testServer.operations, operation)
+
+		responseCode := performOperation(operation, requestBody)
+		if responseCode == ResponseCodeInProgress {
+			writer.WriteHeader(http.StatusOK)
+		} else {
+			writer.WriteHeader(http.StatusBadRequest)
+		}
+
+		// Operations that add a disk or network adapter return its Id.
+		fmt.Fprintf(writer, `{"operation": "%s", "responseCode": "%s", "message": "Request processed.", "info": [{"name": "diskId", "value": "disk-new"}, {"name": "nicId", "value": "nic-new"}], "requestId": "request-1"}`,
+			operation, responseCode,
+		)
+	}))
+
+	client := NewClientWithOptions("", "user1", "password", append([]Option{
+		WithBaseAddress(testServer.URL),
+		WithWaiter(&Waiter{InitialPollInterval: 5 * time.Millisecond}),
+	}, options...)...)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	return testServer, client
+}
+
+// Operations retrieves the names of the operations performed so far (comma-separated).
+func (testServer *serverOperationsTestServer) Operations() string {
+	testServer.stateLock.Lock()
+	defer testServer.stateLock.Unlock()
+
+	return strings.Join(testServer.operations, ",")
+}
@@ -84,6 +84,24 @@ type VirtualMachineCPU struct {
 	CoresPerSocket int    `json:"coresPerSocket,omitempty"`
 }
 
+// VirtualMachineVMwareTools represents the status of VMware Tools in a virtual machine.
+type VirtualMachineVMwareTools struct {
+	VersionStatus string `json:"versionStatus"`
+	RunningStatus string `json:"runningStatus"`
+	APIVersion    int    `json:"apiVersion"`
+}
+
+// IsRunning determines whether VMware Tools is running in the virtual machine.
+func (vmwareTools *VirtualMachineVMwareTools) IsRunning() bool {
+	return vmwareTools != nil && vmwareTools.RunningStatus == VMwareToolsRunningStatusRunning
+}
+
+// VirtualMachineVirtualHardware represents the virtual hardware version of a virtual machine.
+type VirtualMachineVirtualHardware struct {
+	Version  string `json:"version"`
+	UpToDate bool   `json:"upToDate"`
+}
+
 // VirtualMachineSCSIController represents the configuration for a SCSI controller in a virtual machine.
 type VirtualMachineSCSIController struct {
 	ID          string              `json:"id,omitempty"`
@@ -184,6 +202,49 @@ type VirtualMachineNetwork struct {
 	AdditionalNetworkAdapters []VirtualMachineNetworkAdapter `json:"additionalNic"`
 }
 
+// GetAdapters retrieves all of the network's adapters (the primary adapter first, followed by any additional adapters).
+func (network *VirtualMachineNetwork) GetAdapters() []*VirtualMachineNetworkAdapter {
+	adapters := []*VirtualMachineNetworkAdapter{&network.PrimaryAdapter}
+	for index := range network.AdditionalNetworkAdapters {
+		adapters = append(adapters, &network.AdditionalNetworkAdapters[index])
+	}
+
+	return adapters
+}
+
+// GetAdapterByID retrieves the network adapter (if any) with the specified Id.
+func (network *VirtualMachineNetwork) GetAdapterByID(id string) *VirtualMachineNetworkAdapter {
+	for _, adapter := range network.GetAdapters() {
+		if adapter.GetID() == id {
+			return adapter
+		}
+	}
+
+	return nil
+}
+
+// GetAdapterByKey retrieves the network adapter (if any) with the specified adapter key (CloudControl v2.4 and higher).
+func (network *VirtualMachineNetwork) GetAdapterByKey(key int) *VirtualMachineNetworkAdapter {
+	for _, adapter := range network.GetAdapters() {
+		if adapter.AdapterKey != nil && *adapter.AdapterKey == key {
+			return adapter
+		}
+	}
+
+	return nil
+}
+
+// GetAdaptersByVLANID retrieves the network adapters (if any) connected to the specified VLAN.
+func (network *VirtualMachineNetwork) GetAdaptersByVLANID(vlanID string) (adapters []*VirtualMachineNetworkAdapter) {
+	for _, adapter := range network.GetAdapters() {
+		if adapter.VLANID != nil && *adapter.VLANID == vlanID {
+			adapters = append(adapters, adapter)
+		}
+	}
+
+	return
+}
+
 // VirtualMachineNetworkAdapter represents the configuration for a virtual machine's network adapter.
 // If deploying a new VM, exactly one of VLANID / PrivateIPv4Address must be specified.
 //
@@ -13,10 +13,36 @@ const (
 	// ResourceStatusPendingDelete indicates that a delete operation is pending for the resource.
 	ResourceStatusPendingDelete = "PENDING_DELETE"
 
+	// ResourceStatusFailedAdd indicates that an add operation has failed for the resource.
+	ResourceStatusFailedAdd = "FAILED_ADD"
+
+	// ResourceStatusFailedChange indicates that a change operation has failed for the resource.
+	ResourceStatusFailedChange = "FAILED_CHANGE"
+
+	// ResourceStatusFailedDelete indicates that a delete operation has failed for the resource.
+	ResourceStatusFailedDelete = "FAILED_DELETE"
+
+	// ResourceStatusRequiresSupport indicates that an operation has failed for the resource, and it requires intervention by support staff.
+	ResourceStatusRequiresSupport = "REQUIRES_SUPPORT"
+
 	// ResourceStatusDeleted is a pseudo-status indicates that a resource has been deleted.
 	ResourceStatusDeleted = ""
 )
 
+const (
+	// VMwareToolsRunningStatusRunning indicates that VMware Tools is running in a virtual machine.
+	VMwareToolsRunningStatusRunning = "RUNNING"
+
+	// VMwareToolsRunningStatusNotRunning indicates that VMware Tools is not running in a virtual machine.
+	VMwareToolsRunningStatusNotRunning = "NOT_RUNNING"
+
+	// VMwareToolsVersionStatusCurrent indicates that the version of VMware Tools in a virtual machine is current.
+	VMwareToolsVersionStatusCurrent = "CURRENT"
+
+	// VMwareToolsVersionStatusNeedsUpgrade indicates that the version of VMware Tools in a virtual machine needs to be upgraded.
+	VMwareToolsVersionStatusNeedsUpgrade = "NEEDS_UPGRADE"
+)
+
 const (
 	// NetworkAdapterTypeE1000 represents the E1000 network adapter type.
 	NetworkAdapterTypeE1000 = "E1000"
@@ -0,0 +1,349 @@
+package compute
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Credentials represents the credentials used to authenticate to the compute API.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialsProvider supplies the credentials used to authenticate to the compute API.
+//
+// The client consults its CredentialsProvider each time it creates a request, so a provider can supply new credentials (e.g. after a password is rotated) without the client being re-created.
+type CredentialsProvider interface {
+	// Retrieve retrieves the current credentials.
+	Retrieve(ctx context.Context) (*Credentials, error)
+}
+
+// StaticCredentialsProvider is a CredentialsProvider that always supplies the same credentials.
+type StaticCredentialsProvider struct {
+	credentials Credentials
+}
+
+// NewStaticCredentialsProvider creates a new StaticCredentialsProvider (this is what NewClient uses).
+func NewStaticCredentialsProvider(username string, password string) *StaticCredentialsProvider {
+	return &StaticCredentialsProvider{
+		credentials: Credentials{
+			Username: username,
+			Password: password,
+		},
+	}
+}
+
+// Retrieve retrieves the current credentials.
+func (provider *StaticCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	credentials := provider.credentials
+
+	return &credentials, nil
+}
+
+var _ CredentialsProvider = &StaticCredentialsProvider{}
+
+// Environment variables used by EnvironmentCredentialsProvider and ProfileCredentialsProvider.
+const (
+	// EnvironmentVariableUser is the name of the environment variable containing the compute API user name.
+	EnvironmentVariableUser = "MCP_USER"
+
+	// EnvironmentVariablePassword is the name of the environment variable containing the compute API password.
+	EnvironmentVariablePassword = "MCP_PASSWORD"
+
+	// EnvironmentVariableProfile is the name of the environment variable containing the name of the default profile.
+	EnvironmentVariableProfile = "MCP_PROFILE"
+
+	// EnvironmentVariableProfileFile is the name of the environment variable containing the name of the default profile file.
+	EnvironmentVariableProfileFile = "MCP_PROFILE_FILE"
+)
+
+// EnvironmentCredentialsProvider is a CredentialsProvider that supplies credentials from the MCP_USER and MCP_PASSWORD environment variables.
+//
+// The environment variables are read each time credentials are retrieved.
+type EnvironmentCredentialsProvider struct{}
+
+// NewEnvironmentCredentialsProvider creates a new EnvironmentCredentialsProvider.
+func NewEnvironmentCredentialsProvider() *EnvironmentCredentialsProvider {
+	return &EnvironmentCredentialsProvider{}
+}
+
+// Retrieve retrieves the current credentials.
+func (provider *EnvironmentCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	username := os.Getenv(EnvironmentVariableUser)
+	if username == "" {
+		return nil, fmt.Errorf("the %s environment variable is not set", EnvironmentVariableUser)
+	}
+
+	password := os.Getenv(EnvironmentVariablePassword)
+	if password == "" {
+		return nil, fmt.Errorf("the %s environment variable is not set", EnvironmentVariablePassword)
+	}
+
+	return &Credentials{
+		Username: username,
+		Password: password,
+	}, nil
+}
+
+var _ CredentialsProvider = &EnvironmentCredentialsProvider{}
+
+// DefaultProfileName is the name of the profile used when no profile name is specified (and the MCP_PROFILE environment variable is not set).
+const DefaultProfileName = "default"
+
+// Profile represents a named profile from a profile file.
+//
+// Profile files can be in INI format:
+//
+//	[default]
+//	user = user1
+//	password = password1
+//	region = AU
+//
+// or JSON format (if the file name ends with ".json"):
+//
+//	{
+//	    "default": { "user": "user1", "password": "password1", "region": "AU" }
+//	}
+type Profile struct {
+	Name     string `json:"-"`
+	Username string `json:"user"`
+	Password string `json:"password"`
+	Region   string `json:"region"`
+}
+
+// DefaultProfileFileName determines the name of the default profile file.
+//
+// This is the value of the MCP_PROFILE_FILE environment variable or, if that is not set, "$HOME/.mcp/credentials".
+func DefaultProfileFileName() string {
+	fileName := os.Getenv(EnvironmentVariableProfileFile)
+	if fileName != "" {
+		return fileName
+	}
+
+	homeDirectory, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".mcp", "credentials")
+	}
+
+	return filepath.Join(homeDirectory, ".mcp", "credentials")
+}
+
+// LoadProfile loads the specified profile from a profile file.
+//
+// If fileName is empty, DefaultProfileFileName is used. If profileName is empty, the value of the MCP_PROFILE environment variable (or, if that is not set, DefaultProfileName) is used.
+func LoadProfile(fileName string, profileName string) (*Profile, error) {
+	if fileName == "" {
+		fileName = DefaultProfileFileName()
+	}
+	if profileName == "" {
+		profileName = os.Getenv(EnvironmentVariableProfile)
+	}
+	if profileName == "" {
+		profileName = DefaultProfileName
+	}
+
+	fileContent, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read profile file '%s'", fileName)
+	}
+
+	var profiles map[string]*Profile
+	if strings.EqualFold(filepath.Ext(fileName), ".json") {
+		err = json.Unmarshal(fileContent, &profiles)
+	} else {
+		profiles, err = parseINIProfiles(fileContent)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse profile file '%s'", fileName)
+	}
+
+	profile, ok := profiles[profileName]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("profile '%s' was not found in profile file '%s'", profileName, fileName)
+	}
+	profile.Name = profileName
+
+	return profile, nil
+}
+
+// parseINIProfiles parses profiles from a profile file in INI format.
+func parseINIProfiles(fileContent []byte) (map[string]*Profile, error) {
+	profiles := make(map[string]*Profile)
+
+	var currentProfile *Profile
+	scanner := bufio.NewScanner(bytes.NewReader(fileContent))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			profileName := strings.TrimSpace(line[1 : len(line)-1])
+			currentProfile = &Profile{}
+			profiles[profileName] = currentProfile
+
+			continue
+		}
+
+		keyAndValue := strings.SplitN(line, "=", 2)
+		if len(keyAndValue) != 2 {
+			return nil, fmt.Errorf("invalid entry on line %d", lineNumber)
+		}
+		if currentProfile == nil {
+			return nil, fmt.Errorf("entry on line %d is not in a profile section", lineNumber)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(keyAndValue[0]))
+		value := strings.TrimSpace(keyAndValue[1])
+		switch key {
+		case "user", "username":
+			currentProfile.Username = value
+		case "password":
+			currentProfile.Password = value
+		case "region":
+			currentProfile.Region = value
+		}
+	}
+
+	return profiles, scanner.Err()
+}
+
+// ProfileCredentialsProvider is a CredentialsProvider that supplies credentials from a named profile in a profile file (see Profile).
+//
+// The profile file is read each time credentials are retrieved (wrap the provider using NewCachingCredentialsProvider to avoid this).
+type ProfileCredentialsProvider struct {
+	// The name of the profile file (if empty, DefaultProfileFileName is used).
+	FileName string
+
+	// The name of the profile (if empty, the MCP_PROFILE environment variable or DefaultProfileName is used).
+	ProfileName string
+}
+
+// NewProfileCredentialsProvider creates a new ProfileCredentialsProvider.
+func NewProfileCredentialsProvider(fileName string, profileName string) *ProfileCredentialsProvider {
+	return &ProfileCredentialsProvider{
+		FileName:    fileName,
+		ProfileName: profileName,
+	}
+}
+
+// Retrieve retrieves the current credentials.
+func (provider *ProfileCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	profile, err := LoadProfile(provider.FileName, provider.ProfileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.Username == "" || profile.Password == "" {
+		return nil, fmt.Errorf("profile '%s' does not specify both a user name and password", profile.Name)
+	}
+
+	return &Credentials{
+		Username: profile.Username,
+		Password: profile.Password,
+	}, nil
+}
+
+var _ CredentialsProvider = &ProfileCredentialsProvider{}
+
+// NewClientFromProfile creates a new cloud compute API client using the region and credentials from a named profile in a profile file (see LoadProfile).
+//
+// The client retrieves its credentials using a ProfileCredentialsProvider (so changes to the profile's credentials take effect without the client being re-created).
+func NewClientFromProfile(fileName string, profileName string, options ...Option) (*Client, error) {
+	profile, err := LoadProfile(fileName, profileName)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Region == "" {
+		return nil, fmt.Errorf("profile '%s' does not specify a region", profile.Name)
+	}
+
+	options = append([]Option{
+		WithCredentialsProvider(NewProfileCredentialsProvider(fileName, profile.Name)),
+	}, options...)
+
+	return NewClientWithOptions(profile.Region, "", "", options...), nil
+}
+
+// CredentialsRefreshFunc is a function that retrieves new credentials for a CachingCredentialsProvider.
+type CredentialsRefreshFunc func(ctx context.Context) (*Credentials, error)
+
+// CachingCredentialsProvider is a CredentialsProvider that caches credentials retrieved from a callback, refreshing them when they expire.
+type CachingCredentialsProvider struct {
+	refresh     CredentialsRefreshFunc
+	ttl         time.Duration
+	stateLock   sync.Mutex
+	credentials *Credentials
+	expiresAt   time.Time
+	now         func() time.Time
+}
+
+// NewCachingCredentialsProvider creates a new CachingCredentialsProvider.
+//
+// refresh is called to retrieve credentials when there are no cached credentials, or the cached credentials are older than ttl (if ttl is 0, cached credentials only expire when Expire is called).
+func NewCachingCredentialsProvider(refresh CredentialsRefreshFunc, ttl time.Duration) *CachingCredentialsProvider {
+	return &CachingCredentialsProvider{
+		refresh: refresh,
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Retrieve retrieves the current credentials.
+func (provider *CachingCredentialsProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	provider.stateLock.Lock()
+	defer provider.stateLock.Unlock()
+
+	if provider.credentials != nil && (provider.ttl <= 0 || provider.now().Before(provider.expiresAt)) {
+		credentials := *provider.credentials
+
+		return &credentials, nil
+	}
+
+	credentials, err := provider.refresh(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to refresh credentials")
+	}
+	if credentials == nil {
+		return nil, fmt.Errorf("credentials refresh function did not return any credentials")
+	}
+
+	cachedCredentials := *credentials
+	provider.credentials = &cachedCredentials
+	provider.expiresAt = provider.now().Add(provider.ttl)
+
+	return credentials, nil
+}
+
+// Expire discards the cached credentials (if any), so that the next call to Retrieve refreshes them.
+func (provider *CachingCredentialsProvider) Expire() {
+	provider.stateLock.Lock()
+	defer provider.stateLock.Unlock()
+
+	provider.credentials = nil
+}
+
+var _ CredentialsProvider = &CachingCredentialsProvider{}
+
+// getCredentials retrieves the credentials used to authenticate requests.
+func (client *Client) getCredentials() (*Credentials, error) {
+	credentials, err := client.credentialsProvider.Retrieve(client.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve credentials")
+	}
+
+	return credentials, nil
+}
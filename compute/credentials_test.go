@@ -0,0 +1,216 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Environment credentials provider reads MCP_USER / MCP_PASSWORD.
+func TestEnvironmentCredentialsProvider(test *testing.T) {
+	expect := expect(test)
+
+	test.Setenv(EnvironmentVariableUser, "user1")
+	test.Setenv(EnvironmentVariablePassword, "")
+
+	provider := NewEnvironmentCredentialsProvider()
+	_, err := provider.Retrieve(context.Background())
+	expect.NotNil("Retrieve.Error (no password)", err)
+
+	test.Setenv(EnvironmentVariablePassword, "password1")
+	credentials, err := provider.Retrieve(context.Background())
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Credentials.Username", "user1", credentials.Username)
+	expect.EqualsString("Credentials.Password", "password1", credentials.Password)
+}
+
+// Profiles can be loaded from INI or JSON profile files.
+func TestLoadProfile(test *testing.T) {
+	expect := expect(test)
+
+	directory := test.TempDir()
+	iniFileName := filepath.Join(directory, "credentials")
+	writeTestFile(test, iniFileName, `
+# Default profile
+[default]
+user = user1
+password = pass=word1
+region = AU
+
+[production]
+user     = user2
+password = password2
+region   = NA
+`)
+	jsonFileName := filepath.Join(directory, "credentials.json")
+	writeTestFile(test, jsonFileName, `{
+	"production": { "user": "user3", "password": "password3", "region": "EU" }
+}`)
+
+	profile, err := LoadProfile(iniFileName, "")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Profile.Name", "default", profile.Name)
+	expect.EqualsString("Profile.Username", "user1", profile.Username)
+	expect.EqualsString("Profile.Password", "pass=word1", profile.Password)
+	expect.EqualsString("Profile.Region", "AU", profile.Region)
+
+	test.Setenv(EnvironmentVariableProfile, "production")
+	profile, err = LoadProfile(iniFileName, "")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Profile.Username", "user2", profile.Username)
+	expect.EqualsString("Profile.Region", "NA", profile.Region)
+
+	profile, err = LoadProfile(jsonFileName, "production")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Profile.Username", "user3", profile.Username)
+	expect.EqualsString("Profile.Password", "password3", profile.Password)
+	expect.EqualsString("Profile.Region", "EU", profile.Region)
+
+	_, err = LoadProfile(jsonFileName, "staging")
+	expect.NotNil("LoadProfile.Error (missing profile)", err)
+
+	client, err := NewClientFromProfile(iniFileName, "production")
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Client.baseAddress", "https://api-NA.dimensiondata.com", client.baseAddress)
+}
+
+// Caching credentials provider only refreshes credentials when they expire.
+func TestCachingCredentialsProvider(test *testing.T) {
+	expect := expect(test)
+
+	refreshCount := 0
+	provider := NewCachingCredentialsProvider(func(ctx context.Context) (*Credentials, error) {
+		refreshCount++
+
+		return &Credentials{
+			Username: "user1",
+			Password: fmt.Sprintf("password%d", refreshCount),
+		}, nil
+	}, 10*time.Minute)
+
+	now := time.Now()
+	provider.now = func() time.Time {
+		return now
+	}
+
+	credentials, err := provider.Retrieve(context.Background())
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Credentials.Password", "password1", credentials.Password)
+
+	now = now.Add(5 * time.Minute)
+	credentials, err = provider.Retrieve(context.Background())
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Credentials.Password (cached)", "password1", credentials.Password)
+
+	now = now.Add(6 * time.Minute)
+	credentials, err = provider.Retrieve(context.Background())
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Credentials.Password (expired)", "password2", credentials.Password)
+
+	provider.Expire()
+	credentials, err = provider.Retrieve(context.Background())
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Credentials.Password (after Expire)", "password3", credentials.Password)
+	expect.EqualsInt("RefreshCount", 3, refreshCount)
+}
+
+// Client consults its credentials provider for each request (without losing cached account details).
+func TestClient_CredentialsProvider_Rotation(test *testing.T) {
+	expect := expect(test)
+
+	expectedPassword := "password1"
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		username, password, ok := request.BasicAuth()
+		expect.IsTrue("Request.BasicAuth", ok)
+		expect.EqualsString("Request.Username", "user1", username)
+		expect.EqualsString("Request.Password", expectedPassword, password)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, retryTestOKResponse)
+	}))
+	defer testServer.Close()
+
+	provider := NewCachingCredentialsProvider(func(ctx context.Context) (*Credentials, error) {
+		return &Credentials{
+			Username: "user1",
+			Password: expectedPassword,
+		}, nil
+	}, 0)
+
+	client := NewClientWithOptions("AU", "", "",
+		WithBaseAddress(testServer.URL),
+		WithCredentialsProvider(provider),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expectedPassword = "password2"
+	provider.Expire()
+
+	err = client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	account, err := client.GetAccount()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Account.OrganizationID", "dummy-organization-id", account.OrganizationID)
+}
+
+// Client fails requests if its credentials provider cannot supply credentials.
+func TestClient_CredentialsProvider_Error(test *testing.T) {
+	expect := expect(test)
+
+	test.Setenv(EnvironmentVariableUser, "")
+
+	client := NewClientWithOptions("AU", "", "",
+		WithBaseAddress("http://127.0.0.1:1"),
+		WithCredentialsProvider(NewEnvironmentCredentialsProvider()),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+	expect.NotNil("DeleteVLAN.Error", err)
+}
+
+func writeTestFile(test *testing.T, fileName string, content string) {
+	err := ioutil.WriteFile(fileName, []byte(content), 0600)
+	if err != nil {
+		test.Fatal(err)
+	}
+}
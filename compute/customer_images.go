@@ -25,6 +25,32 @@ type CustomerImages struct {
 	PageSize int `json:"pageSize"`
 }
 
+// IsEmpty determines whether the page contains no results.
+func (page *CustomerImages) IsEmpty() bool {
+	return page.pagedResult().IsEmpty()
+}
+
+// IsLastPage determines whether the page represents the last page of results.
+func (page *CustomerImages) IsLastPage() bool {
+	return page.pagedResult().IsLastPage()
+}
+
+// pagedResult creates a PagedResult from the page's paging fields.
+func (page *CustomerImages) pagedResult() *PagedResult {
+	if page == nil {
+		return nil
+	}
+
+	return &PagedResult{
+		PageNumber: page.PageNumber,
+		PageCount:  page.PageCount,
+		TotalCount: page.TotalCount,
+		PageSize:   page.PageSize,
+	}
+}
+
+var _ PagedResults = &CustomerImages{}
+
 // CustomerImage represents a custom virtual machine image.
 type CustomerImage struct {
 	ID              string                        `json:"id"`
@@ -149,7 +175,7 @@ func (client *Client) GetCustomerImage(id string) (image *CustomerImage, err err
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +220,7 @@ func (client *Client) FindCustomerImage(name string, dataCenterID string) (image
 		url.QueryEscape(name),
 		url.QueryEscape(dataCenterID),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -234,17 +260,30 @@ func (client *Client) FindCustomerImage(name string, dataCenterID string) (image
 
 // ListCustomerImagesInDatacenter lists all customer images in a given data centre.
 func (client *Client) ListCustomerImagesInDatacenter(dataCenterID string, paging *Paging) (images *CustomerImages, err error) {
+	return client.ListCustomerImagesInDatacenterWithFilter(dataCenterID, nil, paging)
+}
+
+// ListCustomerImagesInDatacenterWithFilter lists the customer images in a given data centre that match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results.
+func (client *Client) ListCustomerImagesInDatacenterWithFilter(dataCenterID string, filter *Filter, paging *Paging) (images *CustomerImages, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/image/customerImage?datacenterId=%s&%s",
+	filterParameters, err := filter.toQueryParameters(FilterFieldDatacenterID)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/image/customerImage?datacenterId=%s&%s%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(dataCenterID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
+		filterParameters,
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -271,6 +310,22 @@ func (client *Client) ListCustomerImagesInDatacenter(dataCenterID string, paging
 	return
 }
 
+// AllCustomerImagesInDatacenter retrieves all customer images in the specified datacenter (across all pages of results).
+func (client *Client) AllCustomerImagesInDatacenter(dataCenterID string) (images []CustomerImage, err error) {
+	return client.AllCustomerImagesInDatacenterWithFilter(dataCenterID, nil)
+}
+
+// AllCustomerImagesInDatacenterWithFilter retrieves all customer images in the specified datacenter that match the specified filter criteria (across all pages of results).
+func (client *Client) AllCustomerImagesInDatacenterWithFilter(dataCenterID string, filter *Filter) (images []CustomerImage, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListCustomerImagesInDatacenterWithFilter(dataCenterID, filter, paging)
+	}, func(page PagedResults) {
+		images = append(images, page.(*CustomerImages).Images...)
+	})
+
+	return
+}
+
 // ImportCustomerImage imports the specified customer image from an OVF package.
 //
 // The OVF package can be uploaded via FTPS (call GetDatacenter to determine the FTPS end-point for the target datacenter).
@@ -285,7 +340,7 @@ func (client *Client) ImportCustomerImage(imageName string, imageDescription str
 	requestURI := fmt.Sprintf("%s/image/importImage",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodPost, &importCustomerImage{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &importCustomerImage{
 		ImageName:            imageName,
 		ImageDescription:     imageDescription,
 		DatacenterID:         datacenterID,
@@ -339,7 +394,7 @@ func (client *Client) ExportCustomerImage(imageID string, ovfPackagePrefix strin
 	requestURI := fmt.Sprintf("%s/image/exportImage",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodPost, &exportCustomerImage{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &exportCustomerImage{
 		ImageID:          imageID,
 		OVFPackagePrefix: ovfPackagePrefix,
 	})
@@ -58,18 +58,30 @@ type Datacenters struct {
 }
 
 // ListDatacenters retrieves a list of all datacenters.
-// TODO: Support filtering and sorting.
 func (client *Client) ListDatacenters(paging *Paging) (datacenters *Datacenters, err error) {
+	return client.ListDatacentersWithFilter(nil, paging)
+}
+
+// ListDatacentersWithFilter retrieves a list of the datacenters that match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results.
+func (client *Client) ListDatacentersWithFilter(filter *Filter, paging *Paging) (datacenters *Datacenters, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/infrastructure/datacenter?%s",
+	filterParameters, err := filter.toQueryParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/infrastructure/datacenter?%s%s",
 		url.QueryEscape(organizationID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
+		filterParameters,
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +111,22 @@ func (client *Client) ListDatacenters(paging *Paging) (datacenters *Datacenters,
 	return datacenters, nil
 }
 
+// AllDatacenters retrieves all datacenters (across all pages of results).
+func (client *Client) AllDatacenters() (datacenters []Datacenter, err error) {
+	return client.AllDatacentersWithFilter(nil)
+}
+
+// AllDatacentersWithFilter retrieves all datacenters that match the specified filter criteria (across all pages of results).
+func (client *Client) AllDatacentersWithFilter(filter *Filter) (datacenters []Datacenter, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListDatacentersWithFilter(filter, paging)
+	}, func(page PagedResults) {
+		datacenters = append(datacenters, page.(*Datacenters).Items...)
+	})
+
+	return
+}
+
 // GetDatacenter retrieves the datacenter with the specified Id.
 // id is the Id of the datacenter to retrieve.
 // Returns nil if no datacenter is found with the specified Id.
@@ -112,7 +140,7 @@ func (client *Client) GetDatacenter(id string) (datacenter *Datacenter, err erro
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +60,11 @@ func (domain *NetworkDomain) GetState() string {
 	return domain.State
 }
 
+// GetProgress returns the network domain's current progress (if any).
+func (domain *NetworkDomain) GetProgress() string {
+	return domain.Progress
+}
+
 // IsDeleted determines whether the network domain has been deleted (is nil).
 func (domain *NetworkDomain) IsDeleted() bool {
 	return domain == nil
@@ -122,18 +127,30 @@ type deleteNetworkDomain struct {
 }
 
 // ListNetworkDomains retrieves a list of all network domains.
-// TODO: Support filtering and sorting.
 func (client *Client) ListNetworkDomains(paging *Paging) (domains *NetworkDomains, err error) {
+	return client.ListNetworkDomainsWithFilter(nil, paging)
+}
+
+// ListNetworkDomainsWithFilter retrieves a list of the network domains that match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results.
+func (client *Client) ListNetworkDomainsWithFilter(filter *Filter, paging *Paging) (domains *NetworkDomains, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/network/networkDomain?%s",
+	filterParameters, err := filter.toQueryParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/network/networkDomain?%s%s",
 		url.QueryEscape(organizationID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
+		filterParameters,
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -163,6 +180,22 @@ func (client *Client) ListNetworkDomains(paging *Paging) (domains *NetworkDomain
 	return domains, nil
 }
 
+// AllNetworkDomains retrieves all network domains (across all pages of results).
+func (client *Client) AllNetworkDomains() (domains []NetworkDomain, err error) {
+	return client.AllNetworkDomainsWithFilter(nil)
+}
+
+// AllNetworkDomainsWithFilter retrieves all network domains that match the specified filter criteria (across all pages of results).
+func (client *Client) AllNetworkDomainsWithFilter(filter *Filter) (domains []NetworkDomain, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListNetworkDomainsWithFilter(filter, paging)
+	}, func(page PagedResults) {
+		domains = append(domains, page.(*NetworkDomains).Domains...)
+	})
+
+	return
+}
+
 // GetNetworkDomain retrieves the network domain with the specified Id.
 // id is the Id of the network domain to retrieve.
 // Returns nil if no network domain is found with the specified Id.
@@ -176,7 +209,7 @@ func (client *Client) GetNetworkDomain(id string) (domain *NetworkDomain, err er
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -221,7 +254,7 @@ func (client *Client) GetNetworkDomainByName(name string, dataCenterID string) (
 		url.QueryEscape(name),
 		url.QueryEscape(dataCenterID),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -261,103 +294,138 @@ func (client *Client) GetNetworkDomainByName(name string, dataCenterID string) (
 // DeployNetworkDomain deploys a new network domain.
 // Returns the Id of the new network domain.
 func (client *Client) DeployNetworkDomain(name string, description string, plan string, datacenter string) (networkDomainID string, err error) {
-	organizationID, err := client.getOrganizationID()
+	operation, err := client.DeployNetworkDomainAsync(name, description, plan, datacenter)
 	if err != nil {
 		return "", err
 	}
 
+	return operation.ResourceID, nil
+}
+
+// DeployNetworkDomainAsync is equivalent to DeployNetworkDomain, but returns an Operation that can be used to wait for the deploy operation to complete.
+func (client *Client) DeployNetworkDomainAsync(name string, description string, plan string, datacenter string) (operation *Operation, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
 	requestURI := fmt.Sprintf("%s/network/deployNetworkDomain",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodPost, &deployNetworkDomain{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deployNetworkDomain{
 		Name:         name,
 		Description:  description,
 		Type:         plan,
 		DatacenterID: datacenter,
 	})
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeInProgress {
-		return "", apiResponse.ToError("Request to deploy network domain '%s' failed with status code %d (%s): %s", name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to deploy network domain '%s' failed with status code %d (%s): %s", name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
 	// Expected: "info" { "name": "networkDomainId", "value": "the-Id-of-the-new-network-domain" }
 	networkDomainIDMessage := apiResponse.GetFieldMessage("networkDomainId")
 	if networkDomainIDMessage == nil {
-		return "", apiResponse.ToError("Received an unexpected response (missing 'networkDomainId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Received an unexpected response (missing 'networkDomainId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return *networkDomainIDMessage, nil
+	return client.newPendingOperation(ResourceTypeNetworkDomain, *networkDomainIDMessage, "Deploy", ResourceStatusPendingAdd, false, apiResponse), nil
 }
 
 // EditNetworkDomain updates an existing network domain.
 // Pass an empty string for any field to retain its existing value.
 // Returns an error if the operation was not successful.
 func (client *Client) EditNetworkDomain(id string, name *string, description *string, plan *string) (err error) {
+	_, err = client.EditNetworkDomainAsync(id, name, description, plan)
+
+	return err
+}
+
+// EditNetworkDomainAsync is equivalent to EditNetworkDomain, but returns an Operation that can be used to wait for the edit operation to complete.
+//
+// CloudControl completes this operation synchronously, so the returned Operation has already completed.
+func (client *Client) EditNetworkDomainAsync(id string, name *string, description *string, plan *string) (operation *Operation, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/network/editNetworkDomain",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodPost, &editNetworkDomain{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &editNetworkDomain{
 		ID:          id,
 		Name:        name,
 		Description: description,
 		Type:        plan,
 	})
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeOK {
-		return apiResponse.ToError("Request to edit VLAN failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to edit VLAN failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return nil
+	return client.newCompletedOperation(ResourceTypeNetworkDomain, id, "Edit", apiResponse), nil
 }
 
 // DeleteNetworkDomain deletes an existing network domain.
 // Returns an error if the operation was not successful.
 func (client *Client) DeleteNetworkDomain(id string) (err error) {
+	_, err = client.DeleteNetworkDomainAsync(id)
+
+	return err
+}
+
+// DeleteNetworkDomainAsync is equivalent to DeleteNetworkDomain, but returns an Operation that can be used to wait for the delete operation to complete.
+func (client *Client) DeleteNetworkDomainAsync(id string) (operation *Operation, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/network/deleteNetworkDomain",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodPost, &deleteNetworkDomain{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteNetworkDomain{id})
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeInProgress {
-		return apiResponse.ToError("Request to delete network domain failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to delete network domain failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return nil
+	return client.newPendingOperation(ResourceTypeNetworkDomain, id, "Delete", ResourceStatusPendingDelete, true, apiResponse), nil
 }
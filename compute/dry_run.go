@@ -0,0 +1,341 @@
+package compute
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute/requests"
+)
+
+// DryRunMessage is the message returned in the synthetic API responses for requests recorded in dry-run mode.
+const DryRunMessage = "Request recorded (dry run); no changes were made."
+
+// PlannedRequest represents a mutating request that was recorded (but not performed) by a client in dry-run mode.
+type PlannedRequest struct {
+	// The compute API operation (e.g. "server/deployServer").
+	Operation string `json:"operation"`
+
+	// The HTTP method (e.g. "POST").
+	Method string `json:"method"`
+
+	// The request URL.
+	URL string `json:"url"`
+
+	// The request content type (if any).
+	ContentType string `json:"contentType,omitempty"`
+
+	// The request body (if any), with sensitive data (such as administrator passwords) redacted.
+	Body string `json:"body,omitempty"`
+
+	// The synthetic Id (if any) returned for the resource that the request would have created.
+	SyntheticID string `json:"syntheticId,omitempty"`
+
+	// The synthetic request Id returned in the API response.
+	RequestID string `json:"requestId"`
+}
+
+// DryRunPlan records the mutating requests that a client in dry-run mode would have performed (see Client.SetDryRun).
+//
+// A DryRunPlan can be shared by multiple clients.
+type DryRunPlan struct {
+	stateLock sync.Mutex
+	requests  []PlannedRequest
+}
+
+// NewDryRunPlan creates a new, empty, DryRunPlan.
+func NewDryRunPlan() *DryRunPlan {
+	return &DryRunPlan{}
+}
+
+// Requests retrieves the requests recorded in the plan (in the order they were made).
+func (plan *DryRunPlan) Requests() []PlannedRequest {
+	plan.stateLock.Lock()
+	defer plan.stateLock.Unlock()
+
+	plannedRequests := make([]PlannedRequest, len(plan.requests))
+	copy(plannedRequests, plan.requests)
+
+	return plannedRequests
+}
+
+// Reset removes all requests from the plan.
+func (plan *DryRunPlan) Reset() {
+	plan.stateLock.Lock()
+	defer plan.stateLock.Unlock()
+
+	plan.requests = nil
+}
+
+// String creates a human-readable representation of the plan.
+func (plan *DryRunPlan) String() string {
+	plannedRequests := plan.Requests()
+	if len(plannedRequests) == 0 {
+		return "No changes.\n"
+	}
+
+	var builder strings.Builder
+	for index, plannedRequest := range plannedRequests {
+		fmt.Fprintf(&builder, "%d. %s %s\n", index+1, plannedRequest.Method, plannedRequest.URL)
+		if plannedRequest.Body != "" {
+			fmt.Fprintf(&builder, "   %s\n", plannedRequest.Body)
+		}
+	}
+
+	return builder.String()
+}
+
+// MarshalJSON serialises the plan's requests as JSON.
+func (plan *DryRunPlan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(plan.Requests())
+}
+
+var _ json.Marshaler = &DryRunPlan{}
+
+// record adds the specified request to the plan, and creates a synthetic response for it.
+func (plan *DryRunPlan) record(request *http.Request) (responseBody []byte, statusCode int, err error) {
+	snapshot, err := requests.CreateSnapshotAndClose(request)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	isV1 := strings.HasPrefix(request.URL.Path, "/oec/")
+	relativePath := getOrganizationRelativePath(request.URL.Path)
+
+	operation := relativePath
+	pathSegments := strings.SplitN(relativePath, "/", 3)
+	if !isV1 && len(pathSegments) >= 2 {
+		operation = pathSegments[0] + "/" + pathSegments[1]
+	}
+
+	plannedRequest := PlannedRequest{
+		Operation:   operation,
+		Method:      request.Method,
+		URL:         request.URL.String(),
+		ContentType: request.Header.Get("Content-Type"),
+		Body:        redactBody(snapshot.GetCachedRequestBody()),
+	}
+
+	plan.stateLock.Lock()
+	plannedRequest.RequestID = fmt.Sprintf("dry-run/%d", len(plan.requests)+1)
+	if isV1 {
+		responseBody, plannedRequest.SyntheticID, err = newDryRunResponseV1(relativePath, plannedRequest.RequestID)
+	} else {
+		responseBody, plannedRequest.SyntheticID, err = newDryRunResponseV2(operation, plannedRequest.RequestID)
+	}
+	if err == nil {
+		plan.requests = append(plan.requests, plannedRequest)
+	}
+	plan.stateLock.Unlock()
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return responseBody, http.StatusOK, nil
+}
+
+// dryRunInProgressOperations are the compute API (v2) operations whose responses indicate that the operation is still in progress (i.e. IN_PROGRESS rather than OK).
+var dryRunInProgressOperations = map[string]bool{
+	"image/exportImage":                    true,
+	"image/importImage":                    true,
+	"network/deleteNetworkDomain":          true,
+	"network/deleteVlan":                   true,
+	"network/deployNetworkDomain":          true,
+	"network/deployVlan":                   true,
+	"server/addDisk":                       true,
+	"server/addNic":                        true,
+	"server/addScsiController":             true,
+	"server/changeDiskIops":                true,
+	"server/changeDiskSpeed":               true,
+	"server/changeNetworkAdapter":          true,
+	"server/cloneServer":                   true,
+	"server/deleteServer":                  true,
+	"server/deployServer":                  true,
+	"server/deployUncustomizedServer":      true,
+	"server/expandDisk":                    true,
+	"server/notifyNicIpChange":             true,
+	"server/powerOffServer":                true,
+	"server/rebootServer":                  true,
+	"server/removeDisk":                    true,
+	"server/removeNic":                     true,
+	"server/removeScsiController":          true,
+	"server/resetServer":                   true,
+	"server/shutdownServer":                true,
+	"server/startServer":                   true,
+	"server/updateVmwareTools":             true,
+	"server/upgradeVirtualHardware":        true,
+	"snapshot/createSnapshotPreviewServer": true,
+	"snapshot/initiateManualSnapshot":      true,
+	"snapshot/restoreFiles":                true,
+}
+
+// dryRunIDFields are the names of the response fields used by compute API (v2) operations to return the Id of the resource they create.
+var dryRunIDFields = map[string]string{
+	"image/exportImage":                           "imageExportId",
+	"image/importImage":                           "imageId",
+	"network/addPublicIpBlock":                    "ipBlockId",
+	"network/createFirewallRule":                  "firewallRuleId",
+	"network/createIpAddressList":                 "ipAddressListId",
+	"network/createNatRule":                       "natRuleId",
+	"network/createPortList":                      "portListId",
+	"network/createStaticRoute":                   "staticRouteId",
+	"network/deployNetworkDomain":                 "networkDomainId",
+	"network/deployVlan":                          "vlanId",
+	"networkDomainVip/addPoolMember":              "poolMemberId",
+	"networkDomainVip/createNode":                 "nodeId",
+	"networkDomainVip/createPool":                 "poolId",
+	"networkDomainVip/createSslOffloadProfile":    "sslOffloadProfileId",
+	"networkDomainVip/createVirtualListener":      "virtualListenerId",
+	"networkDomainVip/importSslCertificateChain":  "sslCertificateChainId",
+	"networkDomainVip/importSslDomainCertificate": "sslDomainCertificateId",
+	"server/addDisk":                              "diskId",
+	"server/addNic":                               "nicId",
+	"server/cloneServer":                          "imageId",
+	"server/deployServer":                         "serverId",
+	"server/deployUncustomizedServer":             "serverId",
+	"snapshot/createSnapshotPreviewServer":        "serverId",
+	"tag/createTagKey":                            "tagKeyId",
+}
+
+// newDryRunResponseV2 creates a synthetic compute API (v2) response for an operation recorded in dry-run mode.
+func newDryRunResponseV2(operation string, requestID string) (responseBody []byte, syntheticID string, err error) {
+	apiResponse := &APIResponseV2{
+		Operation:    toOperationConstant(operation[strings.LastIndex(operation, "/")+1:]),
+		ResponseCode: ResponseCodeOK,
+		Message:      DryRunMessage,
+		RequestID:    requestID,
+	}
+	if dryRunInProgressOperations[operation] {
+		apiResponse.ResponseCode = ResponseCodeInProgress
+	}
+
+	idField, ok := dryRunIDFields[operation]
+	if ok {
+		syntheticID, err = newSyntheticID()
+		if err != nil {
+			return nil, "", err
+		}
+
+		apiResponse.FieldMessages = []FieldMessage{
+			{FieldName: idField, Message: syntheticID},
+		}
+	}
+
+	responseBody, err = json.Marshal(apiResponse)
+
+	return
+}
+
+// newDryRunResponseV1 creates a synthetic compute API (v1) response for an operation recorded in dry-run mode.
+func newDryRunResponseV1(relativePath string, requestID string) (responseBody []byte, syntheticID string, err error) {
+	apiResponse := &APIResponseV1{
+		Operation:  strings.SplitN(relativePath, "?", 2)[0],
+		Result:     ResultSuccess,
+		ResultCode: ResultCodeSuccess,
+		Message:    DryRunMessage + " Request Id: " + requestID,
+	}
+
+	var idField string
+	switch {
+	case strings.HasSuffix(relativePath, "antiAffinityRule"):
+		idField = "antiaffinityrule.id"
+	case strings.HasSuffix(relativePath, "backup/client"):
+		idField = "backupClient.id"
+	}
+	if idField != "" {
+		syntheticID, err = newSyntheticID()
+		if err != nil {
+			return nil, "", err
+		}
+
+		apiResponse.AdditionalInformation = []APIResponseAdditionalInformationV1{
+			{Name: idField, Value: syntheticID},
+		}
+	}
+
+	var buffer bytes.Buffer
+	err = xml.NewEncoder(&buffer).Encode(apiResponse)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buffer.Bytes(), syntheticID, nil
+}
+
+// newSyntheticID creates a random (version 4) UUID for use as the Id of a resource created in dry-run mode.
+func newSyntheticID() (string, error) {
+	var data [16]byte
+	_, err := rand.Read(data[:])
+	if err != nil {
+		return "", err
+	}
+	data[6] = (data[6] & 0x0f) | 0x40
+	data[8] = (data[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", data[0:4], data[4:6], data[6:8], data[8:10], data[10:16]), nil
+}
+
+// toOperationConstant converts an operation name (e.g. "deployServer") to the form used in API responses (e.g. "DEPLOY_SERVER").
+func toOperationConstant(operationName string) string {
+	var builder strings.Builder
+	for index, character := range operationName {
+		if unicode.IsUpper(character) && index > 0 {
+			builder.WriteRune('_')
+		}
+		builder.WriteRune(unicode.ToUpper(character))
+	}
+
+	return builder.String()
+}
+
+// isMutatingRequest determines whether the specified request modifies resources.
+//
+// Some compute API (v1) operations (e.g. deleting an anti-affinity rule) are performed using GET requests with a command as the query string.
+func isMutatingRequest(request *http.Request) bool {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return true
+	}
+
+	if !strings.HasPrefix(request.URL.Path, "/oec/") {
+		return false
+	}
+
+	switch request.URL.RawQuery {
+	case "delete", "disable", "remove", "cancelJob":
+		return true
+	default:
+		return false
+	}
+}
+
+// SetDryRun configures the client to run in dry-run mode, where mutating (non-GET) requests are recorded in the specified plan (and a synthetic response returned) instead of being sent to the compute API.
+// Read-only (GET) requests are still sent to the compute API.
+//
+// Note that resources "created" in dry-run mode do not exist, so waiting for their pending operations to complete will fail; use the XXXAsync variants of operations (e.g. DeployServerAsync) to record them without waiting.
+//
+// Set plan to nil (the default) to disable dry-run mode.
+func (client *Client) SetDryRun(plan *DryRunPlan) {
+	client.dryRun.Store(dryRunHolder{plan})
+}
+
+// getDryRunPlan retrieves the plan used to record requests in dry-run mode (nil if dry-run mode is disabled).
+func (client *Client) getDryRunPlan() *DryRunPlan {
+	holder, ok := client.dryRun.Load().(dryRunHolder)
+	if !ok {
+		return nil
+	}
+
+	return holder.plan
+}
+
+// dryRunHolder enables storage of a (possibly nil) DryRunPlan in an atomic.Value.
+type dryRunHolder struct {
+	plan *DryRunPlan
+}
@@ -0,0 +1,88 @@
+package compute
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// In dry-run mode, mutating requests are recorded in the plan (with synthetic responses), while GET requests are still performed.
+func TestClient_DryRun(test *testing.T) {
+	expect := expect(test)
+
+	plan := NewDryRunPlan()
+	getCount := 0
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			client.SetDryRun(plan)
+
+			vlan, err := client.GetVLAN("0e56433f-d808-4669-821d-812769517ff8")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.NotNil("VLAN", vlan)
+
+			operation, err := client.DeployNetworkDomainAsync("Domain 1", "This is domain 1", "ESSENTIALS", "AU9")
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.IsFalse("Deploy.Done", operation.Done())
+			expect.EqualsString("Deploy.RequestID", "dry-run/1", operation.RequestID)
+
+			name := "VLAN 2"
+			err = client.EditVLAN("0e56433f-d808-4669-821d-812769517ff8", &name, nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			err = client.DeleteServerAntiAffinityRule("b9b8a4a3-1b2c-4e5f-9a8b-7c6d5e4f3a2b", "484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			plannedRequests := plan.Requests()
+			expect.EqualsInt("Plan size", 3, len(plannedRequests))
+
+			expect.EqualsString("Plan[0].Operation", "network/deployNetworkDomain", plannedRequests[0].Operation)
+			expect.EqualsString("Plan[0].Method", http.MethodPost, plannedRequests[0].Method)
+			expect.EqualsString("Plan[0].SyntheticID", operation.ResourceID, plannedRequests[0].SyntheticID)
+			expect.EqualsInt("Plan[0].SyntheticID length", 36, len(plannedRequests[0].SyntheticID))
+			expect.IsTrue("Plan[0].Body", strings.Contains(plannedRequests[0].Body, `"name":"Domain 1"`))
+
+			expect.EqualsString("Plan[1].Operation", "network/editVlan", plannedRequests[1].Operation)
+			expect.EqualsString("Plan[1].ContentType", "application/json", plannedRequests[1].ContentType)
+			expect.EqualsString("Plan[1].SyntheticID", "", plannedRequests[1].SyntheticID)
+
+			expect.EqualsString("Plan[2].Method", http.MethodGet, plannedRequests[2].Method)
+			expect.IsTrue("Plan[2].URL", strings.HasSuffix(plannedRequests[2].URL, "/antiAffinityRule/b9b8a4a3-1b2c-4e5f-9a8b-7c6d5e4f3a2b?delete"))
+
+			expect.IsTrue("Plan.String", strings.HasPrefix(plan.String(), "1. POST "))
+
+			serializedPlan, err := json.Marshal(plan)
+			if err != nil {
+				test.Fatal(err)
+			}
+			var deserializedPlan []PlannedRequest
+			err = json.Unmarshal(serializedPlan, &deserializedPlan)
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Deserialized plan size", 3, len(deserializedPlan))
+			expect.EqualsString("Deserialized Plan[1].RequestID", "dry-run/2", deserializedPlan[1].RequestID)
+
+			plan.Reset()
+			expect.EqualsString("Plan.String (reset)", "No changes.\n", plan.String())
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if request.Method != http.MethodGet || request.URL.RawQuery == "delete" {
+				test.Errorf("Unexpected %s request to '%s' in dry-run mode", request.Method, request.URL.String())
+			}
+			getCount++
+
+			return http.StatusOK, getVLANTestResponse
+		},
+	})
+
+	expect.EqualsInt("GET request count", 1, getCount)
+}
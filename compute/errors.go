@@ -0,0 +1,207 @@
+package compute
+
+import (
+	"net/http"
+)
+
+// NotFoundError represents a CloudControl API error indicating that a target resource was not found (RESOURCE_NOT_FOUND, or its v1 equivalents).
+//
+// Use errors.As to determine whether an error returned by the client is a NotFoundError.
+type NotFoundError struct {
+	*APIError
+}
+
+// Unwrap retrieves the underlying APIError.
+func (err *NotFoundError) Unwrap() error {
+	return err.APIError
+}
+
+// ConflictError represents a CloudControl API error indicating that an operation failed because a name or IP address duplicates an existing one (NAME_NOT_UNIQUE or IP_ADDRESS_NOT_UNIQUE).
+//
+// Use errors.As to determine whether an error returned by the client is a ConflictError.
+type ConflictError struct {
+	*APIError
+}
+
+// Unwrap retrieves the underlying APIError.
+func (err *ConflictError) Unwrap() error {
+	return err.APIError
+}
+
+// DependencyError represents a CloudControl API error indicating that an operation cannot be performed on a resource because of another resource that depends on it (HAS_DEPENDENCY, or its v1 equivalents).
+//
+// Use errors.As to determine whether an error returned by the client is a DependencyError.
+type DependencyError struct {
+	*APIError
+}
+
+// Unwrap retrieves the underlying APIError.
+func (err *DependencyError) Unwrap() error {
+	return err.APIError
+}
+
+// QuotaError represents a CloudControl API error indicating that an operation failed because a resource limit was exceeded or some type of resource has been exhausted (EXCEEDS_LIMIT or OUT_OF_RESOURCES, or their v1 equivalents).
+//
+// Use errors.As to determine whether an error returned by the client is a QuotaError.
+type QuotaError struct {
+	*APIError
+}
+
+// Unwrap retrieves the underlying APIError.
+func (err *QuotaError) Unwrap() error {
+	return err.APIError
+}
+
+// AuthError represents a CloudControl API error indicating that the caller's credentials are invalid, or that the caller is not authorised to perform an operation (AUTHORIZATION_FAILURE, or HTTP 401 / 403).
+//
+// Use errors.As to determine whether an error returned by the client is an AuthError.
+type AuthError struct {
+	*APIError
+}
+
+// Unwrap retrieves the underlying APIError.
+func (err *AuthError) Unwrap() error {
+	return err.APIError
+}
+
+// MaintenanceError represents a CloudControl API error indicating that an operation failed due to maintenance being performed on the supporting infrastructure (INFRASTRUCTURE_IN_MAINTENANCE).
+//
+// Use errors.As to determine whether an error returned by the client is a MaintenanceError.
+type MaintenanceError struct {
+	*APIError
+}
+
+// Unwrap retrieves the underlying APIError.
+func (err *MaintenanceError) Unwrap() error {
+	return err.APIError
+}
+
+// As enables errors.As to treat an APIError as the typed error (NotFoundError, ConflictError, etc) corresponding to its response code.
+func (apiError *APIError) As(target interface{}) bool {
+	switch target := target.(type) {
+	case **NotFoundError:
+		if apiError.isNotFound() {
+			*target = &NotFoundError{apiError}
+
+			return true
+		}
+	case **ConflictError:
+		if apiError.isConflict() {
+			*target = &ConflictError{apiError}
+
+			return true
+		}
+	case **DependencyError:
+		if apiError.isDependency() {
+			*target = &DependencyError{apiError}
+
+			return true
+		}
+	case **QuotaError:
+		if apiError.isQuota() {
+			*target = &QuotaError{apiError}
+
+			return true
+		}
+	case **AuthError:
+		if apiError.isAuth() {
+			*target = &AuthError{apiError}
+
+			return true
+		}
+	case **MaintenanceError:
+		if apiError.isMaintenance() {
+			*target = &MaintenanceError{apiError}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (apiError *APIError) isNotFound() bool {
+	switch apiError.responseCode() {
+	case ResponseCodeResourceNotFound, ResultCodeServerNotFound, ResultCodeBackupClientNotFound:
+		return true
+	default:
+		return false
+	}
+}
+
+func (apiError *APIError) isConflict() bool {
+	switch apiError.responseCode() {
+	case ResponseCodeResourceNameNotUnique, ResponseCodeIPAddressNotUnique:
+		return true
+	default:
+		return false
+	}
+}
+
+func (apiError *APIError) isDependency() bool {
+	switch apiError.responseCode() {
+	case ResponseCodeResourceHasDependency, ResultCodeServerHasBackupAgents:
+		return true
+	default:
+		return false
+	}
+}
+
+func (apiError *APIError) isQuota() bool {
+	switch apiError.responseCode() {
+	case ResponseCodeExceedsLimit, ResponseCodeOutOfResources, ResultCodeExceedsLimit:
+		return true
+	default:
+		return false
+	}
+}
+
+func (apiError *APIError) isAuth() bool {
+	if apiError.StatusCode == http.StatusUnauthorized || apiError.StatusCode == http.StatusForbidden {
+		return true
+	}
+
+	return apiError.responseCode() == ResponseCodeAuthorizationFailure
+}
+
+func (apiError *APIError) isMaintenance() bool {
+	return apiError.responseCode() == ResponseCodeInfrastructureInMaintenance
+}
+
+// responseCode retrieves the response code (if any) from the API response that the APIError represents.
+func (apiError *APIError) responseCode() string {
+	if apiError.Response == nil {
+		return ""
+	}
+
+	return apiError.Response.GetResponseCode()
+}
+
+// newAPIError creates a new APIError representing the specified API response.
+func newAPIError(message string, response APIResponse) *APIError {
+	apiError := &APIError{
+		Message:  message,
+		Response: response,
+	}
+
+	switch response := response.(type) {
+	case *APIResponseV1:
+		apiError.StatusCode = response.HTTPStatusCode
+		apiError.Operation = response.Operation
+		for _, additionalInformation := range response.AdditionalInformation {
+			apiError.FieldMessages = append(apiError.FieldMessages, FieldMessage{
+				FieldName: additionalInformation.Name,
+				Message:   additionalInformation.Value,
+			})
+		}
+	case *APIResponseV2:
+		apiError.StatusCode = response.HTTPStatusCode
+		apiError.Operation = response.Operation
+		apiError.RequestID = response.RequestID
+		apiError.FieldErrors = response.FieldErrors
+		apiError.FieldWarnings = response.FieldWarnings
+		apiError.FieldMessages = response.FieldMessages
+	}
+
+	return apiError
+}
@@ -0,0 +1,120 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Deploy VLAN (name not unique).
+func TestClient_DeployVLAN_ConflictError(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			_, err := client.DeployVLAN("484174a2-ae74-4658-9e56-50fc90e086cf", "Production VLAN", "For hosting our Production servers", "10.0.3.0", 23, "", "")
+			if err == nil {
+				test.Fatal("DeployVLAN: expected error")
+			}
+
+			var conflictError *ConflictError
+			expect.IsTrue("errors.As(ConflictError)", errors.As(err, &conflictError))
+			expect.EqualsInt("ConflictError.StatusCode", http.StatusBadRequest, conflictError.StatusCode)
+			expect.EqualsString("ConflictError.RequestID", "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad", conflictError.RequestID)
+			expect.EqualsString("ConflictError.Operation", "DEPLOY_VLAN", conflictError.Operation)
+			expect.EqualsInt("ConflictError.FieldErrors size", 1, len(conflictError.FieldErrors))
+			expect.EqualsString("ConflictError.FieldErrors[0].FieldName", "name", conflictError.FieldErrors[0].FieldName)
+
+			var notFoundError *NotFoundError
+			expect.IsFalse("errors.As(NotFoundError)", errors.As(err, &notFoundError))
+
+			// Typed errors are views of the underlying APIError.
+			var apiError *APIError
+			expect.IsTrue("errors.As(APIError)", errors.As(conflictError, &apiError))
+			expect.IsTrue("IsAPIErrorCode(NAME_NOT_UNIQUE)", IsAPIErrorCode(fmt.Errorf("deploy failed: %w", err), ResponseCodeResourceNameNotUnique))
+		},
+		Respond: testRespond(http.StatusBadRequest, deployVLANConflictTestResponse),
+	})
+}
+
+// Typed errors are mapped from v2 response codes, v1 result codes and HTTP status codes.
+func TestAPIError_As(test *testing.T) {
+	expect := expect(test)
+
+	var (
+		notFoundError    *NotFoundError
+		dependencyError  *DependencyError
+		quotaError       *QuotaError
+		authError        *AuthError
+		maintenanceError *MaintenanceError
+	)
+
+	err := (&APIResponseV2{ResponseCode: ResponseCodeResourceHasDependency}).ToError("has dependency")
+	expect.IsTrue("errors.As(DependencyError)", errors.As(err, &dependencyError))
+
+	err = (&APIResponseV2{ResponseCode: ResponseCodeOutOfResources}).ToError("out of resources")
+	expect.IsTrue("errors.As(QuotaError)", errors.As(err, &quotaError))
+
+	err = (&APIResponseV2{ResponseCode: ResponseCodeInfrastructureInMaintenance}).ToError("in maintenance")
+	expect.IsTrue("errors.As(MaintenanceError)", errors.As(err, &maintenanceError))
+
+	err = (&APIResponseV2{ResponseCode: ResponseCodeAuthorizationFailure}).ToError("authorization failure")
+	expect.IsTrue("errors.As(AuthError)", errors.As(err, &authError))
+
+	err = (&APIResponseV1{
+		Operation:  "Delete Server",
+		ResultCode: ResultCodeServerNotFound,
+		AdditionalInformation: []APIResponseAdditionalInformationV1{
+			{Name: "serverId", Value: "5a32d6e4-9707-4813-a269-56ab4d989f4d"},
+		},
+	}).ToError("server not found")
+	expect.IsTrue("errors.As(NotFoundError) for REASON_395", errors.As(err, &notFoundError))
+	expect.EqualsString("NotFoundError.Operation", "Delete Server", notFoundError.Operation)
+	expect.EqualsInt("NotFoundError.FieldMessages size", 1, len(notFoundError.FieldMessages))
+	expect.EqualsString("NotFoundError.FieldMessages[0].FieldName", "serverId", notFoundError.FieldMessages[0].FieldName)
+
+	err = (&APIResponseV1{ResultCode: ResultCodeExceedsLimit}).ToError("exceeds limit")
+	expect.IsTrue("errors.As(QuotaError) for REASON_751", errors.As(err, &quotaError))
+	expect.IsFalse("errors.As(NotFoundError) for REASON_751", errors.As(err, &notFoundError))
+}
+
+// Get user account details (invalid credentials result in an AuthError).
+func TestClient_GetAccount_AuthError(test *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		http.Error(writer, "Invalid credentials.", http.StatusUnauthorized)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithBaseAddress(testServer.URL, "user1", "password")
+
+	_, err := client.GetAccount()
+
+	var authError *AuthError
+	if !errors.As(err, &authError) {
+		test.Fatalf("GetAccount: expected AuthError but was %#v", err)
+	}
+	if authError.StatusCode != http.StatusUnauthorized {
+		test.Fatalf("AuthError.StatusCode: expected %d but was %d", http.StatusUnauthorized, authError.StatusCode)
+	}
+}
+
+/*
+ * Test responses.
+ */
+
+var deployVLANConflictTestResponse = `
+	{
+		"operation": "DEPLOY_VLAN",
+		"responseCode": "NAME_NOT_UNIQUE",
+		"message": "A VLAN with the name 'Production VLAN' already exists in this Network Domain.",
+		"error": [
+			{
+				"name": "name",
+				"value": "Production VLAN"
+			}
+		],
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
@@ -0,0 +1,211 @@
+package compute
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Well-known filter field names (not every list operation supports every field).
+const (
+	// FilterFieldID is the name of the field used to filter by resource Id.
+	FilterFieldID = "id"
+
+	// FilterFieldName is the name of the field used to filter by resource name.
+	FilterFieldName = "name"
+
+	// FilterFieldState is the name of the field used to filter by resource state.
+	FilterFieldState = "state"
+
+	// FilterFieldCreateTime is the name of the field used to filter by resource creation date / time.
+	FilterFieldCreateTime = "createTime"
+
+	// FilterFieldDatacenterID is the name of the field used to filter by datacenter Id.
+	FilterFieldDatacenterID = "datacenterId"
+
+	// FilterFieldNetworkDomainID is the name of the field used to filter by network domain Id.
+	FilterFieldNetworkDomainID = "networkDomainId"
+
+	// FilterFieldVLANID is the name of the field used to filter by VLAN Id.
+	FilterFieldVLANID = "vlanId"
+
+	// FilterFieldSourceImageID is the name of the field used to filter servers by source image Id.
+	FilterFieldSourceImageID = "sourceImageId"
+
+	// FilterFieldDeployed is the name of the field used to filter servers by deployment status.
+	FilterFieldDeployed = "deployed"
+
+	// FilterFieldStarted is the name of the field used to filter servers by power status.
+	FilterFieldStarted = "started"
+
+	// FilterFieldPrivateIPv4 is the name of the field used to filter by private IPv4 address.
+	FilterFieldPrivateIPv4 = "privateIpv4"
+
+	// FilterFieldIPv6 is the name of the field used to filter by IPv6 address.
+	FilterFieldIPv6 = "ipv6"
+
+	// FilterFieldIPv4 is the name of the field used to filter by (internal or external) IPv4 address.
+	FilterFieldIPv4 = "ipv4"
+
+	// FilterFieldOperatingSystemFamily is the name of the field used to filter images by operating system family.
+	FilterFieldOperatingSystemFamily = "operatingSystemFamily"
+
+	// FilterFieldServerID is the name of the field used to filter by server Id.
+	FilterFieldServerID = "serverId"
+
+	// FilterFieldType is the name of the field used to filter snapshots by type (e.g. ServerSnapshotTypeManual).
+	FilterFieldType = "type"
+
+	// FilterFieldStartTime is the name of the field used to filter snapshots by start date / time.
+	FilterFieldStartTime = "startTime"
+)
+
+// Filter represents filtering and sorting criteria for a compute API list operation.
+//
+// Filter methods return the Filter, so calls can be chained:
+//
+//	filter := compute.NewFilter().State(compute.ResourceStatusPendingChange).OrderBy(compute.FilterFieldName)
+type Filter struct {
+	conditions url.Values
+	orderBy    []string
+}
+
+// NewFilter creates a new (empty) Filter.
+func NewFilter() *Filter {
+	return &Filter{
+		conditions: url.Values{},
+	}
+}
+
+// Equals adds a condition that the specified field must have the specified value.
+//
+// Adding multiple values for the same field matches resources where the field has any of those values.
+func (filter *Filter) Equals(fieldName string, value string) *Filter {
+	filter.conditions.Add(fieldName, value)
+
+	return filter
+}
+
+// Like adds a condition that the specified field must match the specified pattern (use "*" as a wildcard).
+func (filter *Filter) Like(fieldName string, pattern string) *Filter {
+	filter.conditions.Add(fieldName+".LIKE", pattern)
+
+	return filter
+}
+
+// Min adds a condition that the specified field must have a value greater than or equal to the specified value.
+func (filter *Filter) Min(fieldName string, value string) *Filter {
+	filter.conditions.Add(fieldName+".MIN", value)
+
+	return filter
+}
+
+// Max adds a condition that the specified field must have a value less than or equal to the specified value.
+func (filter *Filter) Max(fieldName string, value string) *Filter {
+	filter.conditions.Add(fieldName+".MAX", value)
+
+	return filter
+}
+
+// IsNull adds a condition that the specified field must have no value.
+func (filter *Filter) IsNull(fieldName string) *Filter {
+	filter.conditions.Add(fieldName+".NULL", "")
+
+	return filter
+}
+
+// IsNotNull adds a condition that the specified field must have a value.
+func (filter *Filter) IsNotNull(fieldName string) *Filter {
+	filter.conditions.Add(fieldName+".NOT_NULL", "")
+
+	return filter
+}
+
+// ID adds a condition that the resource Id must be the specified value.
+func (filter *Filter) ID(id string) *Filter {
+	return filter.Equals(FilterFieldID, id)
+}
+
+// Name adds a condition that the resource name must be the specified value.
+func (filter *Filter) Name(name string) *Filter {
+	return filter.Equals(FilterFieldName, name)
+}
+
+// State adds a condition that the resource state must be the specified value (e.g. ResourceStatusPendingChange).
+func (filter *Filter) State(state string) *Filter {
+	return filter.Equals(FilterFieldState, state)
+}
+
+// DatacenterID adds a condition that the resource must be located in the specified datacenter.
+func (filter *Filter) DatacenterID(datacenterID string) *Filter {
+	return filter.Equals(FilterFieldDatacenterID, datacenterID)
+}
+
+// CreatedAfter adds a condition that the resource must have been created at or after the specified date / time.
+func (filter *Filter) CreatedAfter(createTime time.Time) *Filter {
+	return filter.Min(FilterFieldCreateTime, formatFilterTime(createTime))
+}
+
+// CreatedBefore adds a condition that the resource must have been created at or before the specified date / time.
+func (filter *Filter) CreatedBefore(createTime time.Time) *Filter {
+	return filter.Max(FilterFieldCreateTime, formatFilterTime(createTime))
+}
+
+// OrderBy adds the specified field to the sort order (ascending).
+//
+// Results are sorted by fields in the order that they were added.
+func (filter *Filter) OrderBy(fieldName string) *Filter {
+	filter.orderBy = append(filter.orderBy, fieldName)
+
+	return filter
+}
+
+// OrderByDescending adds the specified field to the sort order (descending).
+//
+// Results are sorted by fields in the order that they were added.
+func (filter *Filter) OrderByDescending(fieldName string) *Filter {
+	filter.orderBy = append(filter.orderBy, fieldName+".DESCENDING")
+
+	return filter
+}
+
+// IsEmpty determines whether the filter has no conditions and no sort order.
+func (filter *Filter) IsEmpty() bool {
+	return filter == nil || (len(filter.conditions) == 0 && len(filter.orderBy) == 0)
+}
+
+// toQueryParameters serialises the filter as query parameters.
+//
+// Unless the filter is empty, the result is prefixed with "&" so that it can be appended to an existing query string.
+//
+// reservedFieldNames are the names of fields that the list operation already specifies in its query string (paging parameters are always reserved); returns an error if the filter has a condition on any of them.
+func (filter *Filter) toQueryParameters(reservedFieldNames ...string) (string, error) {
+	if filter.IsEmpty() {
+		return "", nil
+	}
+
+	reservedFieldNames = append(reservedFieldNames, "pageNumber", "pageSize", "orderBy")
+
+	queryParameters := url.Values{}
+	for conditionName, values := range filter.conditions {
+		fieldName := strings.SplitN(conditionName, ".", 2)[0]
+		for _, reservedFieldName := range reservedFieldNames {
+			if fieldName == reservedFieldName {
+				return "", fmt.Errorf("filter cannot specify a condition on field '%s' (it is already specified by the list operation)", fieldName)
+			}
+		}
+
+		queryParameters[conditionName] = values
+	}
+	if len(filter.orderBy) > 0 {
+		queryParameters.Set("orderBy", strings.Join(filter.orderBy, ","))
+	}
+
+	return "&" + queryParameters.Encode(), nil
+}
+
+// formatFilterTime formats a date / time for use in a filter condition (ISO 8601, UTC).
+func formatFilterTime(value time.Time) string {
+	return value.UTC().Format("2006-01-02T15:04:05.000Z")
+}
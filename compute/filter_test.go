@@ -0,0 +1,199 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// An empty filter produces no query parameters.
+func TestFilter_Empty(test *testing.T) {
+	expect := expect(test)
+
+	var nilFilter *Filter
+	expect.IsTrue("nil Filter.IsEmpty", nilFilter.IsEmpty())
+	queryParameters, err := nilFilter.toQueryParameters()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("nil Filter.toQueryParameters", "", queryParameters)
+
+	filter := NewFilter()
+	expect.IsTrue("Filter.IsEmpty", filter.IsEmpty())
+	queryParameters, err = filter.toQueryParameters()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Filter.toQueryParameters", "", queryParameters)
+}
+
+// A filter with conditions and sort order is serialised as query parameters.
+func TestFilter_ToQueryParameters(test *testing.T) {
+	expect := expect(test)
+
+	filter := NewFilter().
+		State(ResourceStatusPendingChange).
+		Like(FilterFieldName, "web*").
+		CreatedAfter(time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)).
+		OrderBy(FilterFieldName).
+		OrderByDescending(FilterFieldCreateTime)
+
+	expect.IsFalse("Filter.IsEmpty", filter.IsEmpty())
+
+	queryParameters, err := filter.toQueryParameters(FilterFieldNetworkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsString("Filter.toQueryParameters",
+		"&createTime.MIN=2017-01-02T03%3A04%3A05.000Z&name.LIKE=web%2A&orderBy=name%2CcreateTime.DESCENDING&state=PENDING_CHANGE",
+		queryParameters,
+	)
+}
+
+// A filter with conditions on fields already specified by the list operation (or on paging parameters) is rejected.
+func TestFilter_ToQueryParameters_ReservedField(test *testing.T) {
+	expect := expect(test)
+
+	_, err := NewFilter().Equals(FilterFieldNetworkDomainID, "484174a2-ae74-4658-9e56-50fc90e086cf").toQueryParameters(FilterFieldNetworkDomainID)
+	expect.NotNil("Error (networkDomainId)", err)
+
+	_, err = NewFilter().Like(FilterFieldDatacenterID, "NA*").toQueryParameters(FilterFieldDatacenterID)
+	expect.NotNil("Error (datacenterId.LIKE)", err)
+
+	_, err = NewFilter().Equals("pageSize", "500").toQueryParameters()
+	expect.NotNil("Error (pageSize)", err)
+
+	_, err = NewFilter().DatacenterID("NA9").toQueryParameters(FilterFieldNetworkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+}
+
+// List servers in a network domain, filtered by state (successful).
+func TestClient_ListServersInNetworkDomainWithFilter_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			filter := NewFilter().State(ResourceStatusPendingChange).OrderBy(FilterFieldName)
+
+			servers, err := client.ListServersInNetworkDomainWithFilter("484174a2-ae74-4658-9e56-50fc90e086cf", filter, nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Servers.PageCount", 0, servers.PageCount)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			query := request.URL.Query()
+
+			expect.EqualsString("Request.networkDomainId", "484174a2-ae74-4658-9e56-50fc90e086cf", query.Get("networkDomainId"))
+			expect.EqualsString("Request.pageNumber", "1", query.Get("pageNumber"))
+			expect.EqualsString("Request.state", ResourceStatusPendingChange, query.Get("state"))
+			expect.EqualsString("Request.orderBy", FilterFieldName, query.Get("orderBy"))
+
+			return http.StatusOK, `{"server": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 5}`
+		},
+	})
+}
+
+// List VLANs in a network domain, filtered by name (successful).
+func TestClient_ListVLANsWithFilter_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			filter := NewFilter().Like(FilterFieldName, "VLAN*").OrderByDescending(FilterFieldName)
+
+			vlans, err := client.ListVLANsWithFilter("484174a2-ae74-4658-9e56-50fc90e086cf", filter, &Paging{PageNumber: 2, PageSize: 10})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("VLANs.PageCount", 0, vlans.PageCount)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			query := request.URL.Query()
+
+			expect.EqualsInt("Request.networkDomainId (count)", 1, len(query["networkDomainId"]))
+			expect.EqualsString("Request.networkDomainId", "484174a2-ae74-4658-9e56-50fc90e086cf", query.Get("networkDomainId"))
+			expect.EqualsString("Request.pageNumber", "2", query.Get("pageNumber"))
+			expect.EqualsString("Request.pageSize", "10", query.Get("pageSize"))
+			expect.EqualsString("Request.name.LIKE", "VLAN*", query.Get("name.LIKE"))
+			expect.EqualsString("Request.orderBy", "name.DESCENDING", query.Get("orderBy"))
+
+			return http.StatusOK, `{"vlan": [], "pageNumber": 2, "pageCount": 0, "totalCount": 0, "pageSize": 10}`
+		},
+	})
+}
+
+// List VLANs with a filter on the network domain Id (rejected without sending a request).
+func TestClient_ListVLANsWithFilter_ReservedField(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			filter := NewFilter().Equals(FilterFieldNetworkDomainID, "some-other-network-domain")
+
+			vlans, err := client.ListVLANsWithFilter("484174a2-ae74-4658-9e56-50fc90e086cf", filter, nil)
+			expect.NotNil("Error", err)
+			expect.IsNil("VLANs", vlans)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			test.Fatalf("Unexpected request to '%s'.", request.URL.String())
+
+			return http.StatusInternalServerError, ""
+		},
+	})
+}
+
+// List datacenters, filtered by Id (successful).
+func TestClient_ListDatacentersWithFilter_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			datacenters, err := client.ListDatacentersWithFilter(NewFilter().ID("NA9").ID("NA12"), nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Datacenters.PageCount", 2, datacenters.PageCount)
+			expect.EqualsString("Datacenters[0].ID", "NA9", datacenters.Items[0].ID)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			query := request.URL.Query()
+
+			expect.EqualsString("Request.pageNumber", "1", query.Get("pageNumber"))
+			expect.EqualsString("Request.id", "NA9,NA12", strings.Join(query["id"], ","))
+
+			return http.StatusOK, `{"datacenter": [{"id": "NA9"}, {"id": "NA12"}], "pageNumber": 1, "pageCount": 2, "totalCount": 2, "pageSize": 50}`
+		},
+	})
+}
+
+// Retrieve all pages of filtered VLANs.
+func TestClient_AllVLANsWithFilter_Success(test *testing.T) {
+	expect := expect(test)
+
+	requestedPages := &pagerTestRequestLog{}
+	respondWithVLANPages := testRespondWithVLANPages(requestedPages, 50, 120)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			vlans, err := client.AllVLANsWithFilter("484174a2-ae74-4658-9e56-50fc90e086cf", NewFilter().State(ResourceStatusNormal))
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("VLANCount", 120, len(vlans))
+			expect.EqualsString("RequestedPages", "1,2,3", requestedPages.String())
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.state", ResourceStatusNormal, request.URL.Query().Get("state"))
+
+			return respondWithVLANPages(test, request)
+		},
+	})
+}
@@ -562,7 +562,7 @@ func (client *Client) GetFirewallRule(id string) (rule *FirewallRule, err error)
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -597,17 +597,30 @@ func (client *Client) GetFirewallRule(id string) (rule *FirewallRule, err error)
 
 // ListFirewallRules lists all firewall rules that apply to the specified network domain.
 func (client *Client) ListFirewallRules(networkDomainID string, paging *Paging) (rules *FirewallRules, err error) {
+	return client.ListFirewallRulesWithFilter(networkDomainID, nil, paging)
+}
+
+// ListFirewallRulesWithFilter lists the firewall rules that apply to the specified network domain and match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results.
+func (client *Client) ListFirewallRulesWithFilter(networkDomainID string, filter *Filter, paging *Paging) (rules *FirewallRules, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/network/firewallRule?networkDomainId=%s&%s",
+	filterParameters, err := filter.toQueryParameters(FilterFieldNetworkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/network/firewallRule?networkDomainId=%s&%s%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
+		filterParameters,
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -634,6 +647,22 @@ func (client *Client) ListFirewallRules(networkDomainID string, paging *Paging)
 	return rules, err
 }
 
+// AllFirewallRules retrieves all firewall rules in the specified network domain (across all pages of results).
+func (client *Client) AllFirewallRules(networkDomainID string) (rules []FirewallRule, err error) {
+	return client.AllFirewallRulesWithFilter(networkDomainID, nil)
+}
+
+// AllFirewallRulesWithFilter retrieves all firewall rules in the specified network domain that match the specified filter criteria (across all pages of results).
+func (client *Client) AllFirewallRulesWithFilter(networkDomainID string, filter *Filter) (rules []FirewallRule, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListFirewallRulesWithFilter(networkDomainID, filter, paging)
+	}, func(page PagedResults) {
+		rules = append(rules, page.(*FirewallRules).Rules...)
+	})
+
+	return
+}
+
 // CreateFirewallRule creates a new firewall rule.
 func (client *Client) CreateFirewallRule(configuration FirewallRuleConfiguration) (firewallRuleID string, err error) {
 	organizationID, err := client.getOrganizationID()
@@ -644,7 +673,10 @@ func (client *Client) CreateFirewallRule(configuration FirewallRuleConfiguration
 	requestURI := fmt.Sprintf("%s/network/createFirewallRule",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &configuration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &configuration)
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -679,10 +711,13 @@ func (client *Client) EditFirewallRule(id string, enabled bool) error {
 	requestURI := fmt.Sprintf("%s/network/editFirewallRule",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &editFirewallRule{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &editFirewallRule{
 		ID:      id,
 		Enabled: enabled,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -710,9 +745,12 @@ func (client *Client) DeleteFirewallRule(id string) error {
 	requestURI := fmt.Sprintf("%s/network/deleteFirewallRule",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost,
+	request, err := client.newRequestV2(requestURI, http.MethodPost,
 		&deleteFirewallRule{id},
 	)
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -32,9 +32,9 @@ func (client *Client) ListDefaultHealthMonitors(networkDomainID string, paging *
 	requestURI := fmt.Sprintf("%s/networkDomainVip/defaultHealthMonitor?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,464 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Instrumentation receives notifications about the requests performed by a Client (e.g. to record metrics or traces).
+//
+// Implementations must be safe for concurrent use.
+type Instrumentation interface {
+	// StartRequest is called before each attempt at performing a request to the compute API.
+	//
+	// The returned context is used to perform the request, and the returned function is called once the attempt is complete.
+	StartRequest(ctx context.Context, request RequestInfo) (context.Context, func(result RequestResult))
+
+	// StartPoll is called before each iteration of polling a resource's status (e.g. while performing WaitForDeploy).
+	//
+	// The returned context is used to perform the requests made while polling, and the returned function is called once the iteration is complete.
+	StartPoll(ctx context.Context, poll PollInfo) (context.Context, func(result PollResult))
+}
+
+// RequestInfo describes an attempt at performing a request to the compute API.
+type RequestInfo struct {
+	// The name of the logical operation (i.e. Client method) that performed the request (e.g. "DeployServer" or "ListVLANs").
+	Operation string
+
+	// The version of the compute API (e.g. "2.4", or "0.9" for the v1 API).
+	APIVersion string
+
+	// The HTTP method.
+	Method string
+
+	// The request URL.
+	URL string
+
+	// The attempt number (greater than 1 if the request is being retried).
+	Attempt int
+}
+
+// RequestResult describes the outcome of an attempt at performing a request to the compute API.
+type RequestResult struct {
+	// The HTTP status code (0 if no response was received).
+	StatusCode int
+
+	// The CloudControl response code (v2) or result code (v1), if present in the response.
+	ResponseCode string
+
+	// The time taken to perform the attempt.
+	Duration time.Duration
+
+	// The error (if any) that prevented a response from being received.
+	Err error
+}
+
+// PollInfo describes an iteration of polling a resource's status.
+type PollInfo struct {
+	// The name of the logical operation (i.e. Client method) that is polling (e.g. "WaitForDeploy").
+	Operation string
+
+	// A description of the resource type (e.g. "server").
+	ResourceType string
+
+	// The resource Id.
+	ResourceID string
+
+	// The iteration number (starting at 1).
+	Iteration int
+}
+
+// PollResult describes the outcome of an iteration of polling a resource's status.
+type PollResult struct {
+	// The resource's state (empty if the resource was not found).
+	State string
+
+	// The time taken to perform the iteration.
+	Duration time.Duration
+
+	// The error (if any) encountered while polling.
+	Err error
+}
+
+// SetInstrumentation configures the client to notify the specified Instrumentation about the requests it performs.
+// Set instrumentation to nil (the default) to disable instrumentation.
+func (client *Client) SetInstrumentation(instrumentation Instrumentation) {
+	client.instrumentation.Store(instrumentationHolder{instrumentation})
+}
+
+// getInstrumentation retrieves the client's instrumentation (if any).
+func (client *Client) getInstrumentation() Instrumentation {
+	holder, ok := client.instrumentation.Load().(instrumentationHolder)
+	if !ok {
+		return nil
+	}
+
+	return holder.instrumentation
+}
+
+// instrumentationHolder enables storage of a (possibly nil) Instrumentation in an atomic.Value.
+type instrumentationHolder struct {
+	instrumentation Instrumentation
+}
+
+// startRequest notifies the client's instrumentation (if any) that an attempt at performing a request is starting.
+//
+// Returns the context to use for the request, and a function to call when the attempt is complete.
+func (client *Client) startRequest(request *http.Request, attempt int) (context.Context, func(statusCode int, responseBody []byte, err error)) {
+	instrumentation := client.getInstrumentation()
+	if instrumentation == nil {
+		return client.Context(), func(int, []byte, error) {}
+	}
+
+	ctx, finish := instrumentation.StartRequest(client.Context(), RequestInfo{
+		Operation:  callerOperationName(request.Method + " " + request.URL.Path),
+		APIVersion: getAPIVersion(request.URL.Path),
+		Method:     request.Method,
+		URL:        request.URL.String(),
+		Attempt:    attempt,
+	})
+	startTime := time.Now()
+
+	return ctx, func(statusCode int, responseBody []byte, err error) {
+		finish(RequestResult{
+			StatusCode:   statusCode,
+			ResponseCode: getResponseCode(responseBody),
+			Duration:     time.Since(startTime),
+			Err:          err,
+		})
+	}
+}
+
+// startPoll notifies the client's instrumentation (if any) that an iteration of polling a resource's status is starting.
+//
+// Returns a copy of the client (bound to the context to use while polling), and a function to call when the iteration is complete.
+func (client *Client) startPoll(resourceDescription string, id string, iteration int) (*Client, func(resource Resource, err error)) {
+	instrumentation := client.getInstrumentation()
+	if instrumentation == nil {
+		return client, func(Resource, error) {}
+	}
+
+	ctx, finish := instrumentation.StartPoll(client.Context(), PollInfo{
+		Operation:    callerOperationName("WaitForResourceStatus"),
+		ResourceType: resourceDescription,
+		ResourceID:   id,
+		Iteration:    iteration,
+	})
+	startTime := time.Now()
+
+	return client.WithContext(ctx), func(resource Resource, err error) {
+		result := PollResult{
+			Duration: time.Since(startTime),
+			Err:      err,
+		}
+		if resource != nil && !resource.IsDeleted() {
+			result.State = resource.GetState()
+		}
+
+		finish(result)
+	}
+}
+
+// The prefix for the names of Client methods, as reported by runtime.FuncForPC (e.g. "github.com/xxx/compute.(*Client).").
+var clientMethodPrefix = reflect.TypeOf(Client{}).PkgPath() + ".(*Client)."
+
+// callerOperationName determines the name of the (exported) Client method that is performing the current request (e.g. "DeployServer").
+//
+// Returns defaultName if no exported Client method is found on the call stack.
+func callerOperationName(defaultName string) string {
+	callers := make([]uintptr, 32)
+	callerCount := runtime.Callers(2, callers)
+
+	frames := runtime.CallersFrames(callers[:callerCount])
+	for {
+		frame, more := frames.Next()
+
+		if strings.HasPrefix(frame.Function, clientMethodPrefix) {
+			// Strip closure suffixes (e.g. "DeployServer.func1").
+			methodName := strings.SplitN(frame.Function[len(clientMethodPrefix):], ".", 2)[0]
+			if methodName != "" && unicode.IsUpper(rune(methodName[0])) {
+				// Operations initiated via XXXAsync are reported under the same name as XXX.
+				return strings.TrimSuffix(methodName, "Async")
+			}
+		}
+
+		if !more {
+			return defaultName
+		}
+	}
+}
+
+// getAPIVersion extracts the compute API version (e.g. "2.4" or "0.9") from a request path.
+func getAPIVersion(requestPath string) string {
+	pathSegments := strings.SplitN(strings.TrimPrefix(requestPath, "/"), "/", 3)
+	if len(pathSegments) < 2 {
+		return ""
+	}
+
+	switch pathSegments[0] {
+	case "caas", "oec":
+		return pathSegments[1]
+	default:
+		return ""
+	}
+}
+
+// getResponseCode extracts the CloudControl response code (v2, JSON) or result code (v1, XML), if any, from a response body.
+func getResponseCode(responseBody []byte) string {
+	var responseV2 struct {
+		ResponseCode string `json:"responseCode"`
+	}
+	if json.Unmarshal(responseBody, &responseV2) == nil {
+		return responseV2.ResponseCode
+	}
+
+	var responseV1 struct {
+		ResultCode string `xml:"resultCode"`
+	}
+	if xml.Unmarshal(responseBody, &responseV1) == nil {
+		return responseV1.ResultCode
+	}
+
+	return ""
+}
+
+// MultiInstrumentation is an Instrumentation that notifies multiple Instrumentations (e.g. for both metrics and tracing).
+type MultiInstrumentation []Instrumentation
+
+// StartRequest is called before each attempt at performing a request to the compute API.
+func (instrumentations MultiInstrumentation) StartRequest(ctx context.Context, request RequestInfo) (context.Context, func(result RequestResult)) {
+	finishers := make([]func(result RequestResult), len(instrumentations))
+	for index, instrumentation := range instrumentations {
+		ctx, finishers[index] = instrumentation.StartRequest(ctx, request)
+	}
+
+	return ctx, func(result RequestResult) {
+		for index := len(finishers) - 1; index >= 0; index-- {
+			finishers[index](result)
+		}
+	}
+}
+
+// StartPoll is called before each iteration of polling a resource's status.
+func (instrumentations MultiInstrumentation) StartPoll(ctx context.Context, poll PollInfo) (context.Context, func(result PollResult)) {
+	finishers := make([]func(result PollResult), len(instrumentations))
+	for index, instrumentation := range instrumentations {
+		ctx, finishers[index] = instrumentation.StartPoll(ctx, poll)
+	}
+
+	return ctx, func(result PollResult) {
+		for index := len(finishers) - 1; index >= 0; index-- {
+			finishers[index](result)
+		}
+	}
+}
+
+var _ Instrumentation = MultiInstrumentation{}
+
+// Tracer creates spans (compatible with OpenTelemetry's trace.Tracer, via a thin wrapper).
+type Tracer interface {
+	// Start creates a span (as a child of the span, if any, in the specified context) and a context containing the new span.
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span represents a single operation within a trace (compatible with OpenTelemetry's trace.Span, via a thin wrapper).
+type Span interface {
+	// SetAttribute sets an attribute on the span.
+	//
+	// value is a string, int or bool.
+	SetAttribute(key string, value interface{})
+
+	// RecordError records an error as an exception event, and marks the span as failed.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// Well-known span attributes (names follow OpenTelemetry semantic conventions where applicable).
+const (
+	// SpanAttributeHTTPMethod is the span attribute containing the HTTP request method.
+	SpanAttributeHTTPMethod = "http.request.method"
+
+	// SpanAttributeHTTPStatusCode is the span attribute containing the HTTP response status code.
+	SpanAttributeHTTPStatusCode = "http.response.status_code"
+
+	// SpanAttributeURL is the span attribute containing the request URL.
+	SpanAttributeURL = "url.full"
+
+	// SpanAttributeAttempt is the span attribute containing the attempt number (greater than 1 if the request is being retried).
+	SpanAttributeAttempt = "http.request.resend_count"
+
+	// SpanAttributeAPIVersion is the span attribute containing the compute API version.
+	SpanAttributeAPIVersion = "cloudcontrol.api_version"
+
+	// SpanAttributeResponseCode is the span attribute containing the CloudControl response (or result) code.
+	SpanAttributeResponseCode = "cloudcontrol.response_code"
+
+	// SpanAttributeResourceType is the span attribute containing the resource type being polled.
+	SpanAttributeResourceType = "cloudcontrol.resource_type"
+
+	// SpanAttributeResourceID is the span attribute containing the Id of the resource being polled.
+	SpanAttributeResourceID = "cloudcontrol.resource_id"
+
+	// SpanAttributeResourceState is the span attribute containing the state of the resource being polled.
+	SpanAttributeResourceState = "cloudcontrol.resource_state"
+
+	// SpanAttributePollIteration is the span attribute containing the polling iteration number.
+	SpanAttributePollIteration = "cloudcontrol.poll_iteration"
+)
+
+// TracerInstrumentation is an Instrumentation that creates a span for each request attempt (named after its operation, e.g. "DeployServer") and for each polling iteration (e.g. "WaitForDeploy poll").
+type TracerInstrumentation struct {
+	tracer Tracer
+}
+
+// NewTracerInstrumentation creates a new TracerInstrumentation that uses the specified Tracer.
+func NewTracerInstrumentation(tracer Tracer) *TracerInstrumentation {
+	return &TracerInstrumentation{
+		tracer: tracer,
+	}
+}
+
+// StartRequest is called before each attempt at performing a request to the compute API.
+func (instrumentation *TracerInstrumentation) StartRequest(ctx context.Context, request RequestInfo) (context.Context, func(result RequestResult)) {
+	ctx, span := instrumentation.tracer.Start(ctx, request.Operation)
+	span.SetAttribute(SpanAttributeHTTPMethod, request.Method)
+	span.SetAttribute(SpanAttributeURL, request.URL)
+	span.SetAttribute(SpanAttributeAPIVersion, request.APIVersion)
+	span.SetAttribute(SpanAttributeAttempt, request.Attempt)
+
+	return ctx, func(result RequestResult) {
+		if result.StatusCode != 0 {
+			span.SetAttribute(SpanAttributeHTTPStatusCode, result.StatusCode)
+		}
+		if result.ResponseCode != "" {
+			span.SetAttribute(SpanAttributeResponseCode, result.ResponseCode)
+		}
+		if result.Err != nil {
+			span.RecordError(result.Err)
+		}
+
+		span.End()
+	}
+}
+
+// StartPoll is called before each iteration of polling a resource's status.
+func (instrumentation *TracerInstrumentation) StartPoll(ctx context.Context, poll PollInfo) (context.Context, func(result PollResult)) {
+	ctx, span := instrumentation.tracer.Start(ctx, poll.Operation+" poll")
+	span.SetAttribute(SpanAttributeResourceType, poll.ResourceType)
+	span.SetAttribute(SpanAttributeResourceID, poll.ResourceID)
+	span.SetAttribute(SpanAttributePollIteration, poll.Iteration)
+
+	return ctx, func(result PollResult) {
+		span.SetAttribute(SpanAttributeResourceState, result.State)
+		if result.Err != nil {
+			span.RecordError(result.Err)
+		}
+
+		span.End()
+	}
+}
+
+var _ Instrumentation = &TracerInstrumentation{}
+
+// MetricsRecorder records metrics (e.g. using Prometheus counter and histogram vectors, keyed by metric name).
+type MetricsRecorder interface {
+	// IncrementCounter increments the specified counter.
+	IncrementCounter(name string, labels map[string]string)
+
+	// ObserveHistogram adds an observation to the specified histogram.
+	ObserveHistogram(name string, labels map[string]string, value float64)
+}
+
+// Well-known metrics recorded by MetricsInstrumentation.
+const (
+	// MetricRequestsTotal is the counter of request attempts (labels: operation, api_version, method, status, response_code).
+	MetricRequestsTotal = "cloudcontrol_requests_total"
+
+	// MetricRequestRetriesTotal is the counter of request attempts that were retries (labels: operation, api_version, method).
+	MetricRequestRetriesTotal = "cloudcontrol_request_retries_total"
+
+	// MetricRequestDurationSeconds is the histogram of request attempt durations, in seconds (labels: operation, api_version, method, status, response_code).
+	MetricRequestDurationSeconds = "cloudcontrol_request_duration_seconds"
+
+	// MetricPollsTotal is the counter of resource-status polling iterations (labels: operation, resource_type, state).
+	MetricPollsTotal = "cloudcontrol_resource_polls_total"
+
+	// MetricPollDurationSeconds is the histogram of resource-status polling iteration durations, in seconds (labels: operation, resource_type, state).
+	MetricPollDurationSeconds = "cloudcontrol_resource_poll_duration_seconds"
+)
+
+// Metric label values used when a request or poll fails without a response.
+const (
+	// MetricLabelValueError is the value of the "status" (or "state") label for request attempts (or polling iterations) that failed without a response.
+	MetricLabelValueError = "error"
+)
+
+// MetricsInstrumentation is an Instrumentation that records Prometheus-style metrics for each request attempt and polling iteration.
+type MetricsInstrumentation struct {
+	recorder MetricsRecorder
+}
+
+// NewMetricsInstrumentation creates a new MetricsInstrumentation that uses the specified MetricsRecorder.
+func NewMetricsInstrumentation(recorder MetricsRecorder) *MetricsInstrumentation {
+	return &MetricsInstrumentation{
+		recorder: recorder,
+	}
+}
+
+// StartRequest is called before each attempt at performing a request to the compute API.
+func (instrumentation *MetricsInstrumentation) StartRequest(ctx context.Context, request RequestInfo) (context.Context, func(result RequestResult)) {
+	if request.Attempt > 1 {
+		instrumentation.recorder.IncrementCounter(MetricRequestRetriesTotal, map[string]string{
+			"operation":   request.Operation,
+			"api_version": request.APIVersion,
+			"method":      request.Method,
+		})
+	}
+
+	return ctx, func(result RequestResult) {
+		status := MetricLabelValueError
+		if result.StatusCode != 0 {
+			status = strconv.Itoa(result.StatusCode)
+		}
+		labels := map[string]string{
+			"operation":     request.Operation,
+			"api_version":   request.APIVersion,
+			"method":        request.Method,
+			"status":        status,
+			"response_code": result.ResponseCode,
+		}
+
+		instrumentation.recorder.IncrementCounter(MetricRequestsTotal, labels)
+		instrumentation.recorder.ObserveHistogram(MetricRequestDurationSeconds, labels, result.Duration.Seconds())
+	}
+}
+
+// StartPoll is called before each iteration of polling a resource's status.
+func (instrumentation *MetricsInstrumentation) StartPoll(ctx context.Context, poll PollInfo) (context.Context, func(result PollResult)) {
+	return ctx, func(result PollResult) {
+		state := result.State
+		if result.Err != nil {
+			state = MetricLabelValueError
+		}
+		labels := map[string]string{
+			"operation":     poll.Operation,
+			"resource_type": poll.ResourceType,
+			"state":         state,
+		}
+
+		instrumentation.recorder.IncrementCounter(MetricPollsTotal, labels)
+		instrumentation.recorder.ObserveHistogram(MetricPollDurationSeconds, labels, result.Duration.Seconds())
+	}
+}
+
+var _ Instrumentation = &MetricsInstrumentation{}
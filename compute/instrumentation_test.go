@@ -0,0 +1,222 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Request attempts (including retries) are reported as spans and metrics, named after the Client method that performed them.
+func TestClient_Instrumentation_Retry(test *testing.T) {
+	expect := expect(test)
+
+	tracer := &testTracer{}
+	metrics := &testMetricsRecorder{}
+
+	requestCount := 0
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			client.SetRetryPolicy(NewExponentialBackoffRetryPolicy(3, 1*time.Millisecond))
+			client.SetInstrumentation(MultiInstrumentation{
+				NewTracerInstrumentation(tracer),
+				NewMetricsInstrumentation(metrics),
+			})
+
+			err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+			if err != nil {
+				test.Fatal(err)
+			}
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			requestCount++
+			if requestCount < 2 {
+				return http.StatusBadRequest, retryTestResourceBusyResponse
+			}
+
+			return http.StatusOK, retryTestOKResponse
+		},
+	})
+
+	spans := tracer.Spans()
+	expect.EqualsInt("Span count", 2, len(spans))
+	for index, span := range spans {
+		expect.EqualsString(fmt.Sprintf("Span[%d].Name", index), "DeleteVLAN", span.Name)
+		expect.EqualsString(fmt.Sprintf("Span[%d].APIVersion", index), "2.9", span.Attributes[SpanAttributeAPIVersion].(string))
+		expect.EqualsString(fmt.Sprintf("Span[%d].Method", index), "POST", span.Attributes[SpanAttributeHTTPMethod].(string))
+		expect.EqualsInt(fmt.Sprintf("Span[%d].Attempt", index), index+1, span.Attributes[SpanAttributeAttempt].(int))
+		expect.IsTrue(fmt.Sprintf("Span[%d].Ended", index), span.Ended)
+	}
+	expect.EqualsInt("Span[0].StatusCode", http.StatusBadRequest, spans[0].Attributes[SpanAttributeHTTPStatusCode].(int))
+	expect.EqualsString("Span[0].ResponseCode", ResponseCodeResourceBusy, spans[0].Attributes[SpanAttributeResponseCode].(string))
+	expect.EqualsInt("Span[1].StatusCode", http.StatusOK, spans[1].Attributes[SpanAttributeHTTPStatusCode].(int))
+	expect.EqualsString("Span[1].ResponseCode", ResponseCodeInProgress, spans[1].Attributes[SpanAttributeResponseCode].(string))
+
+	expect.EqualsInt("Counter(requests, RESOURCE_BUSY)", 1, metrics.Counter(MetricRequestsTotal, "operation=DeleteVLAN", "status=400", "response_code="+ResponseCodeResourceBusy))
+	expect.EqualsInt("Counter(requests, IN_PROGRESS)", 1, metrics.Counter(MetricRequestsTotal, "operation=DeleteVLAN", "status=200", "response_code="+ResponseCodeInProgress))
+	expect.EqualsInt("Counter(retries)", 1, metrics.Counter(MetricRequestRetriesTotal, "operation=DeleteVLAN"))
+	expect.EqualsInt("Histogram(duration) count", 2, metrics.HistogramCount(MetricRequestDurationSeconds, "operation=DeleteVLAN"))
+}
+
+// Polling iterations (and the requests they perform) are reported as spans and metrics.
+func TestClient_Instrumentation_WaitForDeploy(test *testing.T) {
+	if testing.Short() {
+		test.Skip("Skipping test that waits for CloudControl poll intervals (short mode).")
+	}
+
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, getVLANTestResponse)
+	}))
+	defer testServer.Close()
+
+	tracer := &testTracer{}
+	metrics := &testMetricsRecorder{}
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithInstrumentation(MultiInstrumentation{
+			NewTracerInstrumentation(tracer),
+			NewMetricsInstrumentation(metrics),
+		}),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	_, err := client.WaitForDeploy(ResourceTypeVLAN, "0e56433f-d808-4669-821d-812769517ff8", 30*time.Second)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	spans := tracer.Spans()
+	expect.EqualsInt("Span count", 2, len(spans))
+	expect.EqualsString("Span[0].Name", "WaitForDeploy poll", spans[0].Name)
+	expect.EqualsString("Span[0].ResourceState", ResourceStatusNormal, spans[0].Attributes[SpanAttributeResourceState].(string))
+	expect.EqualsInt("Span[0].PollIteration", 1, spans[0].Attributes[SpanAttributePollIteration].(int))
+	expect.EqualsString("Span[1].Name", "GetVLAN", spans[1].Name)
+	expect.IsTrue("Span[1].Parent is Span[0]", spans[1].Parent == spans[0])
+
+	expect.EqualsInt("Counter(polls)", 1, metrics.Counter(MetricPollsTotal, "operation=WaitForDeploy", "resource_type=VLAN", "state=NORMAL"))
+}
+
+// testTracer is a Tracer that records the spans it creates.
+type testTracer struct {
+	stateLock sync.Mutex
+	spans     []*testSpan
+}
+
+type testSpanContextKey struct{}
+
+func (tracer *testTracer) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	tracer.stateLock.Lock()
+	defer tracer.stateLock.Unlock()
+
+	span := &testSpan{
+		Name:       spanName,
+		Attributes: make(map[string]interface{}),
+	}
+	span.Parent, _ = ctx.Value(testSpanContextKey{}).(*testSpan)
+	tracer.spans = append(tracer.spans, span)
+
+	return context.WithValue(ctx, testSpanContextKey{}, span), span
+}
+
+func (tracer *testTracer) Spans() []*testSpan {
+	tracer.stateLock.Lock()
+	defer tracer.stateLock.Unlock()
+
+	return append([]*testSpan(nil), tracer.spans...)
+}
+
+type testSpan struct {
+	Name       string
+	Parent     *testSpan
+	Attributes map[string]interface{}
+	Err        error
+	Ended      bool
+}
+
+func (span *testSpan) SetAttribute(key string, value interface{}) {
+	span.Attributes[key] = value
+}
+
+func (span *testSpan) RecordError(err error) {
+	span.Err = err
+}
+
+func (span *testSpan) End() {
+	span.Ended = true
+}
+
+// testMetricsRecorder is a MetricsRecorder that records metrics in memory.
+type testMetricsRecorder struct {
+	stateLock  sync.Mutex
+	counters   []testMetric
+	histograms []testMetric
+}
+
+type testMetric struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+func (recorder *testMetricsRecorder) IncrementCounter(name string, labels map[string]string) {
+	recorder.stateLock.Lock()
+	defer recorder.stateLock.Unlock()
+
+	recorder.counters = append(recorder.counters, testMetric{Name: name, Labels: labels, Value: 1})
+}
+
+func (recorder *testMetricsRecorder) ObserveHistogram(name string, labels map[string]string, value float64) {
+	recorder.stateLock.Lock()
+	defer recorder.stateLock.Unlock()
+
+	recorder.histograms = append(recorder.histograms, testMetric{Name: name, Labels: labels, Value: value})
+}
+
+// Counter retrieves the value of the specified counter, for the labels specified as "name=value".
+func (recorder *testMetricsRecorder) Counter(name string, labels ...string) int {
+	recorder.stateLock.Lock()
+	defer recorder.stateLock.Unlock()
+
+	return countMatchingMetrics(recorder.counters, name, labels)
+}
+
+// HistogramCount retrieves the number of observations of the specified histogram, for the labels specified as "name=value".
+func (recorder *testMetricsRecorder) HistogramCount(name string, labels ...string) int {
+	recorder.stateLock.Lock()
+	defer recorder.stateLock.Unlock()
+
+	return countMatchingMetrics(recorder.histograms, name, labels)
+}
+
+func countMatchingMetrics(metrics []testMetric, name string, labels []string) int {
+	count := 0
+	for _, metric := range metrics {
+		if metric.Name != name {
+			continue
+		}
+
+		isMatch := true
+		for _, label := range labels {
+			nameAndValue := strings.SplitN(label, "=", 2)
+			if metric.Labels[nameAndValue[0]] != nameAndValue[1] {
+				isMatch = false
+			}
+		}
+		if isMatch {
+			count++
+		}
+	}
+
+	return count
+}
@@ -102,7 +102,7 @@ func (client *Client) GetPublicIPBlock(id string) (block *PublicIPBlock, err err
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -145,9 +145,9 @@ func (client *Client) ListPublicIPBlocks(networkDomainID string, paging *Paging)
 	requestURI := fmt.Sprintf("%s/network/publicIpBlock?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -174,71 +174,109 @@ func (client *Client) ListPublicIPBlocks(networkDomainID string, paging *Paging)
 	return blocks, err
 }
 
+// AllPublicIPBlocks retrieves all public IPv4 address blocks in the specified network domain (across all pages of results).
+func (client *Client) AllPublicIPBlocks(networkDomainID string) (blocks []PublicIPBlock, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListPublicIPBlocks(networkDomainID, paging)
+	}, func(page PagedResults) {
+		blocks = append(blocks, page.(*PublicIPBlocks).Blocks...)
+	})
+
+	return
+}
+
 // AddPublicIPBlock adds a new block of public IPv4 addresses to the specified network domain.
 func (client *Client) AddPublicIPBlock(networkDomainID string) (blockID string, err error) {
-	organizationID, err := client.getOrganizationID()
+	operation, err := client.AddPublicIPBlockAsync(networkDomainID)
 	if err != nil {
 		return "", err
 	}
 
+	return operation.ResourceID, nil
+}
+
+// AddPublicIPBlockAsync is equivalent to AddPublicIPBlock, but returns an Operation that can be used to wait for the add operation to complete.
+//
+// CloudControl completes this operation synchronously, so the returned Operation has already completed.
+func (client *Client) AddPublicIPBlockAsync(networkDomainID string) (operation *Operation, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
 	requestURI := fmt.Sprintf("%s/network/addPublicIpBlock",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodPost,
+	request, err := client.newRequestV2(requestURI, http.MethodPost,
 		&addPublicAddressBlock{networkDomainID},
 	)
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeOK {
-		return "", apiResponse.ToError("Request to add IPv4 address block to network domain '%s' failed with unexpected status code %d (%s): %s", networkDomainID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to add IPv4 address block to network domain '%s' failed with unexpected status code %d (%s): %s", networkDomainID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
 	// Expected: "info" { "name": "ipBlockId", "value": "the-Id-of-the-new-IP-block" }
 	ipBlockIDMessage := apiResponse.GetFieldMessage("ipBlockId")
 	if ipBlockIDMessage == nil {
-		return "", apiResponse.ToError("Received an unexpected response (missing 'ipBlockId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Received an unexpected response (missing 'ipBlockId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return *ipBlockIDMessage, nil
+	return client.newCompletedOperation(ResourceTypePublicIPBlock, *ipBlockIDMessage, "Add", apiResponse), nil
 }
 
 // RemovePublicIPBlock removes the specified block of public IPv4 addresses from its network domain.
 // This operation is synchronous.
 func (client *Client) RemovePublicIPBlock(id string) error {
+	_, err := client.RemovePublicIPBlockAsync(id)
+
+	return err
+}
+
+// RemovePublicIPBlockAsync is equivalent to RemovePublicIPBlock, but returns an Operation that can be used to wait for the remove operation to complete.
+//
+// CloudControl completes this operation synchronously, so the returned Operation has already completed.
+func (client *Client) RemovePublicIPBlockAsync(id string) (operation *Operation, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/network/removePublicIpBlock",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodPost,
+	request, err := client.newRequestV2(requestURI, http.MethodPost,
 		&removePublicAddressBlock{id},
 	)
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeOK {
-		return apiResponse.ToError("Request to remove IPv4 address block '%s' failed with unexpected status code %d (%s): %s", id, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to remove IPv4 address block '%s' failed with unexpected status code %d (%s): %s", id, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return nil
+	return client.newCompletedOperation(ResourceTypePublicIPBlock, id, "Remove", apiResponse), nil
 }
 
 // ListReservedPublicIPAddresses retrieves all public IPv4 addresses in the specified network domain that have been reserved.
@@ -251,9 +289,9 @@ func (client *Client) ListReservedPublicIPAddresses(networkDomainID string, pagi
 	requestURI := fmt.Sprintf("%s/network/reservedPublicIpv4Address?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,294 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute/requests"
+)
+
+// LogLevel represents the severity of a log entry.
+type LogLevel int
+
+const (
+	// LogLevelDebug is the level for detailed diagnostic information (e.g. request and response bodies).
+	LogLevelDebug LogLevel = iota
+
+	// LogLevelInfo is the level for general information (e.g. progress of WaitForXXX operations).
+	LogLevelInfo
+
+	// LogLevelWarn is the level for unexpected (but recoverable) conditions (e.g. a request that will be retried).
+	LogLevelWarn
+
+	// LogLevelError is the level for errors.
+	LogLevelError
+)
+
+// String returns the name of the log level.
+func (level LogLevel) String() string {
+	switch level {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(level))
+	}
+}
+
+// Well-known log field names.
+const (
+	// LogFieldMethod is the name of the log field containing the HTTP method of a request.
+	LogFieldMethod = "method"
+
+	// LogFieldURL is the name of the log field containing the URL of a request.
+	LogFieldURL = "url"
+
+	// LogFieldStatus is the name of the log field containing the HTTP status code of a response.
+	LogFieldStatus = "status"
+
+	// LogFieldRequestID is the name of the log field containing the CloudControl request Id (correlation identifier) from a response.
+	LogFieldRequestID = "request_id"
+
+	// LogFieldResponseCode is the name of the log field containing the CloudControl response code (e.g. RESOURCE_BUSY) from a response.
+	LogFieldResponseCode = "response_code"
+
+	// LogFieldDuration is the name of the log field containing the time taken to perform a request.
+	LogFieldDuration = "duration"
+
+	// LogFieldAttempt is the name of the log field containing the attempt number for a request (see RetryPolicy).
+	LogFieldAttempt = "attempt"
+
+	// LogFieldBody is the name of the log field containing a (redacted) request or response body.
+	LogFieldBody = "body"
+
+	// LogFieldHeaders is the name of the log field containing (redacted) request headers.
+	LogFieldHeaders = "headers"
+
+	// LogFieldError is the name of the log field containing an error.
+	LogFieldError = "error"
+
+	// LogFieldResourceType is the name of the log field containing the type of resource being waited on.
+	LogFieldResourceType = "resource_type"
+
+	// LogFieldResourceID is the name of the log field containing the Id of a resource.
+	LogFieldResourceID = "resource_id"
+
+	// LogFieldState is the name of the log field containing the state of a resource.
+	LogFieldState = "state"
+
+	// LogFieldLockKeys is the name of the log field containing the resources locked by a LockManager.
+	LogFieldLockKeys = "lock_keys"
+
+	// LogFieldNetworkDomainID is the name of the log field containing the Id of the network domain that contains the resources being waited on.
+	LogFieldNetworkDomainID = "network_domain_id"
+
+	// LogFieldPendingCount is the name of the log field containing the number of resources that are still being waited on.
+	LogFieldPendingCount = "pending_count"
+
+	// LogFieldChange is the name of the log field containing a description of a change being made by a reconciler (e.g. ApplyServerDiskLayout).
+	LogFieldChange = "change"
+)
+
+// LogField represents a named value attached to a log entry.
+type LogField struct {
+	Name  string
+	Value interface{}
+}
+
+// Logger receives log entries from the client.
+type Logger interface {
+	// IsEnabled determines whether log entries with the specified level will be logged.
+	IsEnabled(level LogLevel) bool
+
+	// Log logs an entry with the specified level, message, and fields.
+	Log(level LogLevel, message string, fields ...LogField)
+}
+
+// NewStandardLogger creates a Logger that writes to a logger from the standard library's log package.
+//
+// If logger is nil, the standard logger (log.Default()) is used. Entries with a level lower than minLevel are discarded.
+func NewStandardLogger(logger *log.Logger, minLevel LogLevel) Logger {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &standardLogger{
+		logger:   logger,
+		minLevel: minLevel,
+	}
+}
+
+// standardLogger is a Logger that writes to a *log.Logger.
+type standardLogger struct {
+	logger   *log.Logger
+	minLevel LogLevel
+}
+
+// IsEnabled determines whether log entries with the specified level will be logged.
+func (logger *standardLogger) IsEnabled(level LogLevel) bool {
+	return level >= logger.minLevel
+}
+
+// Log logs an entry with the specified level, message, and fields.
+func (logger *standardLogger) Log(level LogLevel, message string, fields ...LogField) {
+	if !logger.IsEnabled(level) {
+		return
+	}
+
+	entry := &strings.Builder{}
+	fmt.Fprintf(entry, "[%s] %s", level, message)
+	for _, field := range fields {
+		fmt.Fprintf(entry, " %s=%q", field.Name, fmt.Sprint(field.Value))
+	}
+
+	logger.logger.Print(entry.String())
+}
+
+// NewSlogLogger creates a Logger that writes to a structured logger from the standard library's log/slog package.
+//
+// If logger is nil, the default structured logger (slog.Default()) is used.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &slogLogger{logger}
+}
+
+// slogLogger is a Logger that writes to a *slog.Logger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// IsEnabled determines whether log entries with the specified level will be logged.
+func (logger *slogLogger) IsEnabled(level LogLevel) bool {
+	return logger.logger.Enabled(context.Background(), toSlogLevel(level))
+}
+
+// Log logs an entry with the specified level, message, and fields.
+func (logger *slogLogger) Log(level LogLevel, message string, fields ...LogField) {
+	attributes := make([]slog.Attr, len(fields))
+	for index, field := range fields {
+		attributes[index] = slog.Any(field.Name, field.Value)
+	}
+
+	logger.logger.LogAttrs(context.Background(), toSlogLevel(level), message, attributes...)
+}
+
+// toSlogLevel converts a LogLevel to the equivalent slog.Level.
+func toSlogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelInfo:
+		return slog.LevelInfo
+	case LogLevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// loggerHolder enables storage of a Logger in an atomic.Value.
+type loggerHolder struct {
+	logger Logger
+}
+
+var (
+	// The default Logger (the standard library's log package).
+	defaultLogger = NewStandardLogger(nil, LogLevelInfo)
+
+	// The default Logger when extended logging is enabled.
+	defaultExtendedLogger = NewStandardLogger(nil, LogLevelDebug)
+)
+
+// SetLogger configures the Logger that receives the client's log entries.
+//
+// Set logger to nil to restore the default (the standard library's log package, at LogLevelInfo or, if extended logging is enabled, LogLevelDebug).
+func (client *Client) SetLogger(logger Logger) {
+	client.logger.Store(loggerHolder{logger})
+}
+
+// getLogger retrieves the Logger that receives the client's log entries.
+func (client *Client) getLogger() Logger {
+	holder, ok := client.logger.Load().(loggerHolder)
+	if ok && holder.logger != nil {
+		return holder.logger
+	}
+
+	if client.IsExtendedLoggingEnabled() {
+		return defaultExtendedLogger
+	}
+
+	return defaultLogger
+}
+
+// logDebug logs an entry at LogLevelDebug.
+func (client *Client) logDebug(message string, fields ...LogField) {
+	client.writeLog(LogLevelDebug, message, fields...)
+}
+
+// logInfo logs an entry at LogLevelInfo.
+func (client *Client) logInfo(message string, fields ...LogField) {
+	client.writeLog(LogLevelInfo, message, fields...)
+}
+
+// logWarn logs an entry at LogLevelWarn.
+func (client *Client) logWarn(message string, fields ...LogField) {
+	client.writeLog(LogLevelWarn, message, fields...)
+}
+
+// logError logs an entry at LogLevelError.
+func (client *Client) logError(message string, fields ...LogField) {
+	client.writeLog(LogLevelError, message, fields...)
+}
+
+// writeLog logs an entry with the specified level (if that level is enabled).
+func (client *Client) writeLog(level LogLevel, message string, fields ...LogField) {
+	logger := client.getLogger()
+	if !logger.IsEnabled(level) {
+		return
+	}
+
+	logger.Log(level, message, fields...)
+}
+
+// logField creates a LogField.
+func logField(name string, value interface{}) LogField {
+	return LogField{
+		Name:  name,
+		Value: value,
+	}
+}
+
+// redactBody removes sensitive data (administrator passwords and private keys) from a request or response body so that it can be logged.
+func redactBody(body []byte) string {
+	return requests.RedactBody(body)
+}
+
+// redactHeaders creates a copy of the specified request headers with sensitive values (e.g. the Authorization header) removed so that they can be logged.
+func redactHeaders(headers http.Header) http.Header {
+	return requests.RedactHeaders(headers)
+}
+
+// getResponseRequestID extracts the CloudControl request Id (if any) from a v2 API response body.
+func getResponseRequestID(responseBody []byte) string {
+	var response struct {
+		RequestID string `json:"requestId"`
+	}
+	if json.Unmarshal(responseBody, &response) != nil {
+		return ""
+	}
+
+	return response.RequestID
+}
@@ -50,7 +50,7 @@ func (client *Client) GetNATRule(id string) (rule *NATRule, err error) {
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -85,17 +85,30 @@ func (client *Client) GetNATRule(id string) (rule *NATRule, err error) {
 
 // ListNATRules retrieves all NAT rules defined for the specified network domain.
 func (client *Client) ListNATRules(networkDomainID string, paging *Paging) (rules *NATRules, err error) {
+	return client.ListNATRulesWithFilter(networkDomainID, nil, paging)
+}
+
+// ListNATRulesWithFilter retrieves the NAT rules defined for the specified network domain that match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results.
+func (client *Client) ListNATRulesWithFilter(networkDomainID string, filter *Filter, paging *Paging) (rules *NATRules, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/network/natRule?networkDomainId=%s&%s",
+	filterParameters, err := filter.toQueryParameters(FilterFieldNetworkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/network/natRule?networkDomainId=%s&%s%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
+		filterParameters,
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2ForOperation("network/natRule#list", requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +135,22 @@ func (client *Client) ListNATRules(networkDomainID string, paging *Paging) (rule
 	return rules, err
 }
 
+// AllNATRules retrieves all NAT rules in the specified network domain (across all pages of results).
+func (client *Client) AllNATRules(networkDomainID string) (rules []NATRule, err error) {
+	return client.AllNATRulesWithFilter(networkDomainID, nil)
+}
+
+// AllNATRulesWithFilter retrieves all NAT rules in the specified network domain that match the specified filter criteria (across all pages of results).
+func (client *Client) AllNATRulesWithFilter(networkDomainID string, filter *Filter) (rules []NATRule, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListNATRulesWithFilter(networkDomainID, filter, paging)
+	}, func(page PagedResults) {
+		rules = append(rules, page.(*NATRules).Rules...)
+	})
+
+	return
+}
+
 // AddNATRule creates a new NAT rule to forward traffic from the specified external IPv4 address to the specified internal IPv4 address.
 // If externalIPAddress is not specified, an unallocated IPv4 address will be used (if available).
 //
@@ -135,11 +164,14 @@ func (client *Client) AddNATRule(networkDomainID string, internalIPAddress strin
 	requestURI := fmt.Sprintf("%s/network/createNatRule",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodPost, &createNATRule{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &createNATRule{
 		NetworkDomainID:   networkDomainID,
 		InternalIPAddress: internalIPAddress,
 		ExternalIPAddress: externalIPAddress,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -173,9 +205,12 @@ func (client *Client) DeleteNATRule(id string) error {
 	requestURI := fmt.Sprintf("%s/network/deleteNatRule",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost,
+	request, err := client.newRequestV2(requestURI, http.MethodPost,
 		&deleteNATRule{id},
 	)
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
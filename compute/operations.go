@@ -0,0 +1,223 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Operation represents an operation on a resource that has been initiated by a mutating call (e.g. DeployServerAsync).
+//
+// Use Wait to wait for the operation to complete, or Poll to check its progress without waiting.
+type Operation struct {
+	// The type of resource affected by the operation.
+	ResourceType ResourceType
+
+	// The Id of the resource affected by the operation.
+	ResourceID string
+
+	// A short description of the operation (e.g. "Deploy").
+	ActionDescription string
+
+	// The state that the resource is expected to be in while the operation is in progress (e.g. ResourceStatusPendingAdd).
+	//
+	// Empty if CloudControl completed the operation synchronously.
+	ExpectedState string
+
+	// The CloudControl request Id (if any) of the request that initiated the operation.
+	RequestID string
+
+	client    *Client
+	isDelete  bool
+	startTime time.Time
+
+	stateLock sync.Mutex
+	pollCount int
+	done      bool
+	resource  Resource
+	err       error
+}
+
+// newPendingOperation creates a new Operation that will complete once the resource is no longer in the expected (transitional) state.
+func (client *Client) newPendingOperation(resourceType ResourceType, id string, actionDescription string, expectedState string, isDelete bool, apiResponse APIResponse) *Operation {
+	return &Operation{
+		ResourceType:      resourceType,
+		ResourceID:        id,
+		ActionDescription: actionDescription,
+		ExpectedState:     expectedState,
+		RequestID:         apiResponse.GetRequestID(),
+		client:            client,
+		isDelete:          isDelete,
+		startTime:         time.Now(),
+	}
+}
+
+// newCompletedOperation creates a new Operation that CloudControl has already completed.
+func (client *Client) newCompletedOperation(resourceType ResourceType, id string, actionDescription string, apiResponse APIResponse) *Operation {
+	return &Operation{
+		ResourceType:      resourceType,
+		ResourceID:        id,
+		ActionDescription: actionDescription,
+		RequestID:         apiResponse.GetRequestID(),
+		client:            client,
+		startTime:         time.Now(),
+		done:              true,
+	}
+}
+
+// Done determines whether the operation has completed (successfully or otherwise).
+func (operation *Operation) Done() bool {
+	operation.stateLock.Lock()
+	defer operation.stateLock.Unlock()
+
+	return operation.done
+}
+
+// Err retrieves the error (if any) that caused the operation to fail.
+//
+// Returns nil if the operation has not completed, or completed successfully.
+func (operation *Operation) Err() error {
+	operation.stateLock.Lock()
+	defer operation.stateLock.Unlock()
+
+	return operation.err
+}
+
+// Resource retrieves the resource, as it was when the operation completed.
+//
+// Returns nil if the operation has not completed, deleted the resource, or was completed synchronously by CloudControl.
+func (operation *Operation) Resource() Resource {
+	operation.stateLock.Lock()
+	defer operation.stateLock.Unlock()
+
+	return operation.resource
+}
+
+// Poll checks the resource's current state to determine whether the operation has completed.
+//
+// If an error is encountered while retrieving the resource, it is returned but the operation is not considered to have completed.
+func (operation *Operation) Poll() (done bool, err error) {
+	return operation.poll(operation.client)
+}
+
+// Wait waits for the operation to complete (or the context to be cancelled), polling the resource's state as configured by the client's Waiter (see Client.SetWaiter).
+//
+// Returns the resource (nil if it was deleted), a ResourceFailedError if the resource enters a failure state, or an OperationCancelledError if the context is cancelled before the operation completes.
+func (operation *Operation) Wait(ctx context.Context) (resource Resource, err error) {
+	client := operation.client.WithContext(ctx)
+
+	operationDescription := fmt.Sprintf("Wait for %s of %s",
+		operation.ActionDescription,
+		operation.resourceDescription(),
+	)
+
+	waiter := client.getWaiter()
+	for iteration := 1; !operation.Done(); iteration++ {
+		pollTimer := time.NewTimer(waiter.pollInterval(iteration))
+
+		select {
+		case <-ctx.Done():
+			pollTimer.Stop()
+
+			return nil, client.checkCancellation(operationDescription)
+
+		case <-pollTimer.C:
+			err = client.checkCancellation(operationDescription)
+			if err != nil {
+				return nil, err
+			}
+
+			done, err := operation.poll(client)
+			if err != nil && !done {
+				return nil, err
+			}
+		}
+	}
+
+	return operation.Resource(), operation.Err()
+}
+
+// poll retrieves the resource's current state (using the specified client) to determine whether the operation has completed.
+func (operation *Operation) poll(client *Client) (done bool, err error) {
+	operation.stateLock.Lock()
+	if operation.done {
+		operation.stateLock.Unlock()
+
+		return true, operation.Err()
+	}
+	operation.pollCount++
+	iteration := operation.pollCount
+	operation.stateLock.Unlock()
+
+	resourceDescription, err := GetResourceDescription(operation.ResourceType)
+	if err != nil {
+		return false, err
+	}
+
+	client.logDebug("Polling resource status...", logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, operation.ResourceID))
+
+	pollClient, finishPoll := client.startPoll(resourceDescription, operation.ResourceID, iteration)
+	resource, err := pollClient.GetResource(operation.ResourceID, operation.ResourceType)
+	finishPoll(resource, err)
+	if err != nil {
+		return false, err
+	}
+
+	if resource == nil || resource.IsDeleted() {
+		if operation.isDelete {
+			client.logInfo("Resource has been successfully deleted.", logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, operation.ResourceID))
+
+			return operation.complete(nil, nil)
+		}
+
+		return operation.complete(nil,
+			fmt.Errorf("No %s was found with Id '%s'", resourceDescription, operation.ResourceID),
+		)
+	}
+
+	switch resource.GetState() {
+	case ResourceStatusNormal:
+		client.logInfo(fmt.Sprintf("%s has successfully completed.", operation.ActionDescription), logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, operation.ResourceID))
+
+		return operation.complete(resource, nil)
+
+	case ResourceStatusPendingAdd, ResourceStatusPendingChange, ResourceStatusPendingDelete:
+		client.logInfo(fmt.Sprintf("%s is still in progress...", operation.ActionDescription), logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, operation.ResourceID), logField(LogFieldState, resource.GetState()))
+		client.getWaiter().reportProgress(operation.ResourceType, operation.ResourceID, operation.ActionDescription, iteration, operation.startTime, resource, resource.GetState())
+
+		return false, nil
+
+	default:
+		client.logError("Unexpected resource status.", logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, operation.ResourceID), logField(LogFieldState, resource.GetState()))
+
+		return operation.complete(resource,
+			newResourceFailedError(operation.ResourceType, operation.ResourceID, resource, operation.ActionDescription),
+		)
+	}
+}
+
+// complete marks the operation as completed.
+func (operation *Operation) complete(resource Resource, err error) (done bool, completionErr error) {
+	operation.stateLock.Lock()
+	defer operation.stateLock.Unlock()
+
+	// Another poll may have completed the operation first.
+	if !operation.done {
+		operation.done = true
+		operation.resource = resource
+		operation.err = err
+	}
+
+	return true, operation.err
+}
+
+// resourceDescription creates a textual description of the resource affected by the operation (e.g. "server 'xxx'").
+func (operation *Operation) resourceDescription() string {
+	resourceDescription, err := GetResourceDescription(operation.ResourceType)
+	if err != nil {
+		resourceDescription = fmt.Sprintf("resource type %d", operation.ResourceType)
+	}
+
+	return fmt.Sprintf("%s '%s'", resourceDescription, operation.ResourceID)
+}
@@ -0,0 +1,172 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// An asynchronous delete returns an Operation that completes once the resource has been deleted.
+func TestClient_DeleteVLANAsync_Wait(test *testing.T) {
+	expect := expect(test)
+
+	vlanID := "0e56433f-d808-4669-821d-812769517ff8"
+
+	var stateLock sync.Mutex
+	getCount := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		stateLock.Lock()
+		defer stateLock.Unlock()
+
+		writer.Header().Set("Content-Type", "application/json")
+
+		if request.Method != http.MethodGet {
+			writer.WriteHeader(http.StatusOK)
+			fmt.Fprint(writer, retryTestOKResponse)
+
+			return
+		}
+
+		getCount++
+		if getCount < 2 {
+			writer.WriteHeader(http.StatusOK)
+			fmt.Fprintf(writer, `{"id": "%s", "state": "%s"}`, vlanID, ResourceStatusPendingDelete)
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, operationTestNotFoundResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithWaiter(&Waiter{InitialPollInterval: 10 * time.Millisecond}),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	operation, err := client.DeleteVLANAsync(vlanID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.IsTrue("Operation.ResourceType", operation.ResourceType == ResourceTypeVLAN)
+	expect.EqualsString("Operation.ResourceID", vlanID, operation.ResourceID)
+	expect.EqualsString("Operation.ExpectedState", ResourceStatusPendingDelete, operation.ExpectedState)
+	expect.EqualsString("Operation.RequestID", "na9/2015-04-14T13:37:20/62f06368-c3fb-11e3-b29c-001517c4643e", operation.RequestID)
+	expect.IsFalse("Operation.Done", operation.Done())
+
+	done, err := operation.Poll()
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsFalse("Poll (PENDING_DELETE)", done)
+
+	resource, err := operation.Wait(context.Background())
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsTrue("Resource is nil", resource == nil)
+	expect.IsTrue("Operation.Done", operation.Done())
+	expect.IsTrue("Operation.Err is nil", operation.Err() == nil)
+}
+
+// An operation that CloudControl completes synchronously returns an Operation that has already completed.
+func TestClient_EditVLANAsync_Completed(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			name := "Production VLAN"
+			operation, err := client.EditVLANAsync("0e56433f-d808-4669-821d-812769517ff8", &name, nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.IsTrue("Operation.Done", operation.Done())
+			expect.EqualsString("Operation.ExpectedState", "", operation.ExpectedState)
+
+			_, err = operation.Wait(context.Background())
+			if err != nil {
+				test.Fatal(err)
+			}
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.Method", http.MethodPost, request.Method)
+
+			return http.StatusOK, operationTestOKResponse
+		},
+	})
+}
+
+// An operation fails if the resource enters an unexpected state, and Wait can be cancelled via its context.
+func TestOperation_Wait_FailedOrCancelled(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	var stateLock sync.Mutex
+	serverState := ResourceStatusPendingAdd
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		stateLock.Lock()
+		defer stateLock.Unlock()
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprintf(writer, `{"id": "%s", "name": "server1", "state": "%s"}`, serverID, serverState)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithWaiter(&Waiter{InitialPollInterval: 10 * time.Millisecond}),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	operation := client.newPendingOperation(ResourceTypeServer, serverID, "Deploy", ResourceStatusPendingAdd, false, &APIResponseV2{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := operation.Wait(ctx)
+	expect.IsTrue("IsOperationCancelledError", IsOperationCancelledError(err))
+	expect.IsFalse("Operation.Done (cancelled)", operation.Done())
+
+	stateLock.Lock()
+	serverState = ResourceStatusFailedAdd
+	stateLock.Unlock()
+
+	resource, err := operation.Wait(context.Background())
+	expect.IsTrue("IsResourceFailedError", IsResourceFailedError(err))
+	expect.IsTrue("Operation.Done", operation.Done())
+	expect.IsTrue("Operation.Err is the same error", operation.Err() == err)
+	expect.IsTrue("Resource is not nil", resource != nil)
+	expect.EqualsString("Resource.State", ResourceStatusFailedAdd, resource.GetState())
+}
+
+var operationTestOKResponse = `
+{
+	"operation": "EDIT_VLAN",
+	"responseCode": "OK",
+	"message": "VLAN '0e56433f-d808-4669-821d-812769517ff8' has been edited.",
+	"requestId": "na9/2015-04-14T13:37:20/62f06368-c3fb-11e3-b29c-001517c4643e"
+}
+`
+
+var operationTestNotFoundResponse = `
+{
+	"operation": "GET_VLAN",
+	"responseCode": "RESOURCE_NOT_FOUND",
+	"message": "VLAN '0e56433f-d808-4669-821d-812769517ff8' not found.",
+	"requestId": "na9/2015-04-14T13:37:21/62f06368-c3fb-11e3-b29c-001517c4643e"
+}
+`
@@ -0,0 +1,203 @@
+package compute
+
+import (
+	"net/http"
+	"time"
+)
+
+// UserAgent is the value of the User-Agent header sent with requests to the compute API (see WithUserAgentSuffix).
+const UserAgent = "go-dd-cloud-compute"
+
+// Option customises a Client created using NewClientWithOptions.
+type Option func(options *clientOptions)
+
+// clientOptions represents the configuration used to create a Client.
+type clientOptions struct {
+	baseAddress         string
+	httpClient          *http.Client
+	transport           http.RoundTripper
+	requestTimeout      time.Duration
+	userAgentSuffix     string
+	defaultPageSize     int
+	retryPolicy         RetryPolicy
+	logger              Logger
+	credentialsProvider CredentialsProvider
+	rateLimiters        *rateLimitersHolder
+	instrumentation     Instrumentation
+	lockManager         *LockManager
+	waiter              *Waiter
+	responseCache       *ResponseCache
+	apiVersion          int
+	apiVersionOverrides APIVersions
+	dryRunPlan          *DryRunPlan
+	shutdownGracePeriod time.Duration
+}
+
+// WithBaseAddress configures the client to use a custom end-point base address (instead of the one for its region).
+// baseAddress is the base URL of the CloudControl API end-point.
+func WithBaseAddress(baseAddress string) Option {
+	return func(options *clientOptions) {
+		options.baseAddress = baseAddress
+	}
+}
+
+// WithHTTPClient configures the client to use the specified http.Client (e.g. to configure a proxy or custom CAs).
+//
+// The http.Client is copied (so WithTransport and WithRequestTimeout do not modify the original).
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(options *clientOptions) {
+		options.httpClient = httpClient
+	}
+}
+
+// WithTransport configures the client to use the specified http.RoundTripper (e.g. to wrap the default transport for metrics).
+func WithTransport(transport http.RoundTripper) Option {
+	return func(options *clientOptions) {
+		options.transport = transport
+	}
+}
+
+// WithRequestTimeout configures the maximum time allowed for each request to the compute API (including reading the response body).
+func WithRequestTimeout(requestTimeout time.Duration) Option {
+	return func(options *clientOptions) {
+		options.requestTimeout = requestTimeout
+	}
+}
+
+// WithUserAgentSuffix configures a suffix (e.g. "my-tool/1.0") to append to the User-Agent header sent with requests to the compute API.
+func WithUserAgentSuffix(userAgentSuffix string) Option {
+	return func(options *clientOptions) {
+		options.userAgentSuffix = userAgentSuffix
+	}
+}
+
+// WithDefaultPageSize configures the page size used by ListXXX operations when no paging configuration is supplied (and by NewPager when no page size is supplied).
+func WithDefaultPageSize(pageSize int) Option {
+	return func(options *clientOptions) {
+		options.defaultPageSize = pageSize
+	}
+}
+
+// WithRetryPolicy configures the client's retry policy (see Client.SetRetryPolicy).
+func WithRetryPolicy(retryPolicy RetryPolicy) Option {
+	return func(options *clientOptions) {
+		options.retryPolicy = retryPolicy
+	}
+}
+
+// WithLogger configures the client's logger (see Client.SetLogger).
+func WithLogger(logger Logger) Option {
+	return func(options *clientOptions) {
+		options.logger = logger
+	}
+}
+
+// WithCredentialsProvider configures the client to retrieve its credentials from the specified CredentialsProvider (instead of using the user name and password supplied to NewClientWithOptions).
+func WithCredentialsProvider(credentialsProvider CredentialsProvider) Option {
+	return func(options *clientOptions) {
+		options.credentialsProvider = credentialsProvider
+	}
+}
+
+// WithRateLimit configures the client to limit the rate (and concurrency) of all requests to the compute API (see Client.SetRateLimit).
+func WithRateLimit(limit RateLimit) Option {
+	return func(options *clientOptions) {
+		limiter := newRateLimiterOrNil(limit)
+		options.rateLimiters = &rateLimitersHolder{
+			read:  limiter,
+			write: limiter,
+		}
+	}
+}
+
+// WithRateLimits configures the client to limit the rate (and concurrency) of requests to the compute API, using separate budgets for read (GET) requests and mutating (POST, etc) requests (see Client.SetRateLimits).
+func WithRateLimits(readLimit RateLimit, writeLimit RateLimit) Option {
+	return func(options *clientOptions) {
+		options.rateLimiters = &rateLimitersHolder{
+			read:  newRateLimiterOrNil(readLimit),
+			write: newRateLimiterOrNil(writeLimit),
+		}
+	}
+}
+
+// WithInstrumentation configures the client to notify the specified Instrumentation about the requests it performs (see Client.SetInstrumentation).
+func WithInstrumentation(instrumentation Instrumentation) Option {
+	return func(options *clientOptions) {
+		options.instrumentation = instrumentation
+	}
+}
+
+// WithLockManager configures the client to use the specified LockManager to serialise mutating operations that affect the same resource (see Client.SetLockManager).
+func WithLockManager(lockManager *LockManager) Option {
+	return func(options *clientOptions) {
+		options.lockManager = lockManager
+	}
+}
+
+// WithWaiter configures how the client polls a resource's status while waiting for a pending operation to complete (see Client.SetWaiter).
+func WithWaiter(waiter *Waiter) Option {
+	return func(options *clientOptions) {
+		options.waiter = waiter
+	}
+}
+
+// WithResponseCache configures the client to use the specified ResponseCache for responses from read-mostly API end-points (see Client.SetResponseCache).
+func WithResponseCache(cache *ResponseCache) Option {
+	return func(options *clientOptions) {
+		options.responseCache = cache
+	}
+}
+
+// WithAPIVersion configures the client to use the specified CloudControl 2.x minor version (e.g. 4 for v2.4) for all operations that do not have a per-operation override (see Client.SetAPIVersion).
+func WithAPIVersion(minorVersion int) Option {
+	return func(options *clientOptions) {
+		options.apiVersion = minorVersion
+	}
+}
+
+// WithAPIVersionOverrides configures the CloudControl 2.x minor versions that the client uses for specific operations (see Client.SetAPIVersionOverrides).
+func WithAPIVersionOverrides(overrides APIVersions) Option {
+	return func(options *clientOptions) {
+		options.apiVersionOverrides = overrides
+	}
+}
+
+// WithDryRun configures the client to run in dry-run mode, recording mutating requests in the specified plan instead of performing them (see Client.SetDryRun).
+func WithDryRun(plan *DryRunPlan) Option {
+	return func(options *clientOptions) {
+		options.dryRunPlan = plan
+	}
+}
+
+// WithShutdownGracePeriod configures how long the client waits for a server to shut down gracefully before powering it off (see Client.SetShutdownGracePeriod).
+func WithShutdownGracePeriod(gracePeriod time.Duration) Option {
+	return func(options *clientOptions) {
+		options.shutdownGracePeriod = gracePeriod
+	}
+}
+
+// newHTTPClient creates the http.Client used to perform requests.
+func (options *clientOptions) newHTTPClient() *http.Client {
+	httpClient := &http.Client{}
+	if options.httpClient != nil {
+		*httpClient = *options.httpClient
+	}
+
+	if options.transport != nil {
+		httpClient.Transport = options.transport
+	}
+	if options.requestTimeout > 0 {
+		httpClient.Timeout = options.requestTimeout
+	}
+
+	return httpClient
+}
+
+// userAgent determines the value of the User-Agent header sent with requests.
+func (options *clientOptions) userAgent() string {
+	if options.userAgentSuffix == "" {
+		return UserAgent
+	}
+
+	return UserAgent + " " + options.userAgentSuffix
+}
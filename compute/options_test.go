@@ -0,0 +1,86 @@
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Client created with options uses the custom transport, user agent and default page size.
+func TestNewClientWithOptions_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		expect.EqualsString("Request.User-Agent", "go-dd-cloud-compute my-tool/1.0", request.Header.Get("User-Agent"))
+		expect.EqualsString("Request.pageSize", "20", request.URL.Query().Get("pageSize"))
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(writer, `{"vlan": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 20}`)
+	}))
+	defer testServer.Close()
+
+	transport := &countingRoundTripper{
+		inner: http.DefaultTransport,
+	}
+	client := NewClientWithOptions("AU", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithTransport(transport),
+		WithUserAgentSuffix("my-tool/1.0"),
+		WithDefaultPageSize(20),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	_, err := client.ListVLANs("484174a2-ae74-4658-9e56-50fc90e086cf", nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.EqualsInt("RoundTripper.RequestCount", 1, int(atomic.LoadInt32(&transport.requestCount)))
+}
+
+// Options configure the client's HTTP client (without modifying the original).
+func TestNewClientWithOptions_HTTPClient(test *testing.T) {
+	expect := expect(test)
+
+	httpClient := &http.Client{}
+	client := NewClientWithOptions("AU", "user1", "password",
+		WithHTTPClient(httpClient),
+		WithRequestTimeout(30*time.Second),
+		WithRetryPolicy(NewExponentialBackoffRetryPolicy(3, 1*time.Second)),
+	)
+
+	expect.EqualsString("Client.baseAddress", "https://api-AU.dimensiondata.com", client.baseAddress)
+	expect.IsTrue("Client.httpClient.Timeout", client.httpClient.Timeout == 30*time.Second)
+	expect.IsTrue("Original.Timeout", httpClient.Timeout == 0)
+	expect.NotNil("Client.RetryPolicy", client.getRetryPolicy())
+}
+
+// Existing constructors behave as before.
+func TestNewClient_Defaults(test *testing.T) {
+	expect := expect(test)
+
+	client := NewClient("AU", "user1", "password")
+	expect.EqualsString("Client.baseAddress", "https://api-AU.dimensiondata.com", client.baseAddress)
+	expect.EqualsString("Client.userAgent", UserAgent, client.userAgent)
+	expect.IsTrue("Client.RetryPolicy is nil", client.getRetryPolicy() == nil)
+	expect.EqualsInt("DefaultPaging.PageSize", 50, client.ensurePaging(nil).PageSize)
+}
+
+// An http.RoundTripper that counts requests (for testing).
+type countingRoundTripper struct {
+	inner        http.RoundTripper
+	requestCount int32
+}
+
+func (roundTripper *countingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&roundTripper.requestCount, 1)
+
+	return roundTripper.inner.RoundTrip(request)
+}
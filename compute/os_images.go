@@ -124,6 +124,32 @@ type OSImages struct {
 	PageSize int `json:"pageSize"`
 }
 
+// IsEmpty determines whether the page contains no results.
+func (page *OSImages) IsEmpty() bool {
+	return page.pagedResult().IsEmpty()
+}
+
+// IsLastPage determines whether the page represents the last page of results.
+func (page *OSImages) IsLastPage() bool {
+	return page.pagedResult().IsLastPage()
+}
+
+// pagedResult creates a PagedResult from the page's paging fields.
+func (page *OSImages) pagedResult() *PagedResult {
+	if page == nil {
+		return nil
+	}
+
+	return &PagedResult{
+		PageNumber: page.PageNumber,
+		PageCount:  page.PageCount,
+		TotalCount: page.TotalCount,
+		PageSize:   page.PageSize,
+	}
+}
+
+var _ PagedResults = &OSImages{}
+
 // GetOSImage retrieves a specific OS image by Id.
 func (client *Client) GetOSImage(id string) (image *OSImage, err error) {
 	organizationID, err := client.getOrganizationID()
@@ -135,7 +161,7 @@ func (client *Client) GetOSImage(id string) (image *OSImage, err error) {
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2ForOperation("image/osImage#get", requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +206,7 @@ func (client *Client) FindOSImage(name string, dataCenterID string) (image *OSIm
 		url.QueryEscape(name),
 		url.QueryEscape(dataCenterID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -220,17 +246,30 @@ func (client *Client) FindOSImage(name string, dataCenterID string) (image *OSIm
 
 // ListOSImagesInDatacenter lists all OS images in a given data centre.
 func (client *Client) ListOSImagesInDatacenter(dataCenterID string, paging *Paging) (images *OSImages, err error) {
+	return client.ListOSImagesInDatacenterWithFilter(dataCenterID, nil, paging)
+}
+
+// ListOSImagesInDatacenterWithFilter lists the OS images in a given data centre that match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results.
+func (client *Client) ListOSImagesInDatacenterWithFilter(dataCenterID string, filter *Filter, paging *Paging) (images *OSImages, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/image/osImage?datacenterId=%s&%s",
+	filterParameters, err := filter.toQueryParameters(FilterFieldDatacenterID)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/image/osImage?datacenterId=%s&%s%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(dataCenterID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
+		filterParameters,
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -256,3 +295,19 @@ func (client *Client) ListOSImagesInDatacenter(dataCenterID string, paging *Pagi
 
 	return
 }
+
+// AllOSImagesInDatacenter retrieves all OS images in the specified datacenter (across all pages of results).
+func (client *Client) AllOSImagesInDatacenter(dataCenterID string) (images []OSImage, err error) {
+	return client.AllOSImagesInDatacenterWithFilter(dataCenterID, nil)
+}
+
+// AllOSImagesInDatacenterWithFilter retrieves all OS images in the specified datacenter that match the specified filter criteria (across all pages of results).
+func (client *Client) AllOSImagesInDatacenterWithFilter(dataCenterID string, filter *Filter) (images []OSImage, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListOSImagesInDatacenterWithFilter(dataCenterID, filter, paging)
+	}, func(page PagedResults) {
+		images = append(images, page.(*OSImages).Images...)
+	})
+
+	return
+}
@@ -0,0 +1,236 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PagedResults represents a page of results from a paged compute API operation.
+//
+// All of the client's paged result types (e.g. *Servers, *VLANs) implement this interface (via PagedResult).
+type PagedResults interface {
+	// IsEmpty determines whether the page contains no results.
+	IsEmpty() bool
+
+	// IsLastPage determines whether the page represents the last page of results.
+	IsLastPage() bool
+}
+
+var _ PagedResults = &PagedResult{}
+
+// PageLoader is a function that retrieves the specified page of results from a paged compute API operation.
+type PageLoader func(paging *Paging) (page PagedResults, err error)
+
+// PagerResult represents a page of results (or an error) produced by a Pager.
+type PagerResult struct {
+	// The page of results (nil if an error was encountered).
+	Page PagedResults
+
+	// The error (if any) encountered while retrieving the page.
+	Err error
+}
+
+// Pager lazily retrieves successive pages of results from a paged compute API operation.
+//
+// A Pager is not safe for concurrent use by multiple goroutines (except for Close, which can be called at any time).
+type Pager struct {
+	loader     PageLoader
+	context    context.Context
+	paging     *Paging
+	isDone     bool
+	isPrefetch bool
+	prefetched chan PagerResult
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewPager creates a new Pager that uses the specified function to retrieve pages of results.
+//
+// pageSize is the number of results to retrieve per page (pass 0 to use the client's default page size).
+//
+// The pager stops retrieving results if the client's context (see WithContext) is cancelled.
+func (client *Client) NewPager(loader PageLoader, pageSize int) *Pager {
+	paging := client.ensurePaging(nil)
+	if pageSize > 0 {
+		paging.PageSize = pageSize
+	}
+	paging.First()
+
+	return &Pager{
+		loader:  loader,
+		context: client.Context(),
+		paging:  paging,
+		closed:  make(chan struct{}),
+	}
+}
+
+// EnablePrefetch configures the pager to start retrieving the next page of results (in the background) as soon as the current page has been retrieved.
+func (pager *Pager) EnablePrefetch() *Pager {
+	pager.isPrefetch = true
+
+	return pager
+}
+
+// IsDone determines whether the pager has retrieved the last page of results (or encountered an error, or been closed).
+func (pager *Pager) IsDone() bool {
+	return pager.isClosed() || (pager.isDone && pager.prefetched == nil)
+}
+
+// Next retrieves the next page of results.
+//
+// Returns nil (with no error) once there are no more pages of results.
+func (pager *Pager) Next() (page PagedResults, err error) {
+	if pager.isClosed() {
+		return nil, nil
+	}
+
+	var result PagerResult
+	if pager.prefetched != nil {
+		result = <-pager.prefetched
+		pager.prefetched = nil
+	} else {
+		if pager.isDone {
+			return nil, nil
+		}
+
+		result = pager.loadPage(pager.currentPaging())
+	}
+
+	if result.Err != nil {
+		pager.isDone = true
+
+		return nil, result.Err
+	}
+
+	if result.Page.IsLastPage() {
+		pager.isDone = true
+	} else {
+		pager.paging.Next()
+
+		if pager.isPrefetch {
+			paging := pager.currentPaging()
+
+			pager.prefetched = make(chan PagerResult, 1)
+			go func(prefetched chan<- PagerResult) {
+				prefetched <- pager.loadPage(paging)
+			}(pager.prefetched)
+		}
+	}
+
+	return result.Page, nil
+}
+
+// ForEachPage invokes the specified function for each remaining page of results.
+//
+// Iteration stops at the first error (either from the pager or returned by the function).
+func (pager *Pager) ForEachPage(action func(page PagedResults) error) error {
+	for {
+		page, err := pager.Next()
+		if err != nil {
+			return err
+		}
+		if page == nil {
+			return nil
+		}
+
+		err = action(page)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Pages streams the remaining pages of results.
+//
+// The channel is closed after the last page of results (or the first error) has been delivered.
+// If the consumer stops reading before the channel is closed, it must call Close (or cancel the pager's context) to release the background goroutine.
+func (pager *Pager) Pages() <-chan PagerResult {
+	results := make(chan PagerResult)
+
+	go func() {
+		defer close(results)
+
+		for {
+			page, err := pager.Next()
+			if page == nil && err == nil {
+				return
+			}
+
+			select {
+			case results <- PagerResult{Page: page, Err: err}:
+				if err != nil {
+					return
+				}
+
+			case <-pager.context.Done():
+				return
+
+			case <-pager.closed:
+				return
+			}
+		}
+	}()
+
+	return results
+}
+
+// Close stops the pager from retrieving any further pages of results.
+//
+// If Pages has been called, its background goroutine is released (and its channel closed).
+func (pager *Pager) Close() {
+	pager.closeOnce.Do(func() {
+		close(pager.closed)
+	})
+}
+
+// isClosed determines whether Close has been called.
+func (pager *Pager) isClosed() bool {
+	select {
+	case <-pager.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// currentPaging creates a copy of the pager's current paging configuration (page loaders are free to modify it).
+func (pager *Pager) currentPaging() *Paging {
+	paging := *pager.paging
+
+	return &paging
+}
+
+// loadPage retrieves the specified page of results.
+func (pager *Pager) loadPage(paging *Paging) PagerResult {
+	contextErr := pager.context.Err()
+	if contextErr != nil {
+		return PagerResult{
+			Err: &OperationCancelledError{
+				OperationDescription: fmt.Sprintf("Retrieval of page %d", paging.PageNumber),
+				Reason:               contextErr,
+			},
+		}
+	}
+
+	page, err := pager.loader(paging)
+	if err != nil {
+		return PagerResult{Err: err}
+	}
+	if page == nil {
+		return PagerResult{
+			Err: fmt.Errorf("page loader returned no results for page %d", paging.PageNumber),
+		}
+	}
+
+	return PagerResult{Page: page}
+}
+
+// collectPages retrieves all pages of results using the specified loader, passing each one to the specified function.
+func (client *Client) collectPages(loader PageLoader, collect func(page PagedResults)) error {
+	return client.NewPager(loader, 0).ForEachPage(func(page PagedResults) error {
+		collect(page)
+
+		return nil
+	})
+}
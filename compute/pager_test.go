@@ -0,0 +1,236 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// Retrieve all VLANs (3 pages).
+func TestClient_AllVLANs_MultiplePages_Success(test *testing.T) {
+	expect := expect(test)
+
+	requestedPages := &pagerTestRequestLog{}
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			vlans, err := client.AllVLANs("484174a2-ae74-4658-9e56-50fc90e086cf")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("VLANs.Length", 120, len(vlans))
+			for index, vlan := range vlans {
+				expect.EqualsString(fmt.Sprintf("VLANs[%d].ID", index), fmt.Sprintf("vlan-%d", index+1), vlan.ID)
+			}
+
+			expect.EqualsString("RequestedPages", "1,2,3", requestedPages.String())
+		},
+		Respond: testRespondWithVLANPages(requestedPages, 50, 120),
+	})
+}
+
+// Retrieve pages of VLANs with prefetch enabled.
+func TestClient_Pager_Prefetch_Success(test *testing.T) {
+	expect := expect(test)
+
+	requestedPages := &pagerTestRequestLog{}
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			pager := client.NewPager(func(paging *Paging) (PagedResults, error) {
+				return client.ListVLANs("484174a2-ae74-4658-9e56-50fc90e086cf", paging)
+			}, 5).EnablePrefetch()
+
+			page, err := pager.Next()
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Page1.PageNumber", 1, page.(*VLANs).PageNumber)
+
+			page, err = pager.Next()
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.EqualsInt("Page2.PageNumber", 2, page.(*VLANs).PageNumber)
+			expect.IsTrue("Pager.IsDone", pager.IsDone())
+
+			page, err = pager.Next()
+			if err != nil {
+				test.Fatal(err)
+			}
+			expect.IsTrue("Page3 is nil", page == nil)
+
+			expect.EqualsString("RequestedPages", "1,2", requestedPages.String())
+		},
+		Respond: testRespondWithVLANPages(requestedPages, 5, 7),
+	})
+}
+
+// Stream pages of VLANs via a channel.
+func TestClient_Pager_Pages_Success(test *testing.T) {
+	expect := expect(test)
+
+	requestedPages := &pagerTestRequestLog{}
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			pager := client.NewPager(func(paging *Paging) (PagedResults, error) {
+				return client.ListVLANs("484174a2-ae74-4658-9e56-50fc90e086cf", paging)
+			}, 5)
+
+			vlanCount := 0
+			for result := range pager.Pages() {
+				if result.Err != nil {
+					test.Fatal(result.Err)
+				}
+
+				vlanCount += len(result.Page.(*VLANs).VLANs)
+			}
+
+			expect.EqualsInt("VLANCount", 12, vlanCount)
+			expect.EqualsString("RequestedPages", "1,2,3", requestedPages.String())
+		},
+		Respond: testRespondWithVLANPages(requestedPages, 5, 12),
+	})
+}
+
+// Consumer stops reading pages early and closes the pager (without a context).
+func TestClient_Pager_Pages_Close(test *testing.T) {
+	expect := expect(test)
+
+	requestedPages := &pagerTestRequestLog{}
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			pager := client.NewPager(func(paging *Paging) (PagedResults, error) {
+				return client.ListVLANs("484174a2-ae74-4658-9e56-50fc90e086cf", paging)
+			}, 5)
+
+			pages := pager.Pages()
+			result := <-pages
+			if result.Err != nil {
+				test.Fatal(result.Err)
+			}
+			expect.EqualsInt("Page1.PageNumber", 1, result.Page.(*VLANs).PageNumber)
+
+			pager.Close()
+
+			// Channel must be closed once the background goroutine has been released.
+			for range pages {
+			}
+
+			expect.IsTrue("Pager.IsDone", pager.IsDone())
+		},
+		Respond: testRespondWithVLANPages(requestedPages, 5, 50),
+	})
+}
+
+// Pager stops when its context is cancelled.
+func TestClient_Pager_Cancelled(test *testing.T) {
+	expect := expect(test)
+
+	requestedPages := &pagerTestRequestLog{}
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client = client.WithContext(ctx)
+			pager := client.NewPager(func(paging *Paging) (PagedResults, error) {
+				return client.ListVLANs("484174a2-ae74-4658-9e56-50fc90e086cf", paging)
+			}, 5)
+
+			_, err := pager.Next()
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			cancel()
+
+			page, err := pager.Next()
+			expect.IsTrue("IsOperationCancelledError", IsOperationCancelledError(err))
+			expect.IsTrue("Page is nil", page == nil)
+			expect.IsTrue("Pager.IsDone", pager.IsDone())
+
+			expect.EqualsString("RequestedPages", "1", requestedPages.String())
+		},
+		Respond: testRespondWithVLANPages(requestedPages, 5, 12),
+	})
+}
+
+// Records the page numbers requested during a pager test.
+type pagerTestRequestLog struct {
+	stateLock   sync.Mutex
+	pageNumbers []string
+}
+
+func (requestLog *pagerTestRequestLog) Add(pageNumber string) {
+	requestLog.stateLock.Lock()
+	defer requestLog.stateLock.Unlock()
+
+	requestLog.pageNumbers = append(requestLog.pageNumbers, pageNumber)
+}
+
+func (requestLog *pagerTestRequestLog) String() string {
+	requestLog.stateLock.Lock()
+	defer requestLog.stateLock.Unlock()
+
+	result := ""
+	for index, pageNumber := range requestLog.pageNumbers {
+		if index > 0 {
+			result += ","
+		}
+		result += pageNumber
+	}
+
+	return result
+}
+
+// Respond with the requested page of VLANs (named "vlan-1" to "vlan-N").
+func testRespondWithVLANPages(requestLog *pagerTestRequestLog, expectedPageSize int, totalCount int) ClientTestResponder {
+	return func(test *testing.T, request *http.Request) (int, string) {
+		query := request.URL.Query()
+		requestLog.Add(query.Get("pageNumber"))
+
+		pageNumber, err := strconv.Atoi(query.Get("pageNumber"))
+		if err != nil {
+			test.Fatal(err)
+		}
+		pageSize, err := strconv.Atoi(query.Get("pageSize"))
+		if err != nil {
+			test.Fatal(err)
+		}
+		if pageSize != expectedPageSize {
+			test.Fatalf("Requested page size was %d (expected %d).", pageSize, expectedPageSize)
+		}
+
+		firstIndex := (pageNumber-1)*pageSize + 1
+		lastIndex := firstIndex + pageSize - 1
+		if lastIndex > totalCount {
+			lastIndex = totalCount
+		}
+
+		vlans := ""
+		pageCount := 0
+		for index := firstIndex; index <= lastIndex; index++ {
+			if pageCount > 0 {
+				vlans += ","
+			}
+			vlans += fmt.Sprintf(`{ "id": "vlan-%d", "name": "VLAN %d", "state": "NORMAL" }`, index, index)
+			pageCount++
+		}
+
+		return http.StatusOK, fmt.Sprintf(`{
+			"vlan": [ %s ],
+			"pageNumber": %d,
+			"pageCount": %d,
+			"totalCount": %d,
+			"pageSize": %d
+		}`, vlans, pageNumber, pageCount, totalCount, pageSize)
+	}
+}
@@ -66,6 +66,20 @@ func (paging *Paging) EnsurePaging() *Paging {
 	return DefaultPaging()
 }
 
+// ensurePaging always returns a paging configuration (if the supplied Paging is nil, it returns the default configuration, using the client's default page size if one was configured).
+func (client *Client) ensurePaging(paging *Paging) *Paging {
+	if paging != nil {
+		return paging
+	}
+
+	paging = DefaultPaging()
+	if client.defaultPageSize > 0 {
+		paging.PageSize = client.defaultPageSize
+	}
+
+	return paging
+}
+
 func (paging *Paging) ensureValidPageSize() {
 	if paging.PageSize < 5 {
 		paging.PageSize = 5
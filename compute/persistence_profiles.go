@@ -53,9 +53,9 @@ func (client *Client) ListDefaultPersistenceProfiles(networkDomainID string, pag
 	requestURI := fmt.Sprintf("%s/networkDomainVip/defaultPersistenceProfile?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +81,7 @@ func (client *Client) GetPortList(id string) (portList *PortList, err error) {
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +122,7 @@ func (client *Client) ListPortLists(networkDomainID string) (portLists *PortList
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -162,13 +162,16 @@ func (client *Client) CreatePortList(name string, description string, networkDom
 	requestURI := fmt.Sprintf("%s/network/createPortList",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &createPortList{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &createPortList{
 		Name:            name,
 		Description:     description,
 		Ports:           ports,
 		ChildListIDs:    childListIDs,
 		NetworkDomainID: networkDomainID,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -207,7 +210,10 @@ func (client *Client) EditPortList(id string, edit EditPortList) error {
 	requestURI := fmt.Sprintf("%s/network/editPortList",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, edit)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, edit)
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -238,7 +244,10 @@ func (client *Client) DeletePortList(id string) (err error) {
 	requestURI := fmt.Sprintf("%s/network/deletePortList",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &deletePortList{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deletePortList{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -0,0 +1,194 @@
+package compute
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit represents the configuration for a RateLimiter.
+type RateLimit struct {
+	// The maximum sustained number of requests per second (0 for no limit).
+	RequestsPerSecond float64
+
+	// The maximum number of requests that can be started in a burst, above the sustained rate (defaults to 1 if RequestsPerSecond is specified).
+	Burst int
+
+	// The maximum number of requests that can be in flight at the same time (0 for no limit).
+	MaxConcurrentRequests int
+}
+
+// RateLimiter limits the rate at which requests are started (using a token bucket), and the number of requests that are in flight at the same time (using a semaphore).
+type RateLimiter struct {
+	limit     RateLimit
+	stateLock sync.Mutex
+	tokens    float64
+	lastTime  time.Time
+	inFlight  chan struct{}
+}
+
+// NewRateLimiter creates a new RateLimiter.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	if limit.RequestsPerSecond > 0 && limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	limiter := &RateLimiter{
+		limit:    limit,
+		tokens:   float64(limit.Burst),
+		lastTime: time.Now(),
+	}
+	if limit.MaxConcurrentRequests > 0 {
+		limiter.inFlight = make(chan struct{}, limit.MaxConcurrentRequests)
+	}
+
+	return limiter
+}
+
+// Limit retrieves the rate limiter's configuration.
+func (limiter *RateLimiter) Limit() RateLimit {
+	return limiter.limit
+}
+
+// Wait waits until a request can be started (or the context is cancelled).
+//
+// If successful, the caller must call the returned function once the request has completed (to release its in-flight slot).
+func (limiter *RateLimiter) Wait(ctx context.Context) (release func(), err error) {
+	if limiter.inFlight != nil {
+		select {
+		case limiter.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = limiter.releaseInFlight
+
+	delay := limiter.reserveToken()
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			limiter.returnToken()
+			release()
+
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// reserveToken takes a token from the bucket, returning the delay before that token becomes available.
+func (limiter *RateLimiter) reserveToken() time.Duration {
+	if limiter.limit.RequestsPerSecond <= 0 {
+		return 0
+	}
+
+	limiter.stateLock.Lock()
+	defer limiter.stateLock.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(limiter.lastTime).Seconds()
+	limiter.lastTime = now
+	limiter.tokens = math.Min(limiter.tokens+elapsed*limiter.limit.RequestsPerSecond, float64(limiter.limit.Burst))
+
+	// Tokens can go negative; this represents reservations by callers that are still waiting.
+	limiter.tokens--
+	if limiter.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-limiter.tokens / limiter.limit.RequestsPerSecond * float64(time.Second))
+}
+
+// returnToken returns a reserved token to the bucket (e.g. because the caller stopped waiting for it).
+func (limiter *RateLimiter) returnToken() {
+	if limiter.limit.RequestsPerSecond <= 0 {
+		return
+	}
+
+	limiter.stateLock.Lock()
+	defer limiter.stateLock.Unlock()
+
+	limiter.tokens++
+}
+
+// releaseInFlight releases an in-flight slot.
+func (limiter *RateLimiter) releaseInFlight() {
+	if limiter.inFlight != nil {
+		<-limiter.inFlight
+	}
+}
+
+// SetRateLimit configures the client to limit the rate (and concurrency) of all requests to the compute API.
+//
+// Set limit to the zero value (the default) to disable rate limiting.
+func (client *Client) SetRateLimit(limit RateLimit) {
+	limiter := newRateLimiterOrNil(limit)
+
+	client.rateLimiters.Store(rateLimitersHolder{
+		read:  limiter,
+		write: limiter,
+	})
+}
+
+// SetRateLimits configures the client to limit the rate (and concurrency) of requests to the compute API, using separate budgets for read (GET) requests and mutating (POST, etc) requests.
+//
+// Set a limit to the zero value (the default) to disable rate limiting for that type of request.
+func (client *Client) SetRateLimits(readLimit RateLimit, writeLimit RateLimit) {
+	client.rateLimiters.Store(rateLimitersHolder{
+		read:  newRateLimiterOrNil(readLimit),
+		write: newRateLimiterOrNil(writeLimit),
+	})
+}
+
+// waitForRateLimit waits until the client's rate limiter (if any) allows the specified request to be started.
+//
+// If successful, the caller must call the returned function once the request has completed.
+// Returns an OperationCancelledError if cancellation is requested while waiting.
+func (client *Client) waitForRateLimit(method string, operationDescription string) (release func(), err error) {
+	holder, _ := client.rateLimiters.Load().(rateLimitersHolder)
+
+	limiter := holder.write
+	if method == http.MethodGet || method == http.MethodHead {
+		limiter = holder.read
+	}
+	if limiter == nil {
+		return func() {}, nil
+	}
+
+	release, err = limiter.Wait(client.Context())
+	if err != nil {
+		return nil, client.checkCancellation(operationDescription)
+	}
+
+	// Cancel may have been called while we were waiting.
+	err = client.checkCancellation(operationDescription)
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// rateLimitersHolder enables storage of the client's (possibly nil) rate limiters in an atomic.Value.
+type rateLimitersHolder struct {
+	read  *RateLimiter
+	write *RateLimiter
+}
+
+// newRateLimiterOrNil creates a new RateLimiter, or returns nil if the specified limit does not limit anything.
+func newRateLimiterOrNil(limit RateLimit) *RateLimiter {
+	if limit.RequestsPerSecond <= 0 && limit.MaxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	return NewRateLimiter(limit)
+}
@@ -0,0 +1,151 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// No more than MaxConcurrentRequests requests are in flight at the same time.
+func TestClient_RateLimit_MaxConcurrentRequests(test *testing.T) {
+	expect := expect(test)
+
+	var inFlight, maxInFlight int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			previousMax := atomic.LoadInt32(&maxInFlight)
+			if current <= previousMax || atomic.CompareAndSwapInt32(&maxInFlight, previousMax, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprint(writer, retryTestOKResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithRateLimit(RateLimit{MaxConcurrentRequests: 2}),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	var waitGroup sync.WaitGroup
+	for index := 0; index < 6; index++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+			if err != nil {
+				test.Error(err)
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	expect.EqualsInt("MaxInFlight", 2, int(atomic.LoadInt32(&maxInFlight)))
+}
+
+// Requests are started no faster than RequestsPerSecond (after the initial burst).
+func TestClient_RateLimit_RequestsPerSecond(test *testing.T) {
+	expect := expect(test)
+
+	var requestCount int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprint(writer, retryTestOKResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+	)
+	client.SetRateLimit(RateLimit{RequestsPerSecond: 20, Burst: 2})
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	startTime := time.Now()
+	for index := 0; index < 6; index++ {
+		err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+	elapsed := time.Since(startTime)
+
+	// 2 requests in the initial burst, then 4 requests at 50ms intervals.
+	expect.IsTrue(fmt.Sprintf("Elapsed (%s) >= 180ms", elapsed), elapsed >= 180*time.Millisecond)
+	expect.EqualsInt("RequestCount", 6, int(atomic.LoadInt32(&requestCount)))
+}
+
+// Read and write requests use separate budgets, and time spent waiting for the rate limiter counts against the client's context.
+func TestClient_RateLimits_SeparateBudgets_Cancelled(test *testing.T) {
+	expect := expect(test)
+
+	var requestCount int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		if request.Method == http.MethodGet {
+			fmt.Fprint(writer, getServerTestResponse)
+		} else {
+			fmt.Fprint(writer, retryTestOKResponse)
+		}
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithRateLimits(
+			RateLimit{RequestsPerSecond: 0.1},
+			RateLimit{},
+		),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	_, err := client.GetServer("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	// Write requests are not limited.
+	for index := 0; index < 3; index++ {
+		err = client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+		if err != nil {
+			test.Fatal(err)
+		}
+	}
+
+	// The next read request would have to wait 10 seconds.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	startTime := time.Now()
+	_, err = client.WithContext(ctx).GetServer("5a32d6e4-9707-4813-a269-56ab4d989f4d")
+	expect.IsTrue("IsOperationCancelledError", IsOperationCancelledError(err))
+	expect.IsTrue("errors.Is(err, context.DeadlineExceeded)", errors.Is(err, context.DeadlineExceeded))
+	expect.IsTrue("Elapsed < 5s", time.Since(startTime) < 5*time.Second)
+	expect.EqualsInt("RequestCount", 4, int(atomic.LoadInt32(&requestCount)))
+}
@@ -0,0 +1,344 @@
+package requests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// CassetteMode represents the mode in which a Recorder operates.
+type CassetteMode int
+
+const (
+	// CassetteModeReplay indicates that a Recorder serves responses from its cassette (without sending requests over the network).
+	CassetteModeReplay CassetteMode = iota
+
+	// CassetteModeRecord indicates that a Recorder sends requests over the network, and records them (and their responses) in its cassette.
+	CassetteModeRecord
+)
+
+// Cassette is a recorded sequence of HTTP interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded HTTP request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded HTTP request.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// SanitizeFunc is a function that removes sensitive data from an interaction before it is recorded.
+type SanitizeFunc func(interaction *Interaction)
+
+// LoadCassette loads a cassette from the specified file.
+func LoadCassette(fileName string) (*Cassette, error) {
+	cassetteJSON, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read cassette file '%s'", fileName)
+	}
+
+	cassette := &Cassette{}
+	err = json.Unmarshal(cassetteJSON, cassette)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cassette file '%s'", fileName)
+	}
+
+	return cassette, nil
+}
+
+// Save saves the cassette to the specified file.
+func (cassette *Cassette) Save(fileName string) error {
+	cassetteJSON, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "unable to serialise cassette")
+	}
+
+	err = ioutil.WriteFile(fileName, cassetteJSON, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "unable to write cassette file '%s'", fileName)
+	}
+
+	return nil
+}
+
+// NoMatchingInteractionError is the error returned by a Recorder in replay mode when its cassette contains no (unused) interaction matching a request.
+type NoMatchingInteractionError struct {
+	Method string
+	URL    string
+}
+
+// Error creates a string representation of the error.
+func (err *NoMatchingInteractionError) Error() string {
+	return fmt.Sprintf("cassette has no recorded interaction matching %s %s", err.Method, err.URL)
+}
+
+// Recorder is an http.RoundTripper that records HTTP interactions to (or replays them from) a cassette file.
+//
+// In record mode, requests are sent using the underlying transport and, when Stop is called, the (sanitised) interactions are written to the cassette file.
+// In replay mode, requests are matched against the cassette's interactions by method, path, query and (normalised) JSON body; each recorded interaction is replayed at most once, in the order in which it was recorded.
+type Recorder struct {
+	fileName   string
+	mode       CassetteMode
+	transport  http.RoundTripper
+	sanitizers []SanitizeFunc
+	stateLock  sync.Mutex
+	cassette   *Cassette
+	replayed   map[int]bool
+}
+
+// NewRecorder creates a new Recorder.
+//
+// In replay mode, the cassette is loaded from the specified file (and transport is ignored); in record mode, requests are sent using the specified transport (http.DefaultTransport if nil).
+func NewRecorder(fileName string, mode CassetteMode, transport http.RoundTripper) (*Recorder, error) {
+	recorder := &Recorder{
+		fileName:  fileName,
+		mode:      mode,
+		transport: transport,
+		replayed:  make(map[int]bool),
+	}
+	if recorder.transport == nil {
+		recorder.transport = http.DefaultTransport
+	}
+
+	switch mode {
+	case CassetteModeRecord:
+		recorder.cassette = &Cassette{}
+	case CassetteModeReplay:
+		cassette, err := LoadCassette(fileName)
+		if err != nil {
+			return nil, err
+		}
+		recorder.cassette = cassette
+	default:
+		return nil, fmt.Errorf("invalid cassette mode (%d)", mode)
+	}
+
+	return recorder, nil
+}
+
+// NewRecorderFromEnvironment creates a new Recorder whose mode is determined by the specified environment variable.
+//
+// If the environment variable is set to "record", the recorder operates in record mode; otherwise, it operates in replay mode.
+func NewRecorderFromEnvironment(fileName string, environmentVariable string, transport http.RoundTripper) (*Recorder, error) {
+	mode := CassetteModeReplay
+	if strings.EqualFold(os.Getenv(environmentVariable), "record") {
+		mode = CassetteModeRecord
+	}
+
+	return NewRecorder(fileName, mode, transport)
+}
+
+// Mode retrieves the mode in which the recorder operates.
+func (recorder *Recorder) Mode() CassetteMode {
+	return recorder.mode
+}
+
+// AddSanitizer adds a function that removes sensitive data from interactions before they are recorded.
+//
+// In replay mode, sanitizers are also applied to each request (with an empty response) before it is matched against the recorded interactions.
+// Administrator passwords, private keys and the Authorization header are always redacted.
+func (recorder *Recorder) AddSanitizer(sanitizer SanitizeFunc) {
+	recorder.stateLock.Lock()
+	defer recorder.stateLock.Unlock()
+
+	recorder.sanitizers = append(recorder.sanitizers, sanitizer)
+}
+
+// Stop stops the recorder and, in record mode, writes the recorded interactions to the cassette file.
+func (recorder *Recorder) Stop() error {
+	if recorder.mode != CassetteModeRecord {
+		return nil
+	}
+
+	recorder.stateLock.Lock()
+	defer recorder.stateLock.Unlock()
+
+	return recorder.cassette.Save(recorder.fileName)
+}
+
+// RoundTrip executes a single HTTP transaction.
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	snapshot, err := CreateSnapshot(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if recorder.mode == CassetteModeReplay {
+		return recorder.replay(request, snapshot)
+	}
+
+	return recorder.record(request, snapshot)
+}
+
+// record sends a request using the underlying transport, and records the resulting interaction.
+func (recorder *Recorder) record(request *http.Request, snapshot *Snapshot) (*http.Response, error) {
+	outgoingRequest, err := snapshot.CopyWithContext(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := recorder.transport.RoundTrip(outgoingRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unexpected error reading response body")
+	}
+
+	interaction := newInteraction(request, snapshot)
+	interaction.Response = RecordedResponse{
+		StatusCode: response.StatusCode,
+		Headers:    response.Header.Clone(),
+		Body:       RedactBody(responseBody),
+	}
+
+	recorder.stateLock.Lock()
+	recorder.sanitize(interaction)
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, interaction)
+	recorder.stateLock.Unlock()
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	return response, nil
+}
+
+// replay serves the response from the first unused recorded interaction that matches a request.
+func (recorder *Recorder) replay(request *http.Request, snapshot *Snapshot) (*http.Response, error) {
+	recorder.stateLock.Lock()
+	defer recorder.stateLock.Unlock()
+
+	// Recorded interactions have been sanitised, so the request must be too.
+	candidate := newInteraction(request, snapshot)
+	recorder.sanitize(candidate)
+
+	for index, interaction := range recorder.cassette.Interactions {
+		if recorder.replayed[index] {
+			continue
+		}
+
+		if !interactionMatches(interaction, candidate) {
+			continue
+		}
+
+		recorder.replayed[index] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Headers.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+		}, nil
+	}
+
+	return nil, &NoMatchingInteractionError{
+		Method: request.Method,
+		URL:    request.URL.String(),
+	}
+}
+
+// newInteraction creates an Interaction (with an empty response) representing a request, with sensitive data redacted.
+func newInteraction(request *http.Request, snapshot *Snapshot) *Interaction {
+	return &Interaction{
+		Request: RecordedRequest{
+			Method:  request.Method,
+			URL:     request.URL.String(),
+			Headers: RedactHeaders(request.Header),
+			Body:    RedactBody(snapshot.GetCachedRequestBody()),
+		},
+		Response: RecordedResponse{
+			Headers: http.Header{},
+		},
+	}
+}
+
+// sanitize applies the recorder's sanitizers to an interaction.
+//
+// The caller must hold stateLock.
+func (recorder *Recorder) sanitize(interaction *Interaction) {
+	for _, sanitize := range recorder.sanitizers {
+		sanitize(interaction)
+	}
+}
+
+// interactionMatches determines whether a recorded interaction matches a (sanitised) candidate interaction's request (by method, path, query and normalised JSON body).
+func interactionMatches(interaction *Interaction, candidate *Interaction) bool {
+	if interaction.Request.Method != candidate.Request.Method {
+		return false
+	}
+
+	recordedURL, err := url.Parse(interaction.Request.URL)
+	if err != nil {
+		return false
+	}
+	candidateURL, err := url.Parse(candidate.Request.URL)
+	if err != nil {
+		return false
+	}
+	if recordedURL.Path != candidateURL.Path {
+		return false
+	}
+	if !reflect.DeepEqual(normalizeQuery(recordedURL.Query()), normalizeQuery(candidateURL.Query())) {
+		return false
+	}
+
+	return normalizeBody([]byte(interaction.Request.Body)) == normalizeBody([]byte(candidate.Request.Body))
+}
+
+// normalizeQuery converts an empty query to nil (so that it compares equal to a missing query).
+func normalizeQuery(query url.Values) url.Values {
+	if len(query) == 0 {
+		return nil
+	}
+
+	return query
+}
+
+// normalizeBody converts a JSON body to a canonical representation (ignoring whitespace and property order); non-JSON bodies are returned unchanged.
+func normalizeBody(body []byte) string {
+	body = bytes.TrimSpace(body)
+
+	var value interface{}
+	if json.Unmarshal(body, &value) != nil {
+		return string(body)
+	}
+
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(normalized)
+}
@@ -0,0 +1,209 @@
+package requests_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute"
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute/requests"
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/computetest"
+)
+
+// Record a session against a fake CloudControl server, then replay it without the server.
+func TestRecorder_RecordAndReplay(test *testing.T) {
+	cassetteFile := filepath.Join(test.TempDir(), "deploy-server.json")
+
+	server := computetest.NewServer(computetest.WithPendingDuration(0))
+	recorder, err := requests.NewRecorder(cassetteFile, requests.CassetteModeRecord, nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+	recorder.AddSanitizer(func(interaction *requests.Interaction) {
+		interaction.Response.Headers.Del("Date")
+	})
+
+	client := server.NewClient(compute.WithTransport(recorder))
+	networkDomainID, serverID := deployTestServer(test, client)
+	server.Close()
+
+	err = recorder.Stop()
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	cassetteJSON, err := ioutil.ReadFile(cassetteFile)
+	if err != nil {
+		test.Fatal(err)
+	}
+	for _, secret := range []string{"sn4u$ag3s!", "dXNlcjE6cGFzc3dvcmQx" /* user1:password1 */} {
+		if strings.Contains(string(cassetteJSON), secret) {
+			test.Fatalf("Cassette contains sensitive data ('%s')", secret)
+		}
+	}
+	if strings.Contains(string(cassetteJSON), `"Date"`) {
+		test.Fatal("Cassette contains Date header removed by sanitizer")
+	}
+
+	recorder, err = requests.NewRecorder(cassetteFile, requests.CassetteModeReplay, nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	client = compute.NewClientWithOptions("", "user2", "password2",
+		compute.WithBaseAddress("http://replay.invalid"),
+		compute.WithTransport(recorder),
+	)
+	replayedNetworkDomainID, replayedServerID := deployTestServer(test, client)
+	if replayedNetworkDomainID != networkDomainID || replayedServerID != serverID {
+		test.Fatalf("Replayed Ids ('%s', '%s') do not match recorded Ids ('%s', '%s')", replayedNetworkDomainID, replayedServerID, networkDomainID, serverID)
+	}
+
+	// All recorded interactions have now been used.
+	_, err = client.GetServer(serverID)
+	var noMatchError *requests.NoMatchingInteractionError
+	if !errors.As(err, &noMatchError) {
+		test.Fatalf("GetServer: expected NoMatchingInteractionError but was %#v", err)
+	}
+}
+
+// Requests with a different JSON body do not match a recorded interaction.
+func TestRecorder_Replay_MatchesNormalizedBody(test *testing.T) {
+	cassetteFile := filepath.Join(test.TempDir(), "cassette.json")
+	cassette := &requests.Cassette{
+		Interactions: []*requests.Interaction{
+			{
+				Request: requests.RecordedRequest{
+					Method: "POST",
+					URL:    "https://api-au.dimensiondata.com/caas/2.4/org1/network/deleteVlan?b=2&a=1",
+					Body:   `{ "id": "vlan1", "force": true }`,
+				},
+				Response: requests.RecordedResponse{
+					StatusCode: 200,
+					Body:       `{"responseCode": "IN_PROGRESS"}`,
+				},
+			},
+		},
+	}
+	err := cassette.Save(cassetteFile)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	recorder, err := requests.NewRecorder(cassetteFile, requests.CassetteModeReplay, nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	request := newTestRequest(test, "http://localhost/caas/2.4/org1/network/deleteVlan?a=1&b=2", `{"force":true,"id":"vlan2"}`)
+	_, err = recorder.RoundTrip(request)
+	if err == nil {
+		test.Fatal("RoundTrip: expected error for request with different body")
+	}
+
+	request = newTestRequest(test, "http://localhost/caas/2.4/org1/network/deleteVlan?a=1&b=2", `{"force":true,"id":"vlan1"}`)
+	response, err := recorder.RoundTrip(request)
+	if err != nil {
+		test.Fatal(err)
+	}
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if response.StatusCode != 200 || string(responseBody) != `{"responseCode": "IN_PROGRESS"}` {
+		test.Fatalf("RoundTrip: unexpected response %d '%s'", response.StatusCode, responseBody)
+	}
+}
+
+// Sanitizers are applied to requests before they are matched against recorded (sanitised) interactions.
+func TestRecorder_Replay_AppliesSanitizers(test *testing.T) {
+	cassetteFile := filepath.Join(test.TempDir(), "cassette.json")
+	cassette := &requests.Cassette{
+		Interactions: []*requests.Interaction{
+			{
+				Request: requests.RecordedRequest{
+					Method: "POST",
+					URL:    "https://api-au.dimensiondata.com/caas/2.4/ORGANIZATION_ID/network/deleteVlan",
+					Body:   `{"id": "vlan1", "organizationId": "ORGANIZATION_ID"}`,
+				},
+				Response: requests.RecordedResponse{
+					StatusCode: 200,
+					Body:       `{"responseCode": "IN_PROGRESS"}`,
+				},
+			},
+		},
+	}
+	err := cassette.Save(cassetteFile)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	recorder, err := requests.NewRecorder(cassetteFile, requests.CassetteModeReplay, nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+	recorder.AddSanitizer(func(interaction *requests.Interaction) {
+		interaction.Request.URL = strings.ReplaceAll(interaction.Request.URL, "org1", "ORGANIZATION_ID")
+		interaction.Request.Body = strings.ReplaceAll(interaction.Request.Body, "org1", "ORGANIZATION_ID")
+		interaction.Response.Headers.Del("Date")
+	})
+
+	request := newTestRequest(test, "http://localhost/caas/2.4/org1/network/deleteVlan", `{"id":"vlan1","organizationId":"org1"}`)
+	response, err := recorder.RoundTrip(request)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if response.StatusCode != 200 {
+		test.Fatalf("RoundTrip: unexpected status code %d", response.StatusCode)
+	}
+}
+
+func deployTestServer(test *testing.T, client *compute.Client) (networkDomainID string, serverID string) {
+	networkDomainID, err := client.DeployNetworkDomain("domain1", "Network domain 1", "ESSENTIALS", "AU9")
+	if err != nil {
+		test.Fatal(err)
+	}
+	vlanID, err := client.DeployVLAN(networkDomainID, "vlan1", "VLAN 1", "192.168.1.0", 24, "LOW", "")
+	if err != nil {
+		test.Fatal(err)
+	}
+	serverID, err = client.DeployServer(compute.ServerDeploymentConfiguration{
+		Name:                  "server1",
+		ImageID:               "image1",
+		AdministratorPassword: "sn4u$ag3s!",
+		Network: compute.VirtualMachineNetwork{
+			NetworkDomainID: networkDomainID,
+			PrimaryAdapter: compute.VirtualMachineNetworkAdapter{
+				VLANID: &vlanID,
+			},
+		},
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+	_, err = client.CreateFirewallRule(compute.FirewallRuleConfiguration{
+		Name:            "rule1",
+		Action:          "ACCEPT_DECISIVELY",
+		Enabled:         true,
+		IPVersion:       "IPV4",
+		Protocol:        "TCP",
+		NetworkDomainID: networkDomainID,
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return
+}
+
+func newTestRequest(test *testing.T, requestURL string, body string) *http.Request {
+	request, err := http.NewRequest("POST", requestURL, strings.NewReader(body))
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	return request
+}
@@ -0,0 +1,40 @@
+package requests
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue is the value substituted for sensitive data in redacted requests and responses.
+const RedactedValue = "[REDACTED]"
+
+var (
+	// Matches the value of JSON or XML properties containing administrator passwords.
+	redactPasswordJSONPattern = regexp.MustCompile(`("administratorPassword"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	redactPasswordXMLPattern  = regexp.MustCompile(`(<(?:\w+:)?administratorPassword>)[^<]*(</)`)
+
+	// Matches PEM-encoded private keys (as JSON string values or raw text).
+	redactPrivateKeyPattern = regexp.MustCompile(`-----BEGIN ([A-Z ]*)PRIVATE KEY-----(?:\\n|[^"])*?-----END ([A-Z ]*)PRIVATE KEY-----`)
+)
+
+// RedactBody removes sensitive data (administrator passwords and private keys) from a request or response body.
+func RedactBody(body []byte) string {
+	redacted := string(body)
+	redacted = redactPasswordJSONPattern.ReplaceAllString(redacted, `${1}"`+RedactedValue+`"`)
+	redacted = redactPasswordXMLPattern.ReplaceAllString(redacted, "${1}"+RedactedValue+"${2}")
+	redacted = redactPrivateKeyPattern.ReplaceAllString(redacted, RedactedValue)
+
+	return redacted
+}
+
+// RedactHeaders creates a copy of the specified request headers with sensitive values (e.g. the Authorization header) removed.
+func RedactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	if redacted.Get("Authorization") != "" {
+		scheme := strings.SplitN(redacted.Get("Authorization"), " ", 2)[0]
+		redacted.Set("Authorization", scheme+" "+RedactedValue)
+	}
+
+	return redacted
+}
@@ -2,6 +2,7 @@ package requests
 
 import (
 	"bytes"
+	"context"
 	"github.com/pkg/errors"
 	"io"
 	"io/ioutil"
@@ -52,7 +53,13 @@ type Snapshot struct {
 
 // Copy creates a copy of the request represented by the snapshot.
 func (snapshot *Snapshot) Copy() (clonedRequest *http.Request, err error) {
-	clonedRequest, err = http.NewRequest(
+	return snapshot.CopyWithContext(context.Background())
+}
+
+// CopyWithContext creates a copy of the request represented by the snapshot, bound to the specified context.
+func (snapshot *Snapshot) CopyWithContext(ctx context.Context) (clonedRequest *http.Request, err error) {
+	clonedRequest, err = http.NewRequestWithContext(
+		ctx,
 		snapshot.requestMethod,
 		snapshot.requestURL,
 		snapshot.GetCachedRequestBodyReader(),
@@ -46,7 +46,7 @@ func (client *Client) ListReservedPrivateIPv4AddressesInVLAN(vlanID string) (res
 		url.QueryEscape(organizationID),
 		url.QueryEscape(vlanID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -83,11 +83,14 @@ func (client *Client) ReservePrivateIPv4Address(vlanID string, ipAddress string,
 	requestURI := fmt.Sprintf("%s/network/reservePrivateIpv4Address",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &ReservedIPAddress{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &ReservedIPAddress{
 		IPAddress:   ipAddress,
 		VLANID:      vlanID,
 		Description: description,
 	})
+	if err != nil {
+		return err
+	}
 
 	if err != nil {
 		return err
@@ -119,7 +122,7 @@ func (client *Client) UnreservePrivateIPv4Address(vlanID string, ipAddress strin
 	requestURI := fmt.Sprintf("%s/network/unreservePrivateIpv4Address",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &ReservedIPAddress{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &ReservedIPAddress{
 		IPAddress:   ipAddress,
 		VLANID:      vlanID,
 		Description: description,
@@ -155,7 +158,7 @@ func (client *Client) ListReservedIPv6AddressesInVLAN(vlanID string) (reservedIP
 		url.QueryEscape(organizationID),
 		url.QueryEscape(vlanID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -192,7 +195,7 @@ func (client *Client) ReserveIPv6Address(vlanID string, ipAddress string, descri
 	requestURI := fmt.Sprintf("%s/network/reserveIpv6Address",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &ReservedIPAddress{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &ReservedIPAddress{
 		IPAddress:   ipAddress,
 		VLANID:      vlanID,
 		Description: description,
@@ -227,7 +230,7 @@ func (client *Client) UnreserveIPv6Address(vlanID string, ipAddress string, desc
 	requestURI := fmt.Sprintf("%s/network/unreserveIpv6Address",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &ReservedIPAddress{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &ReservedIPAddress{
 		IPAddress:   ipAddress,
 		VLANID:      vlanID,
 		Description: description,
@@ -0,0 +1,329 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LockKey identifies a resource that a LockManager serialises operations on.
+type LockKey struct {
+	ResourceType ResourceType
+	ID           string
+}
+
+// String creates a string representation of the lock key.
+func (key LockKey) String() string {
+	resourceDescription, err := GetResourceDescription(key.ResourceType)
+	if err != nil {
+		resourceDescription = fmt.Sprintf("resource type %d", key.ResourceType)
+	}
+
+	return fmt.Sprintf("%s '%s'", resourceDescription, key.ID)
+}
+
+// LockKeyFunc determines the resources (if any) that a mutating request affects.
+//
+// requestPath is the request URL's path, and requestBody is the (possibly empty) request body.
+type LockKeyFunc func(method string, requestPath string, requestBody []byte) []LockKey
+
+// LockManager serialises mutating operations that affect the same server, network domain or VIP pool (which CloudControl would otherwise reject with RESOURCE_BUSY).
+//
+// Once a mutating operation has been accepted by CloudControl (IN_PROGRESS), its locks are held until the affected resources have returned to the NORMAL state.
+// A LockManager can be shared by multiple clients (for the same CloudControl region).
+type LockManager struct {
+	// The interval between polls of a resource's status while waiting for it to return to the NORMAL state.
+	PollInterval time.Duration
+
+	// The maximum time to wait for a resource to return to the NORMAL state before releasing its lock anyway.
+	Timeout time.Duration
+
+	// The function used to determine the resources that a mutating request affects (if nil, DefaultLockKeys is used).
+	KeyFunc LockKeyFunc
+
+	stateLock sync.Mutex
+	locks     map[LockKey]chan struct{}
+}
+
+// NewLockManager creates a new LockManager with default settings (resource status is polled every 5 seconds, for up to 30 minutes).
+func NewLockManager() *LockManager {
+	return &LockManager{
+		PollInterval: 5 * time.Second,
+		Timeout:      30 * time.Minute,
+		locks:        make(map[LockKey]chan struct{}),
+	}
+}
+
+// keysFor determines the resources (if any) that the specified request affects.
+//
+// additionalKeys are resources that the caller knows the request affects, but that cannot be determined from the request itself (see Client.WithLockKeys).
+func (lockManager *LockManager) keysFor(method string, requestPath string, requestBody []byte, additionalKeys ...LockKey) []LockKey {
+	if method == http.MethodGet || method == http.MethodHead {
+		return nil
+	}
+
+	keyFunc := lockManager.KeyFunc
+	if keyFunc == nil {
+		keyFunc = DefaultLockKeys
+	}
+
+	keys := keyFunc(method, requestPath, requestBody)
+	for _, additionalKey := range additionalKeys {
+		isDuplicate := false
+		for _, key := range keys {
+			if key == additionalKey {
+				isDuplicate = true
+
+				break
+			}
+		}
+		if !isDuplicate {
+			keys = append(keys, additionalKey)
+		}
+	}
+
+	// Always acquire locks in the same order, to avoid deadlock.
+	sort.Slice(keys, func(index1 int, index2 int) bool {
+		if keys[index1].ResourceType != keys[index2].ResourceType {
+			return keys[index1].ResourceType < keys[index2].ResourceType
+		}
+
+		return keys[index1].ID < keys[index2].ID
+	})
+
+	return keys
+}
+
+// Lock acquires the locks for the specified resources, waiting until they are available (or the context is cancelled).
+func (lockManager *LockManager) Lock(ctx context.Context, keys ...LockKey) error {
+	for index, key := range keys {
+		select {
+		case lockManager.getLock(key) <- struct{}{}:
+		case <-ctx.Done():
+			lockManager.Unlock(keys[:index]...)
+
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Unlock releases the locks for the specified resources.
+func (lockManager *LockManager) Unlock(keys ...LockKey) {
+	for _, key := range keys {
+		<-lockManager.getLock(key)
+	}
+}
+
+// getLock retrieves (or creates) the lock for the specified resource.
+func (lockManager *LockManager) getLock(key LockKey) chan struct{} {
+	lockManager.stateLock.Lock()
+	defer lockManager.stateLock.Unlock()
+
+	if lockManager.locks == nil {
+		lockManager.locks = make(map[LockKey]chan struct{})
+	}
+
+	lock, ok := lockManager.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		lockManager.locks[key] = lock
+	}
+
+	return lock
+}
+
+// The fields in request bodies that identify the resources affected by mutating requests.
+var lockKeyFields = map[string]ResourceType{
+	"serverid":        ResourceTypeServer,
+	"networkdomainid": ResourceTypeNetworkDomain,
+	"poolid":          ResourceTypeVIPPool,
+}
+
+// The operations whose request bodies identify the affected resource using the "id" field.
+var lockKeyOperations = map[string]ResourceType{
+	"deleteServer":           ResourceTypeServer,
+	"startServer":            ResourceTypeServer,
+	"shutdownServer":         ResourceTypeServer,
+	"powerOffServer":         ResourceTypeServer,
+	"rebootServer":           ResourceTypeServer,
+	"resetServer":            ResourceTypeServer,
+	"reconfigureServer":      ResourceTypeServer,
+	"editServerMetadata":     ResourceTypeServer,
+	"updateVmwareTools":      ResourceTypeServer,
+	"upgradeVirtualHardware": ResourceTypeServer,
+	"cloneServer":            ResourceTypeServer,
+	"addDisk":                ResourceTypeServer,
+	"editNetworkDomain":      ResourceTypeNetworkDomain,
+	"deleteNetworkDomain":    ResourceTypeNetworkDomain,
+	"editPool":               ResourceTypeVIPPool,
+	"deletePool":             ResourceTypeVIPPool,
+}
+
+// Matches v1 API paths for server operations (e.g. "/oec/0.9/{orgId}/server/{serverId}/disk/{diskId}/changeSize").
+var lockKeyV1ServerPathPattern = regexp.MustCompile(`^/oec/[^/]+/[^/]+/server/([0-9a-fA-F-]{36})(?:/|$)`)
+
+// DefaultLockKeys determines the servers, network domains and VIP pools that a mutating request affects.
+//
+// Resources are identified by the "serverId", "networkDomainId" and "poolId" fields in a JSON request body, by the "id" field for well-known operations on servers, network domains and VIP pools (e.g. "deleteServer"), and by the server Id in v1 API paths.
+//
+// Requests that only identify a disk, SCSI controller or network adapter (e.g. ExpandDisk or RemoveNicFromServer) do not identify the server that owns it.
+// Higher-level operations that know the server (e.g. ApplyServerDiskLayout, ApplyServerNetworkAdapters and ChangeServerDiskSpeed) supply it via Client.WithLockKeys; other callers should do the same.
+func DefaultLockKeys(method string, requestPath string, requestBody []byte) []LockKey {
+	var keys []LockKey
+	addKey := func(resourceType ResourceType, id string) {
+		if id == "" {
+			return
+		}
+
+		key := LockKey{ResourceType: resourceType, ID: id}
+		for _, existingKey := range keys {
+			if existingKey == key {
+				return
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	if match := lockKeyV1ServerPathPattern.FindStringSubmatch(requestPath); match != nil {
+		addKey(ResourceTypeServer, match[1])
+	}
+
+	var body map[string]interface{}
+	if json.Unmarshal(requestBody, &body) != nil {
+		return keys
+	}
+
+	operationName := requestPath[strings.LastIndex(requestPath, "/")+1:]
+	for fieldName, fieldValue := range body {
+		id, ok := fieldValue.(string)
+		if !ok {
+			continue
+		}
+
+		fieldName = strings.ToLower(fieldName)
+		if resourceType, ok := lockKeyFields[fieldName]; ok {
+			addKey(resourceType, id)
+		} else if resourceType, ok := lockKeyOperations[operationName]; ok && fieldName == "id" {
+			addKey(resourceType, id)
+		}
+	}
+
+	return keys
+}
+
+// WithLockKeys creates a copy of the client whose mutating operations also lock the specified resources (if the client has a LockManager).
+//
+// Use this for operations whose requests only identify a child resource, e.g.:
+//
+//	client.WithLockKeys(compute.LockKey{ResourceType: compute.ResourceTypeServer, ID: serverID}).ExpandDisk(diskID, newSizeGB)
+//
+// The copy shares configuration and cached state with the original client (see WithContext).
+func (client *Client) WithLockKeys(keys ...LockKey) *Client {
+	return &Client{
+		clientState: client.clientState,
+		context:     client.context,
+		lockKeys:    append(append([]LockKey(nil), client.lockKeys...), keys...),
+	}
+}
+
+// SetLockManager configures the client to use the specified LockManager to serialise mutating operations that affect the same resource.
+// Set lockManager to nil (the default) to disable serialisation.
+func (client *Client) SetLockManager(lockManager *LockManager) {
+	client.lockManager.Store(lockManagerHolder{lockManager})
+}
+
+// getLockManager retrieves the client's lock manager (if any).
+func (client *Client) getLockManager() *LockManager {
+	holder, ok := client.lockManager.Load().(lockManagerHolder)
+	if !ok {
+		return nil
+	}
+
+	return holder.lockManager
+}
+
+// lockManagerHolder enables storage of a (possibly nil) LockManager in an atomic.Value.
+type lockManagerHolder struct {
+	lockManager *LockManager
+}
+
+// lockResources acquires the locks (if any) for the resources affected by the specified request.
+//
+// If successful, the caller must call the returned function once the request has completed (with its outcome), to release the locks.
+// Returns an OperationCancelledError if cancellation is requested while waiting.
+func (client *Client) lockResources(method string, requestPath string, requestBody []byte, operationDescription string) (unlock func(statusCode int, responseBody []byte, err error), err error) {
+	lockManager := client.getLockManager()
+	if lockManager == nil {
+		return func(int, []byte, error) {}, nil
+	}
+
+	keys := lockManager.keysFor(method, requestPath, requestBody, client.lockKeys...)
+	if len(keys) == 0 {
+		return func(int, []byte, error) {}, nil
+	}
+
+	client.logDebug("Acquiring resource locks...",
+		logField(LogFieldMethod, method),
+		logField(LogFieldURL, requestPath),
+		logField(LogFieldLockKeys, keys),
+	)
+	err = lockManager.Lock(client.Context(), keys...)
+	if err != nil {
+		return nil, client.checkCancellation(operationDescription)
+	}
+
+	return func(statusCode int, responseBody []byte, err error) {
+		if err != nil || statusCode < 200 || statusCode >= 300 || getResponseCode(responseBody) != ResponseCodeInProgress {
+			lockManager.Unlock(keys...)
+
+			return
+		}
+
+		// The operation is in progress; release the locks once the affected resources have returned to the NORMAL state.
+		go client.unlockWhenNormal(lockManager, keys)
+	}, nil
+}
+
+// unlockWhenNormal waits for the specified resources to return to the NORMAL state (or be deleted), then releases their locks.
+func (client *Client) unlockWhenNormal(lockManager *LockManager, keys []LockKey) {
+	defer lockManager.Unlock(keys...)
+
+	// Polling is independent of the context of the operation that acquired the locks.
+	pollClient := client.WithContext(context.Background())
+
+	deadline := time.Now().Add(lockManager.Timeout)
+	for _, key := range keys {
+		for {
+			if time.Now().After(deadline) {
+				client.logWarn("Timed out waiting for resource to return to NORMAL state; releasing lock.",
+					logField(LogFieldLockKeys, keys),
+				)
+
+				return
+			}
+
+			time.Sleep(lockManager.PollInterval)
+
+			resource, err := pollClient.GetResource(key.ID, key.ResourceType)
+			if err != nil {
+				client.logWarn("Unable to determine resource state; releasing lock.",
+					logField(LogFieldLockKeys, keys),
+					logField(LogFieldError, err),
+				)
+
+				return
+			}
+			if resource == nil || resource.IsDeleted() || resource.GetState() == ResourceStatusNormal {
+				break
+			}
+		}
+	}
+}
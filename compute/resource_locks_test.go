@@ -0,0 +1,175 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// DefaultLockKeys identifies the resources affected by well-known mutating requests.
+func TestDefaultLockKeys(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+	networkDomainID := "484174a2-ae74-4658-9e56-50fc90e086cf"
+	poolID := "afb1fe3a-6cc9-4b1e-9b6f-d2ae6c5d6ed9"
+
+	keys := DefaultLockKeys(http.MethodPost, "/caas/2.9/org-id/server/addNic", []byte(`{"serverId":"`+serverID+`","nic":{"vlanId":"x"}}`))
+	expect.EqualsInt("addNic key count", 1, len(keys))
+	expect.IsTrue("addNic key", keys[0] == LockKey{ResourceType: ResourceTypeServer, ID: serverID})
+
+	keys = DefaultLockKeys(http.MethodPost, "/caas/2.9/org-id/network/createFirewallRule", []byte(`{"networkDomainId":"`+networkDomainID+`","name":"rule1"}`))
+	expect.EqualsInt("createFirewallRule key count", 1, len(keys))
+	expect.IsTrue("createFirewallRule key", keys[0] == LockKey{ResourceType: ResourceTypeNetworkDomain, ID: networkDomainID})
+
+	keys = DefaultLockKeys(http.MethodPost, "/caas/2.9/org-id/server/deleteServer", []byte(`{"id":"`+serverID+`"}`))
+	expect.EqualsInt("deleteServer key count", 1, len(keys))
+	expect.IsTrue("deleteServer key", keys[0] == LockKey{ResourceType: ResourceTypeServer, ID: serverID})
+
+	keys = DefaultLockKeys(http.MethodPost, "/caas/2.9/org-id/networkDomainVip/editPool", []byte(`{"id":"`+poolID+`","loadBalanceMethod":"ROUND_ROBIN"}`))
+	expect.EqualsInt("editPool key count", 1, len(keys))
+	expect.IsTrue("editPool key", keys[0] == LockKey{ResourceType: ResourceTypeVIPPool, ID: poolID})
+
+	keys = DefaultLockKeys(http.MethodPost, "/oec/0.9/org-id/server/"+serverID+"/disk/b9a5e1a4-8d24-4b6c-93e8-3e3f6e7a7a01/changeSize", []byte(`<ChangeDiskSize/>`))
+	expect.EqualsInt("v1 changeSize key count", 1, len(keys))
+	expect.IsTrue("v1 changeSize key", keys[0] == LockKey{ResourceType: ResourceTypeServer, ID: serverID})
+
+	// Operations on resources that are not locked.
+	keys = DefaultLockKeys(http.MethodPost, "/caas/2.9/org-id/network/deleteVlan", []byte(`{"id":"0e56433f-d808-4669-821d-812769517ff8"}`))
+	expect.EqualsInt("deleteVlan key count", 0, len(keys))
+
+	// Requests that only identify a child resource are locked using the keys supplied by the caller (see Client.WithLockKeys).
+	expandDiskBody := []byte(`{"id":"b9a5e1a4-8d24-4b6c-93e8-3e3f6e7a7a01","newSizeGb":20}`)
+	keys = DefaultLockKeys(http.MethodPost, "/caas/2.9/org-id/server/expandDisk", expandDiskBody)
+	expect.EqualsInt("expandDisk key count", 0, len(keys))
+	keys = NewLockManager().keysFor(http.MethodPost, "/caas/2.9/org-id/server/expandDisk", expandDiskBody, LockKey{ResourceType: ResourceTypeServer, ID: serverID})
+	expect.EqualsInt("expandDisk key count (with server)", 1, len(keys))
+	expect.IsTrue("expandDisk key (with server)", keys[0] == LockKey{ResourceType: ResourceTypeServer, ID: serverID})
+
+	// Read requests are never locked.
+	keys = NewLockManager().keysFor(http.MethodGet, "/caas/2.9/org-id/server/server/"+serverID, nil)
+	expect.EqualsInt("GET key count", 0, len(keys))
+}
+
+// Mutating operations on the same server wait until the server has returned to the NORMAL state.
+func TestClient_LockManager_SerialisesServerOperations(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	var stateLock sync.Mutex
+	serverState := ResourceStatusNormal
+	pollCount := 0
+	postCount := 0
+	violationCount := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		stateLock.Lock()
+		defer stateLock.Unlock()
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		if request.Method == http.MethodGet {
+			pollCount++
+			if pollCount%2 == 0 {
+				serverState = ResourceStatusNormal
+			}
+
+			fmt.Fprintf(writer, `{"id": "%s", "state": "%s"}`, serverID, serverState)
+
+			return
+		}
+
+		postCount++
+		if serverState != ResourceStatusNormal {
+			violationCount++
+		}
+		serverState = ResourceStatusPendingChange
+		pollCount = 0
+
+		fmt.Fprint(writer, retryTestOKResponse)
+	}))
+	defer testServer.Close()
+
+	lockManager := NewLockManager()
+	lockManager.PollInterval = 10 * time.Millisecond
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithLockManager(lockManager),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	var waitGroup sync.WaitGroup
+	for index := 0; index < 4; index++ {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+
+			var err error
+			if index%2 == 0 {
+				err = client.StartServer(serverID)
+			} else {
+				err = client.ShutdownServer(serverID)
+			}
+			if err != nil {
+				test.Error(err)
+			}
+		}(index)
+	}
+	waitGroup.Wait()
+
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	expect.EqualsInt("PostCount", 4, postCount)
+	expect.EqualsInt("ViolationCount", 0, violationCount)
+}
+
+// Time spent waiting for a resource lock counts against the client's context.
+func TestClient_LockManager_Cancelled(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	requestCount := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprint(writer, retryTestOKResponse)
+	}))
+	defer testServer.Close()
+
+	lockManager := NewLockManager()
+	key := LockKey{ResourceType: ResourceTypeServer, ID: serverID}
+	err := lockManager.Lock(context.Background(), key)
+	if err != nil {
+		test.Fatal(err)
+	}
+	defer lockManager.Unlock(key)
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithLockManager(lockManager),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = client.WithContext(ctx).StartServer(serverID)
+	expect.IsTrue("IsOperationCancelledError", IsOperationCancelledError(err))
+	expect.EqualsInt("RequestCount", 0, requestCount)
+	expect.IsTrue("LockKey.String", strings.Contains(key.String(), serverID))
+}
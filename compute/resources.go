@@ -59,6 +59,9 @@ const (
 
 	// ResourceStaticRoutes represents network domain static routes
 	ResourceTypeStaticRoutes
+
+	// ResourceTypeServerSnapshot represents a snapshot of a virtual machine.
+	ResourceTypeServerSnapshot
 )
 
 // Resource represents a compute resource.
@@ -126,6 +129,9 @@ func GetResourceDescription(resourceType ResourceType) (string, error) {
 	case ResourceTypeStaticRoutes:
 		return "Static Routes", nil
 
+	case ResourceTypeServerSnapshot:
+		return "server snapshot", nil
+
 	default:
 		return "", fmt.Errorf("unrecognised resource type (value = %d)", resourceType)
 	}
@@ -183,6 +189,9 @@ func (client *Client) GetResource(id string, resourceType ResourceType) (Resourc
 
 	case ResourceTypeStaticRoutes:
 		return client.GetStaticRoute(id)
+
+	case ResourceTypeServerSnapshot:
+		return client.GetServerSnapshot(id)
 	}
 
 	return nil, fmt.Errorf("unrecognised resource type (value = %d)", resourceType)
@@ -202,18 +211,12 @@ func (client *Client) getNetworkAdapterByID(id string) (Resource, error) {
 		return nil, fmt.Errorf("No server found with Id '%s.'", compositeIDComponents[0])
 	}
 
-	var targetAdapterID = compositeIDComponents[1]
-	if *server.Network.PrimaryAdapter.ID == targetAdapterID {
-		return &server.Network.PrimaryAdapter, nil
-	}
-
-	for _, adapter := range server.Network.AdditionalNetworkAdapters {
-		if *adapter.ID == targetAdapterID {
-			return &adapter, nil
-		}
+	adapter := server.Network.GetAdapterByID(compositeIDComponents[1])
+	if adapter == nil {
+		return nil, nil
 	}
 
-	return nil, nil
+	return adapter, nil
 }
 
 // Retrieve a server anti-affinity rule by qualified ID ("networkDomainId/ruleId").
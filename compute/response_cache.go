@@ -0,0 +1,255 @@
+package compute
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheRule determines which responses a ResponseCache stores, and for how long.
+type CacheRule struct {
+	// The API end-point whose (GET) responses are cached, relative to the organisation (e.g. "infrastructure/datacenter").
+	//
+	// Requests for resources below the end-point (e.g. "image/osImage/{id}" for "image/osImage") are also cached.
+	Path string
+
+	// The length of time that responses are cached for.
+	TTL time.Duration
+
+	// The names of the mutating operations (e.g. "createTagKey") that invalidate all cached responses for the end-point.
+	InvalidatedBy []string
+}
+
+// DefaultCacheRules creates the default rules for a ResponseCache (data centers, OS images, tag keys, snapshot service plans, and default health monitors, persistence profiles and iRules).
+func DefaultCacheRules() []CacheRule {
+	return []CacheRule{
+		{Path: "infrastructure/datacenter", TTL: 1 * time.Hour},
+		{Path: "image/osImage", TTL: 1 * time.Hour},
+		{Path: "tag/tagKey", TTL: 10 * time.Minute, InvalidatedBy: []string{"createTagKey", "editTagKey", "deleteTagKey"}},
+		{Path: "networkDomainVip/defaultHealthMonitor", TTL: 1 * time.Hour},
+		{Path: "networkDomainVip/defaultPersistenceProfile", TTL: 1 * time.Hour},
+		{Path: "networkDomainVip/defaultIrule", TTL: 1 * time.Hour},
+		{Path: "snapshot/servicePlan", TTL: 1 * time.Hour},
+	}
+}
+
+// ResponseCache caches successful responses from read-mostly API end-points (see CacheRule).
+//
+// Identical concurrent requests for a cacheable end-point are coalesced into a single request.
+// A ResponseCache can be shared by multiple clients, as long as they use the same credentials.
+type ResponseCache struct {
+	rules []CacheRule
+
+	stateLock   sync.Mutex
+	entries     map[string]*responseCacheEntry
+	calls       map[string]*responseCacheCall
+	generations []int // Per rule; incremented when the rule's entries are invalidated.
+}
+
+// responseCacheEntry represents a cached response.
+type responseCacheEntry struct {
+	ruleIndex    int
+	responseBody []byte
+	statusCode   int
+	expiresAt    time.Time
+}
+
+// responseCacheCall represents an in-flight request whose response will be shared by all callers making the same request.
+type responseCacheCall struct {
+	done         chan struct{}
+	responseBody []byte
+	statusCode   int
+	err          error
+}
+
+// NewResponseCache creates a new ResponseCache using the specified rules (or DefaultCacheRules, if no rules are specified).
+func NewResponseCache(rules ...CacheRule) *ResponseCache {
+	if len(rules) == 0 {
+		rules = DefaultCacheRules()
+	}
+
+	return &ResponseCache{
+		rules:       rules,
+		entries:     make(map[string]*responseCacheEntry),
+		calls:       make(map[string]*responseCacheCall),
+		generations: make([]int, len(rules)),
+	}
+}
+
+// Clear removes all responses from the cache.
+func (cache *ResponseCache) Clear() {
+	cache.stateLock.Lock()
+	defer cache.stateLock.Unlock()
+
+	cache.entries = make(map[string]*responseCacheEntry)
+	for ruleIndex := range cache.generations {
+		cache.generations[ruleIndex]++
+	}
+}
+
+// Invalidate removes all cached responses for the specified end-point (e.g. "tag/tagKey").
+func (cache *ResponseCache) Invalidate(path string) {
+	cache.stateLock.Lock()
+	defer cache.stateLock.Unlock()
+
+	for ruleIndex, rule := range cache.rules {
+		if rule.Path == path {
+			cache.invalidateRule(ruleIndex)
+		}
+	}
+}
+
+// invalidateRule removes all cached responses for the specified rule.
+//
+// The caller must hold stateLock.
+func (cache *ResponseCache) invalidateRule(ruleIndex int) {
+	for key, entry := range cache.entries {
+		if entry.ruleIndex == ruleIndex {
+			delete(cache.entries, key)
+		}
+	}
+	cache.generations[ruleIndex]++
+}
+
+// execute performs the specified request using the cache (if applicable).
+func (cache *ResponseCache) execute(client *Client, request *http.Request) (responseBody []byte, statusCode int, err error) {
+	relativePath := getOrganizationRelativePath(request.URL.Path)
+
+	if request.Method != http.MethodGet {
+		responseBody, statusCode, err = client.executeUncachedRequest(request)
+
+		cache.invalidateForOperation(relativePath[strings.LastIndex(relativePath, "/")+1:])
+
+		return
+	}
+
+	ruleIndex := cache.findRule(relativePath)
+	if ruleIndex == -1 {
+		return client.executeUncachedRequest(request)
+	}
+
+	key := request.URL.String()
+
+	for {
+		cache.stateLock.Lock()
+		entry, ok := cache.entries[key]
+		if ok && time.Now().Before(entry.expiresAt) {
+			cache.stateLock.Unlock()
+
+			client.logDebug("Using cached response.", logField(LogFieldMethod, request.Method), logField(LogFieldURL, key))
+
+			return entry.responseBody, entry.statusCode, nil
+		}
+
+		call, ok := cache.calls[key]
+		if ok {
+			cache.stateLock.Unlock()
+
+			// Wait for the identical in-flight request.
+			select {
+			case <-call.done:
+				// The in-flight request was cancelled by its own caller; unless this caller has also been cancelled, try again.
+				if IsOperationCancelledError(call.err) {
+					err = client.checkCancellation("GET of '" + key + "'")
+					if err != nil {
+						return nil, 0, err
+					}
+
+					continue
+				}
+
+				return call.responseBody, call.statusCode, call.err
+
+			case <-client.Context().Done():
+				return nil, 0, client.checkCancellation("GET of '" + key + "'")
+			}
+		}
+
+		call = &responseCacheCall{
+			done: make(chan struct{}),
+		}
+		cache.calls[key] = call
+		generation := cache.generations[ruleIndex]
+		cache.stateLock.Unlock()
+
+		call.responseBody, call.statusCode, call.err = client.executeUncachedRequest(request)
+
+		cache.stateLock.Lock()
+		delete(cache.calls, key)
+
+		// Don't cache failed requests, or responses that may have been invalidated while the request was in flight.
+		if call.err == nil && call.statusCode == http.StatusOK && cache.generations[ruleIndex] == generation {
+			cache.entries[key] = &responseCacheEntry{
+				ruleIndex:    ruleIndex,
+				responseBody: call.responseBody,
+				statusCode:   call.statusCode,
+				expiresAt:    time.Now().Add(cache.rules[ruleIndex].TTL),
+			}
+		}
+		cache.stateLock.Unlock()
+		close(call.done)
+
+		return call.responseBody, call.statusCode, call.err
+	}
+}
+
+// findRule finds the index of the rule (if any) that applies to the specified organisation-relative path.
+//
+// Returns -1 if no rule applies.
+func (cache *ResponseCache) findRule(relativePath string) int {
+	for ruleIndex, rule := range cache.rules {
+		if relativePath == rule.Path || strings.HasPrefix(relativePath, rule.Path+"/") {
+			return ruleIndex
+		}
+	}
+
+	return -1
+}
+
+// invalidateForOperation removes all cached responses invalidated by the specified mutating operation (e.g. "createTagKey").
+func (cache *ResponseCache) invalidateForOperation(operationName string) {
+	cache.stateLock.Lock()
+	defer cache.stateLock.Unlock()
+
+	for ruleIndex, rule := range cache.rules {
+		for _, invalidatingOperationName := range rule.InvalidatedBy {
+			if invalidatingOperationName == operationName {
+				cache.invalidateRule(ruleIndex)
+
+				break
+			}
+		}
+	}
+}
+
+// getOrganizationRelativePath extracts the portion of a request path following the API version and organisation Id (e.g. "infrastructure/datacenter" from "/caas/2.4/{orgId}/infrastructure/datacenter").
+func getOrganizationRelativePath(requestPath string) string {
+	pathSegments := strings.SplitN(strings.TrimPrefix(requestPath, "/"), "/", 4)
+	if len(pathSegments) < 4 {
+		return ""
+	}
+
+	return pathSegments[3]
+}
+
+// SetResponseCache configures the client to use the specified ResponseCache for responses from read-mostly API end-points.
+// Set cache to nil (the default) to disable caching.
+func (client *Client) SetResponseCache(cache *ResponseCache) {
+	client.responseCache.Store(responseCacheHolder{cache})
+}
+
+// getResponseCache retrieves the client's response cache (if any).
+func (client *Client) getResponseCache() *ResponseCache {
+	holder, ok := client.responseCache.Load().(responseCacheHolder)
+	if !ok {
+		return nil
+	}
+
+	return holder.cache
+}
+
+// responseCacheHolder enables storage of a (possibly nil) ResponseCache in an atomic.Value.
+type responseCacheHolder struct {
+	cache *ResponseCache
+}
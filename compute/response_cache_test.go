@@ -0,0 +1,250 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Cached responses are reused until they expire, and are invalidated by related mutating operations.
+func TestClient_ResponseCache_TTLAndInvalidation(test *testing.T) {
+	expect := expect(test)
+
+	var listCount, createCount int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		if strings.HasSuffix(request.URL.Path, "/tag/createTagKey") {
+			atomic.AddInt32(&createCount, 1)
+			fmt.Fprint(writer, responseCacheTestCreateTagKeyResponse)
+
+			return
+		}
+
+		atomic.AddInt32(&listCount, 1)
+		fmt.Fprint(writer, responseCacheTestListTagKeysResponse)
+	}))
+	defer testServer.Close()
+
+	cache := NewResponseCache(CacheRule{
+		Path:          "tag/tagKey",
+		TTL:           100 * time.Millisecond,
+		InvalidatedBy: []string{"createTagKey"},
+	})
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithResponseCache(cache),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	listTagKeys := func() {
+		tagKeys, err := client.ListTagKeys(DefaultPaging())
+		if err != nil {
+			test.Fatal(err)
+		}
+		expect.EqualsInt("TagKeys.Items", 1, len(tagKeys.Items))
+	}
+
+	listTagKeys()
+	listTagKeys()
+	expect.EqualsInt("List count (cached)", 1, int(atomic.LoadInt32(&listCount)))
+
+	_, err := client.CreateTagKey("tag2", "Tag 2", false, false)
+	if err != nil {
+		test.Fatal(err)
+	}
+	listTagKeys()
+	expect.EqualsInt("List count (invalidated)", 2, int(atomic.LoadInt32(&listCount)))
+
+	time.Sleep(150 * time.Millisecond)
+	listTagKeys()
+	expect.EqualsInt("List count (expired)", 3, int(atomic.LoadInt32(&listCount)))
+
+	cache.Invalidate("tag/tagKey")
+	listTagKeys()
+	expect.EqualsInt("List count (Invalidate)", 4, int(atomic.LoadInt32(&listCount)))
+	expect.EqualsInt("Create count", 1, int(atomic.LoadInt32(&createCount)))
+}
+
+// Identical concurrent requests for cacheable end-points are coalesced into a single request.
+func TestClient_ResponseCache_CoalescesConcurrentRequests(test *testing.T) {
+	expect := expect(test)
+
+	var listCount int32
+	release := make(chan struct{})
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&listCount, 1)
+		<-release
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprint(writer, responseCacheTestListTagKeysResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithResponseCache(NewResponseCache()),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	var waitGroup sync.WaitGroup
+	for index := 0; index < 5; index++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			tagKeys, err := client.ListTagKeys(DefaultPaging())
+			if err != nil {
+				test.Error(err)
+
+				return
+			}
+			if len(tagKeys.Items) != 1 {
+				test.Errorf("Expected 1 tag key but got %d", len(tagKeys.Items))
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	waitGroup.Wait()
+
+	expect.EqualsInt("List count", 1, int(atomic.LoadInt32(&listCount)))
+}
+
+// When the caller that started an in-flight request is cancelled, callers waiting for the same request perform it themselves.
+func TestClient_ResponseCache_CancelledInFlightRequest(test *testing.T) {
+	expect := expect(test)
+
+	var listCount int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if atomic.AddInt32(&listCount, 1) == 1 {
+			// Hold the first request until its caller gives up.
+			<-request.Context().Done()
+
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprint(writer, responseCacheTestListTagKeysResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithResponseCache(NewResponseCache()),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancellableClient := client.WithContext(ctx)
+
+	var (
+		waitGroup      sync.WaitGroup
+		cancelledError error
+	)
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+
+		_, cancelledError = cancellableClient.ListTagKeys(DefaultPaging())
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+
+		tagKeys, err := client.ListTagKeys(DefaultPaging())
+		if err != nil {
+			test.Error(err)
+
+			return
+		}
+		if len(tagKeys.Items) != 1 {
+			test.Errorf("Expected 1 tag key but got %d", len(tagKeys.Items))
+		}
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	waitGroup.Wait()
+
+	expect.IsTrue("First caller cancelled", IsOperationCancelledError(cancelledError))
+	expect.EqualsInt("List count", 2, int(atomic.LoadInt32(&listCount)))
+}
+
+// Responses from end-points without a cache rule are never cached.
+func TestClient_ResponseCache_Uncacheable(test *testing.T) {
+	expect := expect(test)
+
+	requestCount := 0
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			client.SetResponseCache(NewResponseCache())
+
+			for index := 0; index < 2; index++ {
+				_, err := client.GetVLAN("0e56433f-d808-4669-821d-812769517ff8")
+				if err != nil {
+					test.Fatal(err)
+				}
+			}
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			requestCount++
+
+			return http.StatusOK, getVLANTestResponse
+		},
+	})
+
+	expect.EqualsInt("Request count", 2, requestCount)
+}
+
+var responseCacheTestListTagKeysResponse = `
+{
+	"tagKey": [
+		{
+			"id": "9f4d8c46-7d0a-4f2f-9b2e-5d2f6c1e0a11",
+			"name": "tag1",
+			"description": "Tag 1",
+			"valueRequired": false,
+			"displayOnReport": true
+		}
+	],
+	"pageNumber": 1,
+	"pageCount": 1,
+	"totalCount": 1,
+	"pageSize": 250
+}
+`
+
+var responseCacheTestCreateTagKeyResponse = `
+{
+	"operation": "CREATE_TAG_KEY",
+	"responseCode": "OK",
+	"message": "Tag Key has been created.",
+	"info": [
+		{
+			"name": "tagKeyId",
+			"value": "5ab77f5f-5aa9-426f-8459-4eab34e03d54"
+		}
+	],
+	"requestId": "na9/2015-04-14T13:37:20/62f06368-c3fb-11e3-b29c-001517c4643e"
+}
+`
@@ -20,9 +20,29 @@ type APIResponse interface {
 }
 
 // APIError is an error representing an error response from an API.
+//
+// Use errors.As to examine it as a NotFoundError, ConflictError, DependencyError, QuotaError, AuthError or MaintenanceError.
 type APIError struct {
 	Message  string
 	Response APIResponse
+
+	// The HTTP status code of the response (0 if unknown).
+	StatusCode int
+
+	// The CloudControl request Id (correlation identifier), if available.
+	RequestID string
+
+	// The name of the CloudControl operation that returned the error, if available.
+	Operation string
+
+	// Error messages (if any) relating to request fields.
+	FieldErrors []FieldMessage
+
+	// Warning messages (if any) relating to request fields.
+	FieldWarnings []FieldMessage
+
+	// Informational messages (if any) relating to request fields (for v1 API responses, this is the response's additional information).
+	FieldMessages []FieldMessage
 }
 
 // Error returns the error message associated with the APIError.
@@ -52,21 +72,26 @@ func IsExceedsLimitError(err error) bool {
 	return IsAPIErrorCode(err, ResponseCodeExceedsLimit) || IsAPIErrorCode(err, ResultCodeExceedsLimit)
 }
 
+// IsVMwareToolsInvalidStatusError determines whether the specified error represents a VMWARE_TOOLS_INVALID_STATUS response from CloudControl.
+func IsVMwareToolsInvalidStatusError(err error) bool {
+	return IsAPIErrorCode(err, ResponseCodeVMwareToolsInvalidStatus)
+}
+
 // IsAPIErrorCode determines whether the specified error represents a CloudControl API error with the specified response code.
 func IsAPIErrorCode(err error, responseCode string) bool {
-	apiError, ok := errors.Cause(err).(*APIError)
-	if !ok {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
 		return false
 	}
 
-	return apiError.Response.GetResponseCode() == responseCode
+	return apiError.responseCode() == responseCode
 }
 
 // IsAPIError determines whether the specified error represents a CloudControl API error.
 func IsAPIError(err error, responseCode string) bool {
-	_, ok := errors.Cause(err).(*APIError)
+	var apiError *APIError
 
-	return ok
+	return errors.As(err, &apiError)
 }
 
 // Well-known API (v1) results
@@ -165,4 +190,7 @@ const (
 
 	// ResponseCodeUnexpectedError is a v2 API response code indicating that the CloudControl API encountered an unexpected error.
 	ResponseCodeUnexpectedError = "UNEXPECTED_ERROR"
+
+	// ResponseCodeVMwareToolsInvalidStatus is a v2 API response code indicating that an operation (e.g. a graceful shutdown) cannot be performed on a server because VMware Tools is not running on it.
+	ResponseCodeVMwareToolsInvalidStatus = "VMWARE_TOOLS_INVALID_STATUS"
 )
@@ -24,6 +24,9 @@ type APIResponseV1 struct {
 
 	// Additional information (if any).
 	AdditionalInformation []APIResponseAdditionalInformationV1 `xml:"additionalInformation"`
+
+	// The HTTP status code of the response (0 if unknown).
+	HTTPStatusCode int `xml:"-"`
 }
 
 // APIResponseAdditionalInformationV1 represents additional information in a V1 API response (in the form of a name / value pair).
@@ -69,8 +72,5 @@ func (response *APIResponseV1) GetAdditionalInformation(name string) *string {
 
 // ToError creates an error representing the API response.
 func (response *APIResponseV1) ToError(errorMessageOrFormat string, formatArgs ...interface{}) error {
-	return &APIError{
-		Message:  fmt.Sprintf(errorMessageOrFormat, formatArgs...),
-		Response: response,
-	}
+	return newAPIError(fmt.Sprintf(errorMessageOrFormat, formatArgs...), response)
 }
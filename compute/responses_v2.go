@@ -24,6 +24,9 @@ type APIResponseV2 struct {
 
 	// The request ID (correlation identifier).
 	RequestID string `json:"requestId"`
+
+	// The HTTP status code of the response (0 if unknown).
+	HTTPStatusCode int `json:"-"`
 }
 
 // GetMessage gets the message associated with the API response.
@@ -89,10 +92,7 @@ var _ APIResponse = &APIResponseV2{}
 
 // ToError creates an error representing the API response.
 func (response *APIResponseV2) ToError(errorMessageOrFormat string, formatArgs ...interface{}) error {
-	return &APIError{
-		Message:  fmt.Sprintf(errorMessageOrFormat, formatArgs...),
-		Response: response,
-	}
+	return newAPIError(fmt.Sprintf(errorMessageOrFormat, formatArgs...), response)
 }
 
 // FieldMessage represents a field name together with an associated message.
@@ -0,0 +1,171 @@
+package compute
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy determines whether (and when) a failed request to the compute API should be retried.
+type RetryPolicy interface {
+	// ShouldRetry determines whether the failed request should be retried.
+	//
+	// If the request should be retried, also returns the delay before the next attempt.
+	ShouldRetry(failure *RequestFailure) (delay time.Duration, shouldRetry bool)
+}
+
+// RequestFailure represents a failed attempt to perform a request to the compute API.
+type RequestFailure struct {
+	// The HTTP method of the failed request.
+	Method string
+
+	// The URL of the failed request.
+	URL string
+
+	// The number of attempts (including the failed attempt) that have been made to perform the request.
+	Attempt int
+
+	// The HTTP status code (0 if no response was received).
+	StatusCode int
+
+	// The error that caused the failure.
+	//
+	// If a response was received, this is an *APIError representing the response (where possible); use IsResourceBusyError, etc to examine it.
+	Err error
+}
+
+// IsIdempotent determines whether the failed request can safely be performed more than once.
+func (failure *RequestFailure) IsIdempotent() bool {
+	switch failure.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRejected determines whether the failure indicates that CloudControl rejected the request without acting on it (e.g. RESOURCE_BUSY or INFRASTRUCTURE_IN_MAINTENANCE).
+//
+// Requests that were rejected are safe to retry, even if they are not idempotent.
+func (failure *RequestFailure) IsRejected() bool {
+	return IsResourceBusyError(failure.Err) || IsAPIErrorCode(failure.Err, ResponseCodeInfrastructureInMaintenance)
+}
+
+// IsTransient determines whether the failure is likely to be temporary (i.e. the same request may succeed if retried).
+func (failure *RequestFailure) IsTransient() bool {
+	if failure.IsRejected() {
+		return true
+	}
+
+	switch failure.StatusCode {
+	case 0:
+		return failure.Err != nil // No response (e.g. connection reset).
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryError is the error returned when a request to the compute API has been retried, but still failed.
+type RetryError struct {
+	// The number of attempts that were made to perform the request.
+	Attempts int
+
+	// The error from the last attempt.
+	Err error
+}
+
+// Error returns the error message associated with the RetryError.
+func (err *RetryError) Error() string {
+	return fmt.Sprintf("%s (failed after %d attempts)", err.Err, err.Attempts)
+}
+
+// Cause retrieves the error from the last attempt (enables IsResourceBusyError, etc).
+func (err *RetryError) Cause() error {
+	return err.Err
+}
+
+// Unwrap retrieves the error from the last attempt.
+func (err *RetryError) Unwrap() error {
+	return err.Err
+}
+
+var _ error = &RetryError{}
+
+// ExponentialBackoffRetryPolicy is a RetryPolicy that retries transient failures with an exponentially-increasing delay between attempts.
+//
+// Requests that are not idempotent (e.g. POST) are only retried if CloudControl rejected them without acting on them (e.g. RESOURCE_BUSY), unless RetryNonIdempotent is true.
+type ExponentialBackoffRetryPolicy struct {
+	// The maximum number of attempts (including the initial attempt).
+	MaxAttempts int
+
+	// The delay before the first retry.
+	InitialDelay time.Duration
+
+	// The maximum delay between attempts (0 for no maximum).
+	MaxDelay time.Duration
+
+	// The factor by which the delay is increased after each retry.
+	Multiplier float64
+
+	// The fraction (between 0 and 1) of each delay that is randomised, to avoid synchronised retries from multiple clients.
+	Jitter float64
+
+	// Retry transient failures of requests that are not idempotent (only set this if the operations being performed are known to be safe to repeat).
+	RetryNonIdempotent bool
+}
+
+// NewExponentialBackoffRetryPolicy creates a new ExponentialBackoffRetryPolicy with default settings (delay doubles after each retry, up to 1 minute, with 20% jitter).
+//
+// maxRetryCount is the maximum number of retries (i.e. excluding the initial attempt).
+func NewExponentialBackoffRetryPolicy(maxRetryCount int, initialDelay time.Duration) *ExponentialBackoffRetryPolicy {
+	return &ExponentialBackoffRetryPolicy{
+		MaxAttempts:  maxRetryCount + 1,
+		InitialDelay: initialDelay,
+		MaxDelay:     1 * time.Minute,
+		Multiplier:   2.0,
+		Jitter:       0.2,
+	}
+}
+
+// ShouldRetry determines whether the failed request should be retried.
+func (policy *ExponentialBackoffRetryPolicy) ShouldRetry(failure *RequestFailure) (delay time.Duration, shouldRetry bool) {
+	if failure.Attempt >= policy.MaxAttempts {
+		return 0, false
+	}
+
+	if !failure.IsTransient() {
+		return 0, false
+	}
+
+	if !(failure.IsIdempotent() || failure.IsRejected() || policy.RetryNonIdempotent) {
+		return 0, false
+	}
+
+	return policy.delayBeforeRetry(failure.Attempt), true
+}
+
+// delayBeforeRetry calculates the delay before the retry that follows the specified attempt.
+func (policy *ExponentialBackoffRetryPolicy) delayBeforeRetry(attempt int) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier < 1.0 {
+		multiplier = 1.0
+	}
+
+	delay := float64(policy.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if policy.MaxDelay > 0 && delay > float64(policy.MaxDelay) {
+		delay = float64(policy.MaxDelay)
+	}
+
+	if policy.Jitter > 0 {
+		jitter := math.Min(policy.Jitter, 1.0)
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+var _ RetryPolicy = &ExponentialBackoffRetryPolicy{}
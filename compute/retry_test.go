@@ -0,0 +1,262 @@
+package compute
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Retry policy gives up once the maximum number of attempts has been made.
+func TestExponentialBackoffRetryPolicy_MaxAttempts(test *testing.T) {
+	expect := expect(test)
+
+	policy := NewExponentialBackoffRetryPolicy(2, 1*time.Second)
+	failure := &RequestFailure{
+		Method:     http.MethodGet,
+		StatusCode: http.StatusServiceUnavailable,
+		Err:        errors.New("service unavailable"),
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		failure.Attempt = attempt
+		_, shouldRetry := policy.ShouldRetry(failure)
+		expect.IsTrue("ShouldRetry (attempt < MaxAttempts)", shouldRetry)
+	}
+
+	failure.Attempt = 3
+	_, shouldRetry := policy.ShouldRetry(failure)
+	expect.IsFalse("ShouldRetry (attempt == MaxAttempts)", shouldRetry)
+}
+
+// Retry policy only retries a POST request if CloudControl rejected it as RESOURCE_BUSY.
+func TestExponentialBackoffRetryPolicy_NonIdempotent(test *testing.T) {
+	expect := expect(test)
+
+	policy := NewExponentialBackoffRetryPolicy(3, 1*time.Second)
+
+	_, shouldRetry := policy.ShouldRetry(&RequestFailure{
+		Method:     http.MethodPost,
+		Attempt:    1,
+		StatusCode: http.StatusBadRequest,
+		Err: &APIError{
+			Message:  "Resource busy",
+			Response: &APIResponseV2{ResponseCode: ResponseCodeResourceBusy},
+		},
+	})
+	expect.IsTrue("ShouldRetry (POST, RESOURCE_BUSY)", shouldRetry)
+
+	_, shouldRetry = policy.ShouldRetry(&RequestFailure{
+		Method:     http.MethodPost,
+		Attempt:    1,
+		StatusCode: http.StatusServiceUnavailable,
+		Err:        errors.New("service unavailable"),
+	})
+	expect.IsFalse("ShouldRetry (POST, 503)", shouldRetry)
+
+	_, shouldRetry = policy.ShouldRetry(&RequestFailure{
+		Method:  http.MethodPost,
+		Attempt: 1,
+		Err:     errors.New("connection reset"),
+	})
+	expect.IsFalse("ShouldRetry (POST, transport error)", shouldRetry)
+
+	policy.RetryNonIdempotent = true
+	_, shouldRetry = policy.ShouldRetry(&RequestFailure{
+		Method:     http.MethodPost,
+		Attempt:    1,
+		StatusCode: http.StatusServiceUnavailable,
+		Err:        errors.New("service unavailable"),
+	})
+	expect.IsTrue("ShouldRetry (POST, 503, RetryNonIdempotent)", shouldRetry)
+}
+
+// Retry policy retries REASON_392 (v1 equivalent of RESOURCE_BUSY), 502 and 503, but not other errors.
+func TestExponentialBackoffRetryPolicy_Classification(test *testing.T) {
+	expect := expect(test)
+
+	policy := NewExponentialBackoffRetryPolicy(3, 1*time.Second)
+
+	_, shouldRetry := policy.ShouldRetry(&RequestFailure{
+		Method:     http.MethodPost,
+		Attempt:    1,
+		StatusCode: http.StatusBadRequest,
+		Err: &APIError{
+			Message:  "Resource busy",
+			Response: &APIResponseV1{ResultCode: ResultCodeResourceBusy},
+		},
+	})
+	expect.IsTrue("ShouldRetry (POST, REASON_392)", shouldRetry)
+
+	_, shouldRetry = policy.ShouldRetry(&RequestFailure{
+		Method:     http.MethodGet,
+		Attempt:    1,
+		StatusCode: http.StatusBadGateway,
+		Err:        errors.New("bad gateway"),
+	})
+	expect.IsTrue("ShouldRetry (GET, 502)", shouldRetry)
+
+	_, shouldRetry = policy.ShouldRetry(&RequestFailure{
+		Method:     http.MethodGet,
+		Attempt:    1,
+		StatusCode: http.StatusServiceUnavailable,
+		Err:        errors.New("service unavailable"),
+	})
+	expect.IsTrue("ShouldRetry (GET, 503)", shouldRetry)
+
+	_, shouldRetry = policy.ShouldRetry(&RequestFailure{
+		Method:     http.MethodGet,
+		Attempt:    1,
+		StatusCode: http.StatusBadRequest,
+		Err: &APIError{
+			Message:  "Invalid input data",
+			Response: &APIResponseV2{ResponseCode: ResponseCodeInvalidInputData},
+		},
+	})
+	expect.IsFalse("ShouldRetry (GET, INVALID_INPUT_DATA)", shouldRetry)
+}
+
+// Retry delay increases exponentially, and jitter keeps it within the expected bounds.
+func TestExponentialBackoffRetryPolicy_Delay(test *testing.T) {
+	expect := expect(test)
+
+	policy := NewExponentialBackoffRetryPolicy(10, 1*time.Second)
+	policy.MaxDelay = 5 * time.Second
+
+	for iteration := 0; iteration < 100; iteration++ {
+		delay := policy.delayBeforeRetry(1)
+		expect.IsTrue("Delay (attempt 1) >= 800ms", delay >= 800*time.Millisecond)
+		expect.IsTrue("Delay (attempt 1) <= 1s", delay <= 1*time.Second)
+
+		delay = policy.delayBeforeRetry(2)
+		expect.IsTrue("Delay (attempt 2) >= 1.6s", delay >= 1600*time.Millisecond)
+		expect.IsTrue("Delay (attempt 2) <= 2s", delay <= 2*time.Second)
+
+		delay = policy.delayBeforeRetry(8)
+		expect.IsTrue("Delay (attempt 8) >= 4s", delay >= 4*time.Second)
+		expect.IsTrue("Delay (attempt 8) <= MaxDelay", delay <= policy.MaxDelay)
+	}
+}
+
+// Request is retried while CloudControl responds with RESOURCE_BUSY, then succeeds.
+func TestClient_Retry_ResourceBusy_Success(test *testing.T) {
+	expect := expect(test)
+
+	var requestCount int32
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			client.SetRetryPolicy(NewExponentialBackoffRetryPolicy(3, 1*time.Millisecond))
+
+			err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("RequestCount", 3, int(atomic.LoadInt32(&requestCount)))
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			if atomic.AddInt32(&requestCount, 1) < 3 {
+				return http.StatusBadRequest, retryTestResourceBusyResponse
+			}
+
+			return http.StatusOK, retryTestOKResponse
+		},
+	})
+}
+
+// Request is retried while CloudControl responds with RESOURCE_BUSY, then gives up.
+func TestClient_Retry_ResourceBusy_Exhausted(test *testing.T) {
+	expect := expect(test)
+
+	var requestCount int32
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			client.ConfigureRetry(2, 1*time.Millisecond)
+
+			err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+			expect.IsTrue("IsResourceBusyError", IsResourceBusyError(err))
+
+			retryError, ok := err.(*RetryError)
+			expect.IsTrue("Error is RetryError", ok)
+			expect.EqualsInt("RetryError.Attempts", 3, retryError.Attempts)
+			expect.EqualsInt("RequestCount", 3, int(atomic.LoadInt32(&requestCount)))
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			atomic.AddInt32(&requestCount, 1)
+
+			return http.StatusBadRequest, retryTestResourceBusyResponse
+		},
+	})
+}
+
+// Without a retry policy, requests are not retried.
+func TestClient_Retry_Disabled(test *testing.T) {
+	expect := expect(test)
+
+	var requestCount int32
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+			expect.IsTrue("IsResourceBusyError", IsResourceBusyError(err))
+
+			_, ok := err.(*RetryError)
+			expect.IsFalse("Error is RetryError", ok)
+			expect.EqualsInt("RequestCount", 1, int(atomic.LoadInt32(&requestCount)))
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			atomic.AddInt32(&requestCount, 1)
+
+			return http.StatusBadRequest, retryTestResourceBusyResponse
+		},
+	})
+}
+
+// Cancellation while waiting to retry a request.
+func TestClient_Retry_Cancelled(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client.SetRetryPolicy(NewExponentialBackoffRetryPolicy(3, 1*time.Minute))
+			client = client.WithContext(ctx)
+
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				cancel()
+			}()
+
+			startTime := time.Now()
+			err := client.DeleteVLAN("0e56433f-d808-4669-821d-812769517ff8")
+			expect.IsTrue("IsOperationCancelledError", IsOperationCancelledError(err))
+			expect.IsTrue("Cancelled before retry delay elapsed", time.Since(startTime) < 30*time.Second)
+		},
+		Respond: testRespond(http.StatusBadRequest, retryTestResourceBusyResponse),
+	})
+}
+
+var retryTestResourceBusyResponse = `
+{
+	"operation": "DELETE_VLAN",
+	"responseCode": "RESOURCE_BUSY",
+	"message": "The VLAN is busy.",
+	"requestId": "na9/2015-04-14T13:37:20/62f06368-c3fb-11e3-b29c-001517c4643e"
+}
+`
+
+var retryTestOKResponse = `
+{
+	"operation": "DELETE_VLAN",
+	"responseCode": "IN_PROGRESS",
+	"message": "Request to Delete VLAN has been accepted and is being processed.",
+	"requestId": "na9/2015-04-14T13:37:20/62f06368-c3fb-11e3-b29c-001517c4643e"
+}
+`
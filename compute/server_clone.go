@@ -23,12 +23,15 @@ func (client *Client) CloneServer(serverID string, imageName string, imageDescri
 	requestURI := fmt.Sprintf("%s/server/cloneServer",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodPost, &cloneServer{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &cloneServer{
 		ServerID:             serverID,
 		ImageName:            imageName,
 		ImageDescription:     imageDescription,
 		GuestOsCustomization: !preventGuestOSCustomisation,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
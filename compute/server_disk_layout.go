@@ -0,0 +1,396 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DiskLayoutChangeType represents a type of change made to a server's disk layout.
+type DiskLayoutChangeType int
+
+const (
+	// DiskLayoutChangeAddSCSIController adds a SCSI controller to the server.
+	DiskLayoutChangeAddSCSIController DiskLayoutChangeType = iota
+
+	// DiskLayoutChangeAddDisk adds a disk to one of the server's SCSI controllers.
+	DiskLayoutChangeAddDisk
+
+	// DiskLayoutChangeExpandDisk increases the size of an existing disk.
+	DiskLayoutChangeExpandDisk
+
+	// DiskLayoutChangeSpeed changes the speed of an existing disk.
+	DiskLayoutChangeSpeed
+
+	// DiskLayoutChangeIops changes the IOPS of an existing disk (whose speed is ServerDiskSpeedProvisionedIops).
+	DiskLayoutChangeIops
+
+	// DiskLayoutChangeRemoveDisk removes an existing disk from the server.
+	DiskLayoutChangeRemoveDisk
+)
+
+// String creates a string representation of the DiskLayoutChangeType.
+func (changeType DiskLayoutChangeType) String() string {
+	switch changeType {
+	case DiskLayoutChangeAddSCSIController:
+		return "Add SCSI controller"
+	case DiskLayoutChangeAddDisk:
+		return "Add disk"
+	case DiskLayoutChangeExpandDisk:
+		return "Expand disk"
+	case DiskLayoutChangeSpeed:
+		return "Change disk speed"
+	case DiskLayoutChangeIops:
+		return "Change disk IOPS"
+	case DiskLayoutChangeRemoveDisk:
+		return "Remove disk"
+	default:
+		return fmt.Sprintf("Unknown disk layout change (%d)", changeType)
+	}
+}
+
+// DiskLayoutChange represents a single change to a server's disk layout.
+type DiskLayoutChange struct {
+	// The type of change.
+	Type DiskLayoutChangeType
+
+	// The SCSI bus number of the target controller.
+	BusNumber int
+
+	// The SCSI unit Id of the target disk (ignored for DiskLayoutChangeAddSCSIController).
+	UnitID int
+
+	// The Id of the target controller (empty if the controller does not exist yet).
+	ControllerID string
+
+	// The Id of the target disk (empty if the disk does not exist yet).
+	DiskID string
+
+	// The adapter type of the controller to add (DiskLayoutChangeAddSCSIController only).
+	AdapterType string
+
+	// The disk's size (in GB), before and after the change.
+	PreviousSizeGB int
+	SizeGB         int
+
+	// The disk's speed, before and after the change.
+	PreviousSpeed string
+	Speed         string
+
+	// The disk's IOPS (ServerDiskSpeedProvisionedIops only), before and after the change.
+	PreviousIops int
+	Iops         int
+}
+
+// String creates a human-readable description of the change.
+func (change DiskLayoutChange) String() string {
+	switch change.Type {
+	case DiskLayoutChangeAddSCSIController:
+		return fmt.Sprintf("%s (%s) on bus %d", change.Type, change.AdapterType, change.BusNumber)
+	case DiskLayoutChangeAddDisk:
+		return fmt.Sprintf("%s at SCSI %d:%d (%d GB, %s)", change.Type, change.BusNumber, change.UnitID, change.SizeGB, change.Speed)
+	case DiskLayoutChangeExpandDisk:
+		return fmt.Sprintf("%s at SCSI %d:%d from %d GB to %d GB", change.Type, change.BusNumber, change.UnitID, change.PreviousSizeGB, change.SizeGB)
+	case DiskLayoutChangeSpeed:
+		return fmt.Sprintf("%s at SCSI %d:%d from %s to %s", change.Type, change.BusNumber, change.UnitID, change.PreviousSpeed, change.Speed)
+	case DiskLayoutChangeIops:
+		return fmt.Sprintf("%s at SCSI %d:%d from %d to %d", change.Type, change.BusNumber, change.UnitID, change.PreviousIops, change.Iops)
+	default:
+		return fmt.Sprintf("%s at SCSI %d:%d", change.Type, change.BusNumber, change.UnitID)
+	}
+}
+
+// DiskLayoutPlan is the ordered list of changes required to make a server's disk layout match a desired layout (see PlanServerDiskLayout).
+type DiskLayoutPlan struct {
+	// The Id of the target server.
+	ServerID string
+
+	// The changes to make (in order).
+	Changes []DiskLayoutChange
+}
+
+// IsEmpty determines whether the plan contains no changes (i.e. the server's disk layout already matches the desired layout).
+func (plan *DiskLayoutPlan) IsEmpty() bool {
+	return len(plan.Changes) == 0
+}
+
+// String creates a human-readable representation of the plan.
+func (plan *DiskLayoutPlan) String() string {
+	if plan.IsEmpty() {
+		return fmt.Sprintf("No disk layout changes for server '%s'.\n", plan.ServerID)
+	}
+
+	var builder strings.Builder
+	for index, change := range plan.Changes {
+		fmt.Fprintf(&builder, "%d. %s\n", index+1, change)
+	}
+
+	return builder.String()
+}
+
+// PlanServerDiskLayout determines the changes required to make a server's disk layout match the desired layout.
+//
+// Disks are matched by SCSI bus number and unit Id; a disk in the desired layout with an empty Speed, or zero SizeGB or Iops, keeps its current value.
+// Disks that are not part of the desired layout are removed (but SCSI controllers are never removed).
+// A new SCSI controller with no AdapterType uses StorageControllerAdapterTypeLSILogicParallel.
+//
+// Returns an error if the desired layout requires an unsupported change (e.g. shrinking a disk, or changing the adapter type of an existing controller).
+//
+// Changes are ordered so that new controllers are added first and disks are removed last: add SCSI controllers, add disks, expand disks, change disk speed, change disk IOPS, remove disks.
+func PlanServerDiskLayout(server *Server, desiredLayout VirtualMachineSCSIControllers) (*DiskLayoutPlan, error) {
+	plan := &DiskLayoutPlan{
+		ServerID: server.ID,
+	}
+
+	var (
+		addControllers []DiskLayoutChange
+		addDisks       []DiskLayoutChange
+		expandDisks    []DiskLayoutChange
+		changeSpeeds   []DiskLayoutChange
+		changeIops     []DiskLayoutChange
+		removeDisks    []DiskLayoutChange
+	)
+
+	seenBusNumbers := make(map[int]bool)
+	for _, desiredController := range desiredLayout {
+		if seenBusNumbers[desiredController.BusNumber] {
+			return nil, fmt.Errorf("desired disk layout for server '%s' contains more than one SCSI controller on bus %d", server.ID, desiredController.BusNumber)
+		}
+		seenBusNumbers[desiredController.BusNumber] = true
+
+		controllerID := ""
+		currentController := server.SCSIControllers.GetByBusNumber(desiredController.BusNumber)
+		if currentController == nil {
+			adapterType := desiredController.AdapterType
+			if adapterType == "" {
+				adapterType = StorageControllerAdapterTypeLSILogicParallel
+			}
+
+			addControllers = append(addControllers, DiskLayoutChange{
+				Type:        DiskLayoutChangeAddSCSIController,
+				BusNumber:   desiredController.BusNumber,
+				AdapterType: adapterType,
+			})
+		} else {
+			if desiredController.AdapterType != "" && desiredController.AdapterType != currentController.AdapterType {
+				return nil, fmt.Errorf("cannot change the adapter type of the SCSI controller on bus %d of server '%s' from %s to %s",
+					desiredController.BusNumber, server.ID, currentController.AdapterType, desiredController.AdapterType,
+				)
+			}
+
+			controllerID = currentController.ID
+		}
+
+		seenUnitIDs := make(map[int]bool)
+		for _, desiredDisk := range desiredController.Disks {
+			if seenUnitIDs[desiredDisk.SCSIUnitID] {
+				return nil, fmt.Errorf("desired disk layout for server '%s' contains more than one disk at SCSI %d:%d", server.ID, desiredController.BusNumber, desiredDisk.SCSIUnitID)
+			}
+			seenUnitIDs[desiredDisk.SCSIUnitID] = true
+
+			change := DiskLayoutChange{
+				BusNumber:    desiredController.BusNumber,
+				UnitID:       desiredDisk.SCSIUnitID,
+				ControllerID: controllerID,
+			}
+
+			currentDisk := server.SCSIControllers.GetDiskBySCSIPath(desiredController.BusNumber, desiredDisk.SCSIUnitID)
+			if currentDisk == nil {
+				if desiredDisk.SizeGB <= 0 {
+					return nil, fmt.Errorf("cannot add disk at SCSI %d:%d of server '%s' (size must be specified)", desiredController.BusNumber, desiredDisk.SCSIUnitID, server.ID)
+				}
+
+				change.Type = DiskLayoutChangeAddDisk
+				change.SizeGB = desiredDisk.SizeGB
+				change.Speed = desiredDisk.Speed
+				if change.Speed == "" {
+					change.Speed = ServerDiskSpeedStandard
+				}
+				change.Iops = desiredDisk.Iops
+				addDisks = append(addDisks, change)
+
+				continue
+			}
+
+			change.DiskID = currentDisk.ID
+			change.PreviousSizeGB = currentDisk.SizeGB
+			change.PreviousSpeed = currentDisk.Speed
+			change.PreviousIops = currentDisk.Iops
+
+			if desiredDisk.SizeGB != 0 && desiredDisk.SizeGB != currentDisk.SizeGB {
+				if desiredDisk.SizeGB < currentDisk.SizeGB {
+					return nil, fmt.Errorf("cannot shrink disk at SCSI %d:%d of server '%s' from %d GB to %d GB",
+						desiredController.BusNumber, desiredDisk.SCSIUnitID, server.ID, currentDisk.SizeGB, desiredDisk.SizeGB,
+					)
+				}
+
+				expandDisk := change
+				expandDisk.Type = DiskLayoutChangeExpandDisk
+				expandDisk.SizeGB = desiredDisk.SizeGB
+				expandDisks = append(expandDisks, expandDisk)
+			}
+
+			if desiredDisk.Speed != "" && desiredDisk.Speed != currentDisk.Speed {
+				changeSpeed := change
+				changeSpeed.Type = DiskLayoutChangeSpeed
+				changeSpeed.Speed = desiredDisk.Speed
+				changeSpeed.Iops = desiredDisk.Iops
+				changeSpeeds = append(changeSpeeds, changeSpeed)
+			} else if desiredDisk.Iops != 0 && desiredDisk.Iops != currentDisk.Iops {
+				if currentDisk.Speed != ServerDiskSpeedProvisionedIops {
+					return nil, fmt.Errorf("cannot change IOPS of disk at SCSI %d:%d of server '%s' (speed is %s, not %s)",
+						desiredController.BusNumber, desiredDisk.SCSIUnitID, server.ID, currentDisk.Speed, ServerDiskSpeedProvisionedIops,
+					)
+				}
+
+				changeDiskIops := change
+				changeDiskIops.Type = DiskLayoutChangeIops
+				changeDiskIops.Iops = desiredDisk.Iops
+				changeIops = append(changeIops, changeDiskIops)
+			}
+		}
+	}
+
+	for _, currentController := range server.SCSIControllers {
+		desiredController := desiredLayout.GetByBusNumber(currentController.BusNumber)
+		for _, currentDisk := range currentController.Disks {
+			if desiredController.GetDiskByUnitID(currentDisk.SCSIUnitID) != nil {
+				continue
+			}
+
+			removeDisks = append(removeDisks, DiskLayoutChange{
+				Type:           DiskLayoutChangeRemoveDisk,
+				BusNumber:      currentController.BusNumber,
+				UnitID:         currentDisk.SCSIUnitID,
+				ControllerID:   currentController.ID,
+				DiskID:         currentDisk.ID,
+				PreviousSizeGB: currentDisk.SizeGB,
+				PreviousSpeed:  currentDisk.Speed,
+				PreviousIops:   currentDisk.Iops,
+			})
+		}
+	}
+
+	for _, changes := range [][]DiskLayoutChange{addControllers, addDisks, expandDisks, changeSpeeds, changeIops, removeDisks} {
+		sort.SliceStable(changes, func(index1 int, index2 int) bool {
+			if changes[index1].BusNumber != changes[index2].BusNumber {
+				return changes[index1].BusNumber < changes[index2].BusNumber
+			}
+
+			return changes[index1].UnitID < changes[index2].UnitID
+		})
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	return plan, nil
+}
+
+// ApplyServerDiskLayout makes the changes in the specified plan (in order), waiting for each change to complete before making the next one.
+//
+// stepTimeout is the maximum time to wait for each change to complete.
+// If a change fails, no further changes are made (and the error identifies the failed change).
+func (client *Client) ApplyServerDiskLayout(plan *DiskLayoutPlan, stepTimeout time.Duration) error {
+	// Ids of controllers added while applying the plan (keyed by bus number).
+	addedControllerIDs := make(map[int]string)
+
+	for index, change := range plan.Changes {
+		client.logInfo("Applying disk layout change...",
+			logField(LogFieldResourceID, plan.ServerID),
+			logField(LogFieldChange, change.String()),
+		)
+
+		if change.Type == DiskLayoutChangeAddDisk && change.ControllerID == "" {
+			change.ControllerID = addedControllerIDs[change.BusNumber]
+		}
+
+		resource, err := client.applyDiskLayoutChange(plan.ServerID, change, stepTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to apply disk layout change %d of %d (%s) to server '%s': %s", index+1, len(plan.Changes), change, plan.ServerID, err)
+		}
+
+		if change.Type == DiskLayoutChangeAddSCSIController {
+			server, ok := resource.(*Server)
+			if ok {
+				addedController := server.SCSIControllers.GetByBusNumber(change.BusNumber)
+				if addedController != nil {
+					addedControllerIDs[change.BusNumber] = addedController.ID
+				}
+			}
+			if addedControllerIDs[change.BusNumber] == "" {
+				return fmt.Errorf("failed to apply disk layout change %d of %d (%s) to server '%s': cannot find the new SCSI controller", index+1, len(plan.Changes), change, plan.ServerID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyDiskLayoutChange makes a single disk layout change, and waits for it to complete.
+//
+// Returns the server, as it was once the change was complete.
+func (client *Client) applyDiskLayoutChange(serverID string, change DiskLayoutChange, timeout time.Duration) (resource Resource, err error) {
+	// Most of these requests only identify the SCSI controller or disk, not the server.
+	client = client.WithLockKeys(LockKey{ResourceType: ResourceTypeServer, ID: serverID})
+
+	switch change.Type {
+	case DiskLayoutChangeAddSCSIController:
+		err = client.AddSCSIControllerToServer(serverID, change.AdapterType, change.BusNumber)
+
+	case DiskLayoutChangeAddDisk:
+		_, err = client.AddDiskToSCSIController(change.ControllerID, change.UnitID, change.SizeGB, change.Speed, change.Iops)
+
+	case DiskLayoutChangeExpandDisk:
+		var apiResponse *APIResponseV2
+		apiResponse, err = client.ExpandDisk(change.DiskID, change.SizeGB)
+		if err == nil && apiResponse.ResponseCode != ResponseCodeInProgress {
+			err = apiResponse.ToError("Request to expand disk '%s' failed with status code %d (%s): %s", change.DiskID, apiResponse.HTTPStatusCode, apiResponse.ResponseCode, apiResponse.Message)
+		}
+
+	case DiskLayoutChangeSpeed:
+		var iops *int
+		if change.Speed == ServerDiskSpeedProvisionedIops && change.Iops != 0 {
+			iops = &change.Iops
+		}
+		_, err = client.ChangeServerDiskSpeed(serverID, change.DiskID, change.Speed, iops)
+
+	case DiskLayoutChangeIops:
+		_, err = client.ChangeServerDiskIops(change.DiskID, change.Iops)
+
+	case DiskLayoutChangeRemoveDisk:
+		err = client.RemoveDisk(change.DiskID)
+
+	default:
+		err = fmt.Errorf("unsupported disk layout change type (%d)", change.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return client.WaitForChange(ResourceTypeServer, serverID, change.Type.String(), timeout)
+}
+
+// ReconcileServerDiskLayout makes a server's disk layout match the desired layout (see PlanServerDiskLayout and ApplyServerDiskLayout).
+//
+// Returns the plan that was applied.
+func (client *Client) ReconcileServerDiskLayout(serverID string, desiredLayout VirtualMachineSCSIControllers, stepTimeout time.Duration) (*DiskLayoutPlan, error) {
+	server, err := client.GetServer(serverID)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("no server was found with Id '%s'", serverID)
+	}
+
+	plan, err := PlanServerDiskLayout(server, desiredLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.ApplyServerDiskLayout(plan, stepTimeout)
+	if err != nil {
+		return plan, err
+	}
+
+	return plan, nil
+}
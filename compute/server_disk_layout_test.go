@@ -0,0 +1,137 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Planning a server's disk layout produces ordered changes, and refuses unsupported changes.
+func TestPlanServerDiskLayout(test *testing.T) {
+	expect := expect(test)
+
+	server := &Server{
+		ID: "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+		SCSIControllers: VirtualMachineSCSIControllers{
+			{
+				ID:          "controller-0",
+				BusNumber:   0,
+				AdapterType: StorageControllerAdapterTypeLSILogicParallel,
+				Disks: VirtualMachineDisks{
+					{ID: "disk-0", SCSIUnitID: 0, SizeGB: 10, Speed: ServerDiskSpeedStandard},
+					{ID: "disk-1", SCSIUnitID: 1, SizeGB: 20, Speed: ServerDiskSpeedStandard},
+					{ID: "disk-2", SCSIUnitID: 2, SizeGB: 30, Speed: ServerDiskSpeedStandard},
+				},
+			},
+		},
+	}
+
+	plan, err := PlanServerDiskLayout(server, VirtualMachineSCSIControllers{
+		{
+			BusNumber: 1,
+			Disks: VirtualMachineDisks{
+				{SCSIUnitID: 0, SizeGB: 100, Speed: ServerDiskSpeedEconomy},
+			},
+		},
+		{
+			BusNumber: 0,
+			Disks: VirtualMachineDisks{
+				{SCSIUnitID: 0, SizeGB: 15},
+				{SCSIUnitID: 1, Speed: ServerDiskSpeedHighPerformance},
+				{SCSIUnitID: 3, SizeGB: 50},
+			},
+		},
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expectedChanges := []string{
+		"Add SCSI controller (LSI_LOGIC_PARALLEL) on bus 1",
+		"Add disk at SCSI 0:3 (50 GB, STANDARD)",
+		"Add disk at SCSI 1:0 (100 GB, ECONOMY)",
+		"Expand disk at SCSI 0:0 from 10 GB to 15 GB",
+		"Change disk speed at SCSI 0:1 from STANDARD to HIGHPERFORMANCE",
+		"Remove disk at SCSI 0:2",
+	}
+	expect.EqualsInt("Plan.Changes size", len(expectedChanges), len(plan.Changes))
+	for index, expectedChange := range expectedChanges {
+		expect.EqualsString(fmt.Sprintf("Plan.Changes[%d]", index), expectedChange, plan.Changes[index].String())
+	}
+	expect.EqualsString("Plan.Changes[1].ControllerID", "controller-0", plan.Changes[1].ControllerID)
+	expect.EqualsString("Plan.Changes[2].ControllerID", "", plan.Changes[2].ControllerID)
+	expect.EqualsString("Plan.Changes[5].DiskID", "disk-2", plan.Changes[5].DiskID)
+
+	_, err = PlanServerDiskLayout(server, VirtualMachineSCSIControllers{
+		{
+			BusNumber: 0,
+			Disks: VirtualMachineDisks{
+				{SCSIUnitID: 0, SizeGB: 5},
+			},
+		},
+	})
+	expect.IsTrue("Shrink disk error", err != nil && strings.Contains(err.Error(), "cannot shrink disk at SCSI 0:0"))
+
+	_, err = PlanServerDiskLayout(server, VirtualMachineSCSIControllers{
+		{BusNumber: 0, AdapterType: StorageControllerAdapterTypeLSILogicSAS},
+	})
+	expect.IsTrue("Change adapter type error", err != nil && strings.Contains(err.Error(), "cannot change the adapter type"))
+
+	plan, err = PlanServerDiskLayout(server, server.SCSIControllers)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsTrue("Plan.IsEmpty (unchanged)", plan.IsEmpty())
+}
+
+// Applying a disk layout plan makes each change in turn, waiting for it to complete, and adds disks to newly-added controllers.
+func TestClient_ApplyServerDiskLayout(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	controllerAdded := false
+	testServer, client := newServerOperationsTestServer(test, func() string {
+		controllers := `{"id": "controller-0", "busNumber": 0, "adapterType": "LSI_LOGIC_PARALLEL", "disk": []}`
+		if controllerAdded {
+			controllers += `, {"id": "controller-1", "busNumber": 1, "adapterType": "LSI_LOGIC_SAS", "disk": []}`
+		}
+
+		return fmt.Sprintf(`{"id": "%s", "name": "server1", "state": "NORMAL", "scsiController": [%s]}`, serverID, controllers)
+	}, func(operation string, requestBody []byte) string {
+		switch operation {
+		case "addScsiController":
+			controllerAdded = true
+		case "addDisk":
+			var addDisk addDiskToSCSIController
+			err := json.Unmarshal(requestBody, &addDisk)
+			if err != nil {
+				test.Error(err)
+			}
+			expect.EqualsString("AddDisk.SCSIController.ControllerID", "controller-1", addDisk.SCSIController.ControllerID)
+			expect.EqualsInt("AddDisk.SizeGB", 100, addDisk.SizeGB)
+		}
+
+		return ResponseCodeInProgress
+	})
+	defer testServer.Close()
+
+	plan, err := client.ReconcileServerDiskLayout(serverID, VirtualMachineSCSIControllers{
+		{BusNumber: 0},
+		{
+			BusNumber:   1,
+			AdapterType: StorageControllerAdapterTypeLSILogicSAS,
+			Disks: VirtualMachineDisks{
+				{SCSIUnitID: 0, SizeGB: 100},
+			},
+		},
+	}, 10*time.Second)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsInt("Plan.Changes size", 2, len(plan.Changes))
+
+	expect.EqualsString("Operations", "addScsiController,addDisk", testServer.Operations())
+}
@@ -0,0 +1,363 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NetworkAdapterChangeType represents a type of change made to a server's network adapters.
+type NetworkAdapterChangeType int
+
+const (
+	// NetworkAdapterChangeAdd adds a network adapter to the server.
+	NetworkAdapterChangeAdd NetworkAdapterChangeType = iota
+
+	// NetworkAdapterChangeAdapterType changes the type of an existing network adapter.
+	NetworkAdapterChangeAdapterType
+
+	// NetworkAdapterChangeIPAddress notifies CloudControl that the IP address(es) of an existing network adapter have changed.
+	NetworkAdapterChangeIPAddress
+
+	// NetworkAdapterChangeRemove removes an existing (non-primary) network adapter from the server.
+	NetworkAdapterChangeRemove
+)
+
+// String creates a string representation of the NetworkAdapterChangeType.
+func (changeType NetworkAdapterChangeType) String() string {
+	switch changeType {
+	case NetworkAdapterChangeAdd:
+		return "Add network adapter"
+	case NetworkAdapterChangeAdapterType:
+		return "Change network adapter type"
+	case NetworkAdapterChangeIPAddress:
+		return "Change network adapter IP address"
+	case NetworkAdapterChangeRemove:
+		return "Remove network adapter"
+	default:
+		return fmt.Sprintf("Unknown network adapter change (%d)", changeType)
+	}
+}
+
+// NetworkAdapterChange represents a single change to a server's network adapters.
+type NetworkAdapterChange struct {
+	// The type of change.
+	Type NetworkAdapterChangeType
+
+	// The Id of the target network adapter (empty if the adapter does not exist yet).
+	AdapterID string
+
+	// The target network adapter's key (0 if the adapter does not exist yet, or the key is unknown).
+	AdapterKey int
+
+	// The Id of the VLAN that the target network adapter is (or will be) connected to.
+	VLANID string
+
+	// Is the target network adapter the server's primary network adapter?
+	IsPrimary bool
+
+	// The network adapter's type, before and after the change.
+	PreviousAdapterType string
+	AdapterType         string
+
+	// The network adapter's private IPv4 address, before and after the change.
+	PreviousIPv4Address string
+	IPv4Address         string
+
+	// The network adapter's IPv6 address, before and after the change.
+	PreviousIPv6Address string
+	IPv6Address         string
+}
+
+// String creates a human-readable description of the change.
+func (change NetworkAdapterChange) String() string {
+	switch change.Type {
+	case NetworkAdapterChangeAdd:
+		var details []string
+		if change.AdapterType != "" {
+			details = append(details, change.AdapterType)
+		}
+		if change.IPv4Address != "" {
+			details = append(details, change.IPv4Address)
+		}
+		if len(details) == 0 {
+			return fmt.Sprintf("%s on VLAN '%s'", change.Type, change.VLANID)
+		}
+
+		return fmt.Sprintf("%s on VLAN '%s' (%s)", change.Type, change.VLANID, strings.Join(details, ", "))
+	case NetworkAdapterChangeAdapterType:
+		return fmt.Sprintf("%s of '%s' from %s to %s", change.Type, change.AdapterID, change.PreviousAdapterType, change.AdapterType)
+	case NetworkAdapterChangeIPAddress:
+		if change.IPv6Address != change.PreviousIPv6Address && change.IPv4Address == change.PreviousIPv4Address {
+			return fmt.Sprintf("%s of '%s' from %s to %s", change.Type, change.AdapterID, change.PreviousIPv6Address, change.IPv6Address)
+		}
+
+		return fmt.Sprintf("%s of '%s' from %s to %s", change.Type, change.AdapterID, change.PreviousIPv4Address, change.IPv4Address)
+	default:
+		return fmt.Sprintf("%s '%s' (VLAN '%s')", change.Type, change.AdapterID, change.VLANID)
+	}
+}
+
+// NetworkAdapterPlan is the ordered list of changes required to make a server's network adapters match the desired network adapters (see PlanServerNetworkAdapters).
+type NetworkAdapterPlan struct {
+	// The Id of the target server.
+	ServerID string
+
+	// The changes to make (in order).
+	Changes []NetworkAdapterChange
+}
+
+// IsEmpty determines whether the plan contains no changes (i.e. the server's network adapters already match the desired network adapters).
+func (plan *NetworkAdapterPlan) IsEmpty() bool {
+	return len(plan.Changes) == 0
+}
+
+// String creates a human-readable representation of the plan.
+func (plan *NetworkAdapterPlan) String() string {
+	if plan.IsEmpty() {
+		return fmt.Sprintf("No network adapter changes for server '%s'.\n", plan.ServerID)
+	}
+
+	var builder strings.Builder
+	for index, change := range plan.Changes {
+		fmt.Fprintf(&builder, "%d. %s\n", index+1, change)
+	}
+
+	return builder.String()
+}
+
+// PlanServerNetworkAdapters determines the changes required to make a server's network adapters match the desired network adapters.
+//
+// Each desired adapter is matched to one of the server's existing adapters by AdapterKey (if specified; CloudControl v2.4 and higher), or otherwise by VLANID (in order, if the server has more than one adapter on the same VLAN).
+// A desired adapter with a nil AdapterType, PrivateIPv4Address, or PrivateIPv6Address keeps its current value.
+// Desired adapters that do not match an existing adapter are added (and must specify a VLANID); existing adapters that do not match a desired adapter are removed.
+//
+// Returns an error if the desired network adapters require an unsupported change (e.g. removing the primary adapter, or moving an existing adapter to a different VLAN).
+//
+// Changes are ordered so that new adapters are added first and adapters are removed last: add adapters, change adapter types, change IP addresses, remove adapters.
+func PlanServerNetworkAdapters(server *Server, desiredAdapters []VirtualMachineNetworkAdapter) (*NetworkAdapterPlan, error) {
+	plan := &NetworkAdapterPlan{
+		ServerID: server.ID,
+	}
+
+	currentAdapters := server.Network.GetAdapters()
+	primaryAdapter := &server.Network.PrimaryAdapter
+
+	// The existing adapter (if any) matched by each desired adapter.
+	matchedAdapters := make([]*VirtualMachineNetworkAdapter, len(desiredAdapters))
+	claimedAdapters := make(map[*VirtualMachineNetworkAdapter]bool)
+
+	// Match by key first, so that adapters matched by VLAN cannot claim an adapter that was explicitly identified.
+	for index, desiredAdapter := range desiredAdapters {
+		if desiredAdapter.AdapterKey == nil {
+			continue
+		}
+
+		currentAdapter := server.Network.GetAdapterByKey(*desiredAdapter.AdapterKey)
+		if currentAdapter == nil {
+			return nil, fmt.Errorf("server '%s' has no network adapter with key %d", server.ID, *desiredAdapter.AdapterKey)
+		}
+		if claimedAdapters[currentAdapter] {
+			return nil, fmt.Errorf("desired network adapters for server '%s' contain more than one adapter with key %d", server.ID, *desiredAdapter.AdapterKey)
+		}
+		if desiredAdapter.VLANID != nil && *desiredAdapter.VLANID != ptrToString(currentAdapter.VLANID) {
+			return nil, fmt.Errorf("cannot move network adapter '%s' of server '%s' from VLAN '%s' to VLAN '%s'",
+				currentAdapter.GetID(), server.ID, ptrToString(currentAdapter.VLANID), *desiredAdapter.VLANID,
+			)
+		}
+
+		matchedAdapters[index] = currentAdapter
+		claimedAdapters[currentAdapter] = true
+	}
+
+	for index, desiredAdapter := range desiredAdapters {
+		if desiredAdapter.AdapterKey != nil {
+			continue
+		}
+		if desiredAdapter.VLANID == nil || *desiredAdapter.VLANID == "" {
+			return nil, fmt.Errorf("desired network adapter %d for server '%s' must specify either an adapter key or a VLAN Id", index+1, server.ID)
+		}
+
+		for _, currentAdapter := range server.Network.GetAdaptersByVLANID(*desiredAdapter.VLANID) {
+			if !claimedAdapters[currentAdapter] {
+				matchedAdapters[index] = currentAdapter
+				claimedAdapters[currentAdapter] = true
+
+				break
+			}
+		}
+	}
+
+	var (
+		addAdapters       []NetworkAdapterChange
+		changeTypes       []NetworkAdapterChange
+		changeIPAddresses []NetworkAdapterChange
+		removeAdapters    []NetworkAdapterChange
+	)
+
+	for index, desiredAdapter := range desiredAdapters {
+		currentAdapter := matchedAdapters[index]
+		if currentAdapter == nil {
+			addAdapters = append(addAdapters, NetworkAdapterChange{
+				Type:        NetworkAdapterChangeAdd,
+				VLANID:      *desiredAdapter.VLANID,
+				AdapterType: ptrToString(desiredAdapter.AdapterType),
+				IPv4Address: ptrToString(desiredAdapter.PrivateIPv4Address),
+			})
+
+			continue
+		}
+
+		change := newNetworkAdapterChange(currentAdapter, currentAdapter == primaryAdapter)
+
+		if desiredAdapter.AdapterType != nil && *desiredAdapter.AdapterType != change.PreviousAdapterType {
+			changeType := change
+			changeType.Type = NetworkAdapterChangeAdapterType
+			changeType.AdapterType = *desiredAdapter.AdapterType
+			changeTypes = append(changeTypes, changeType)
+		}
+
+		changeIPAddress := change
+		changeIPAddress.Type = NetworkAdapterChangeIPAddress
+		if desiredAdapter.PrivateIPv4Address != nil {
+			changeIPAddress.IPv4Address = *desiredAdapter.PrivateIPv4Address
+		}
+		if desiredAdapter.PrivateIPv6Address != nil {
+			changeIPAddress.IPv6Address = *desiredAdapter.PrivateIPv6Address
+		}
+		if changeIPAddress.IPv4Address != change.PreviousIPv4Address || changeIPAddress.IPv6Address != change.PreviousIPv6Address {
+			changeIPAddresses = append(changeIPAddresses, changeIPAddress)
+		}
+	}
+
+	for _, currentAdapter := range currentAdapters {
+		if claimedAdapters[currentAdapter] {
+			continue
+		}
+		if currentAdapter == primaryAdapter {
+			return nil, fmt.Errorf("cannot remove the primary network adapter ('%s') of server '%s'", currentAdapter.GetID(), server.ID)
+		}
+
+		removeAdapter := newNetworkAdapterChange(currentAdapter, false)
+		removeAdapter.Type = NetworkAdapterChangeRemove
+		removeAdapters = append(removeAdapters, removeAdapter)
+	}
+
+	for _, changes := range [][]NetworkAdapterChange{addAdapters, changeTypes, changeIPAddresses, removeAdapters} {
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	return plan, nil
+}
+
+// newNetworkAdapterChange creates a NetworkAdapterChange describing an existing network adapter (whose current values are unchanged).
+func newNetworkAdapterChange(adapter *VirtualMachineNetworkAdapter, isPrimary bool) NetworkAdapterChange {
+	change := NetworkAdapterChange{
+		AdapterID:           adapter.GetID(),
+		VLANID:              ptrToString(adapter.VLANID),
+		IsPrimary:           isPrimary,
+		PreviousAdapterType: ptrToString(adapter.AdapterType),
+		PreviousIPv4Address: ptrToString(adapter.PrivateIPv4Address),
+		PreviousIPv6Address: ptrToString(adapter.PrivateIPv6Address),
+	}
+	if adapter.AdapterKey != nil {
+		change.AdapterKey = *adapter.AdapterKey
+	}
+	change.AdapterType = change.PreviousAdapterType
+	change.IPv4Address = change.PreviousIPv4Address
+	change.IPv6Address = change.PreviousIPv6Address
+
+	return change
+}
+
+// ApplyServerNetworkAdapters makes the changes in the specified plan (in order), waiting for each change to complete before making the next one.
+//
+// stepTimeout is the maximum time to wait for each change to complete.
+// If a change fails, no further changes are made (and the error identifies the failed change).
+func (client *Client) ApplyServerNetworkAdapters(plan *NetworkAdapterPlan, stepTimeout time.Duration) error {
+	for index, change := range plan.Changes {
+		client.logInfo("Applying network adapter change...",
+			logField(LogFieldResourceID, plan.ServerID),
+			logField(LogFieldChange, change.String()),
+		)
+
+		err := client.applyNetworkAdapterChange(plan.ServerID, change, stepTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to apply network adapter change %d of %d (%s) to server '%s': %s", index+1, len(plan.Changes), change, plan.ServerID, err)
+		}
+	}
+
+	return nil
+}
+
+// applyNetworkAdapterChange makes a single network adapter change, and waits for it to complete.
+func (client *Client) applyNetworkAdapterChange(serverID string, change NetworkAdapterChange, timeout time.Duration) (err error) {
+	// Most of these requests only identify the network adapter, not the server.
+	client = client.WithLockKeys(LockKey{ResourceType: ResourceTypeServer, ID: serverID})
+
+	switch change.Type {
+	case NetworkAdapterChangeAdd:
+		// The API only accepts one of VLAN Id or IPv4 address (the VLAN is inferred from the IPv4 address).
+		vlanID := change.VLANID
+		if change.IPv4Address != "" {
+			vlanID = ""
+		}
+
+		if change.AdapterType != "" {
+			_, err = client.AddNicWithTypeToServer(serverID, change.IPv4Address, vlanID, change.AdapterType)
+		} else {
+			_, err = client.AddNicToServer(serverID, change.IPv4Address, vlanID)
+		}
+
+	case NetworkAdapterChangeAdapterType:
+		err = client.ChangeNetworkAdapterType(change.AdapterID, change.AdapterType)
+
+	case NetworkAdapterChangeIPAddress:
+		var ipv4Address, ipv6Address *string
+		if change.IPv4Address != change.PreviousIPv4Address {
+			ipv4Address = &change.IPv4Address
+		}
+		if change.IPv6Address != change.PreviousIPv6Address {
+			ipv6Address = &change.IPv6Address
+		}
+		err = client.NotifyServerIPAddressChange(change.AdapterID, ipv4Address, ipv6Address)
+
+	case NetworkAdapterChangeRemove:
+		err = client.RemoveNicFromServer(change.AdapterID)
+
+	default:
+		err = fmt.Errorf("unsupported network adapter change type (%d)", change.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = client.WaitForChange(ResourceTypeServer, serverID, change.Type.String(), timeout)
+
+	return err
+}
+
+// ReconcileServerNetworkAdapters makes a server's network adapters match the desired network adapters (see PlanServerNetworkAdapters and ApplyServerNetworkAdapters).
+//
+// Returns the plan that was applied.
+func (client *Client) ReconcileServerNetworkAdapters(serverID string, desiredAdapters []VirtualMachineNetworkAdapter, stepTimeout time.Duration) (*NetworkAdapterPlan, error) {
+	server, err := client.GetServer(serverID)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("no server was found with Id '%s'", serverID)
+	}
+
+	plan, err := PlanServerNetworkAdapters(server, desiredAdapters)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.ApplyServerNetworkAdapters(plan, stepTimeout)
+	if err != nil {
+		return plan, err
+	}
+
+	return plan, nil
+}
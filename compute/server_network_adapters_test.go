@@ -0,0 +1,126 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Planning a server's network adapters produces ordered changes, and refuses unsupported changes.
+func TestPlanServerNetworkAdapters(test *testing.T) {
+	expect := expect(test)
+
+	server := &Server{
+		ID: "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+		Network: VirtualMachineNetwork{
+			PrimaryAdapter: VirtualMachineNetworkAdapter{
+				ID:                 stringToPtr("nic-0"),
+				VLANID:             stringToPtr("vlan-1"),
+				PrivateIPv4Address: stringToPtr("10.0.1.4"),
+				AdapterType:        stringToPtr(NetworkAdapterTypeE1000),
+				AdapterKey:         intToPtr(4000),
+			},
+			AdditionalNetworkAdapters: []VirtualMachineNetworkAdapter{
+				{
+					ID:                 stringToPtr("nic-1"),
+					VLANID:             stringToPtr("vlan-2"),
+					PrivateIPv4Address: stringToPtr("10.0.2.4"),
+					AdapterType:        stringToPtr(NetworkAdapterTypeVMXNET3),
+					AdapterKey:         intToPtr(4001),
+				},
+				{
+					ID:                 stringToPtr("nic-2"),
+					VLANID:             stringToPtr("vlan-2"),
+					PrivateIPv4Address: stringToPtr("10.0.2.5"),
+					AdapterType:        stringToPtr(NetworkAdapterTypeVMXNET3),
+					AdapterKey:         intToPtr(4002),
+				},
+			},
+		},
+	}
+
+	plan, err := PlanServerNetworkAdapters(server, []VirtualMachineNetworkAdapter{
+		{VLANID: stringToPtr("vlan-3"), AdapterType: stringToPtr(NetworkAdapterTypeVMXNET3)},
+		{AdapterKey: intToPtr(4000), AdapterType: stringToPtr(NetworkAdapterTypeVMXNET3)},
+		{VLANID: stringToPtr("vlan-2"), PrivateIPv4Address: stringToPtr("10.0.2.10")},
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expectedChanges := []string{
+		"Add network adapter on VLAN 'vlan-3' (VMXNET3)",
+		"Change network adapter type of 'nic-0' from E1000 to VMXNET3",
+		"Change network adapter IP address of 'nic-1' from 10.0.2.4 to 10.0.2.10",
+		"Remove network adapter 'nic-2' (VLAN 'vlan-2')",
+	}
+	expect.EqualsInt("Plan.Changes size", len(expectedChanges), len(plan.Changes))
+	for index, expectedChange := range expectedChanges {
+		expect.EqualsString(fmt.Sprintf("Plan.Changes[%d]", index), expectedChange, plan.Changes[index].String())
+	}
+	expect.IsTrue("Plan.Changes[1].IsPrimary", plan.Changes[1].IsPrimary)
+	expect.EqualsInt("Plan.Changes[3].AdapterKey", 4002, plan.Changes[3].AdapterKey)
+
+	_, err = PlanServerNetworkAdapters(server, []VirtualMachineNetworkAdapter{
+		{VLANID: stringToPtr("vlan-2")},
+		{VLANID: stringToPtr("vlan-2")},
+	})
+	expect.IsTrue("Remove primary adapter error", err != nil && strings.Contains(err.Error(), "cannot remove the primary network adapter"))
+
+	_, err = PlanServerNetworkAdapters(server, []VirtualMachineNetworkAdapter{
+		{AdapterKey: intToPtr(4000), VLANID: stringToPtr("vlan-3")},
+	})
+	expect.IsTrue("Move adapter error", err != nil && strings.Contains(err.Error(), "cannot move network adapter 'nic-0'"))
+
+	plan, err = PlanServerNetworkAdapters(server, []VirtualMachineNetworkAdapter{
+		{VLANID: stringToPtr("vlan-1")},
+		{VLANID: stringToPtr("vlan-2")},
+		{AdapterKey: intToPtr(4002), PrivateIPv4Address: stringToPtr("10.0.2.5")},
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsTrue("Plan.IsEmpty (unchanged)", plan.IsEmpty())
+}
+
+// Applying a network adapter plan makes each change in turn, waiting for it to complete.
+func TestClient_ApplyServerNetworkAdapters(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	testServer, client := newServerOperationsTestServer(test, func() string {
+		return fmt.Sprintf(`{
+			"id": "%s", "name": "server1", "state": "NORMAL",
+			"networkInfo": {
+				"primaryNic": {"id": "nic-0", "vlanId": "vlan-1", "privateIpv4": "10.0.1.4", "networkAdapter": "E1000", "key": 4000},
+				"additionalNic": [{"id": "nic-1", "vlanId": "vlan-2", "privateIpv4": "10.0.2.4", "networkAdapter": "E1000", "key": 4001}]
+			}
+		}`, serverID)
+	}, func(operation string, requestBody []byte) string {
+		if operation == "addNic" {
+			var addNic addNicConfiguration
+			err := json.Unmarshal(requestBody, &addNic)
+			if err != nil {
+				test.Error(err)
+			}
+			expect.EqualsString("AddNic.Nic.VlanID", "vlan-3", addNic.Nic.VlanID)
+		}
+
+		return ResponseCodeInProgress
+	})
+	defer testServer.Close()
+
+	plan, err := client.ReconcileServerNetworkAdapters(serverID, []VirtualMachineNetworkAdapter{
+		{VLANID: stringToPtr("vlan-1"), AdapterType: stringToPtr(NetworkAdapterTypeVMXNET3)},
+		{VLANID: stringToPtr("vlan-3")},
+	}, 10*time.Second)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.EqualsInt("Plan.Changes size", 3, len(plan.Changes))
+
+	expect.EqualsString("Operations", "addNic,changeNetworkAdapter,removeNic", testServer.Operations())
+}
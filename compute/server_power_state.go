@@ -0,0 +1,149 @@
+package compute
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ServerPowerState represents the desired power state of a server (see Client.EnsureServerPowerState).
+type ServerPowerState string
+
+const (
+	// ServerPowerStateStarted indicates that a server is (or should be) started.
+	ServerPowerStateStarted ServerPowerState = "STARTED"
+
+	// ServerPowerStateStopped indicates that a server is (or should be) stopped.
+	ServerPowerStateStopped ServerPowerState = "STOPPED"
+)
+
+// DefaultShutdownGracePeriod is the default length of time that EnsureServerPowerState waits for a server to shut down gracefully before powering it off.
+const DefaultShutdownGracePeriod = 5 * time.Minute
+
+// ServerPowerStateTimeout is the length of time that EnsureServerPowerState waits for a server to start (or power off) before giving up.
+const ServerPowerStateTimeout = 10 * time.Minute
+
+// SetShutdownGracePeriod configures how long EnsureServerPowerState waits for a server to shut down gracefully before powering it off.
+// Set gracePeriod to 0 to use DefaultShutdownGracePeriod.
+func (client *Client) SetShutdownGracePeriod(gracePeriod time.Duration) {
+	atomic.StoreInt64(&client.shutdownGracePeriod, int64(gracePeriod))
+}
+
+// getShutdownGracePeriod retrieves the length of time to wait for a server to shut down gracefully before powering it off.
+func (client *Client) getShutdownGracePeriod() time.Duration {
+	gracePeriod := time.Duration(atomic.LoadInt64(&client.shutdownGracePeriod))
+	if gracePeriod <= 0 {
+		return DefaultShutdownGracePeriod
+	}
+
+	return gracePeriod
+}
+
+// EnsureServerPowerState starts or stops a server (if required), and waits for the change to complete.
+//
+// To stop a server, it is first shut down gracefully; if it has not stopped by the end of the client's shutdown grace period (see SetShutdownGracePeriod), it is powered off.
+// Since CloudControl will not power off a server while the shutdown is still in progress, this may mean waiting (up to ServerPowerStateTimeout) for the shutdown to complete or fail.
+// Returns an error (without powering the server off) if the server is busy, or if it cannot be shut down gracefully because VMware Tools is not running (use PowerOffServer instead).
+//
+// Returns the server, as it was once it reached the desired power state.
+func (client *Client) EnsureServerPowerState(serverID string, desired ServerPowerState) (*Server, error) {
+	if desired != ServerPowerStateStarted && desired != ServerPowerStateStopped {
+		return nil, fmt.Errorf("unsupported server power state '%s'", desired)
+	}
+
+	server, err := client.GetServer(serverID)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("no server was found with Id '%s'", serverID)
+	}
+	if server.State != ResourceStatusNormal {
+		return nil, fmt.Errorf("cannot change the power state of server '%s' because it is busy (state is '%s')", serverID, server.State)
+	}
+
+	if desired == ServerPowerStateStarted {
+		if server.Started {
+			return server, nil
+		}
+
+		err = client.StartServer(serverID)
+		if err != nil {
+			return nil, newServerPowerStateError(serverID, "start", err)
+		}
+
+		return client.waitForServerPowerState(serverID, "Start server", ServerPowerStateTimeout)
+	}
+
+	if !server.Started {
+		return server, nil
+	}
+
+	err = client.ShutdownServer(serverID)
+	if err != nil {
+		if IsVMwareToolsInvalidStatusError(err) {
+			return nil, errors.Wrapf(err, "cannot shut down server '%s' gracefully because VMware Tools is not running (use PowerOffServer to power it off)", serverID)
+		}
+
+		return nil, newServerPowerStateError(serverID, "shut down", err)
+	}
+
+	gracePeriod := client.getShutdownGracePeriod()
+	server, err = client.waitForServerPowerState(serverID, "Shut down server", gracePeriod)
+	if err == nil && !server.Started {
+		return server, nil
+	}
+	if err != nil && !IsWaitTimeoutError(err) {
+		return nil, err
+	}
+
+	client.logWarn("Server did not shut down gracefully within the grace period; powering off.",
+		logField(LogFieldResourceID, serverID),
+		logField(LogFieldDuration, gracePeriod),
+	)
+
+	err = client.PowerOffServer(serverID)
+	if IsResourceBusyError(err) {
+		// The shutdown is still in progress; wait for it to complete (or fail) before trying again.
+		server, err = client.waitForServerPowerState(serverID, "Shut down server", ServerPowerStateTimeout)
+		if err != nil {
+			return nil, err
+		}
+		if !server.Started {
+			return server, nil
+		}
+
+		err = client.PowerOffServer(serverID)
+	}
+	if err != nil {
+		return nil, newServerPowerStateError(serverID, "power off", err)
+	}
+
+	return client.waitForServerPowerState(serverID, "Power off server", ServerPowerStateTimeout)
+}
+
+// waitForServerPowerState waits for a server's pending power (or other server-level) operation to complete.
+func (client *Client) waitForServerPowerState(serverID string, actionDescription string, timeout time.Duration) (*Server, error) {
+	resource, err := client.WaitForChange(ResourceTypeServer, serverID, actionDescription, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	server, ok := resource.(*Server)
+	if !ok || server == nil {
+		return nil, fmt.Errorf("no server was found with Id '%s'", serverID)
+	}
+
+	return server, nil
+}
+
+// newServerPowerStateError creates an error indicating that a server power operation could not be started.
+func newServerPowerStateError(serverID string, action string, err error) error {
+	if IsResourceBusyError(err) {
+		return errors.Wrapf(err, "cannot %s server '%s' because it is busy", action, serverID)
+	}
+
+	return errors.Wrapf(err, "failed to %s server '%s'", action, serverID)
+}
@@ -0,0 +1,79 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Stopping a server shuts it down gracefully, and powers it off if it has not stopped by the end of the grace period (once CloudControl has finished processing the shutdown).
+func TestClient_EnsureServerPowerState_PowerOffAfterGracePeriod(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	var shutdownStartTime time.Time
+	state := ResourceStatusNormal
+	started := true
+	updateState := func() {
+		// Guest OS never shuts down; CloudControl eventually gives up on the shutdown.
+		if state == ResourceStatusPendingChange && time.Since(shutdownStartTime) > 150*time.Millisecond {
+			state = ResourceStatusNormal
+		}
+	}
+	testServer, client := newServerOperationsTestServer(test, func() string {
+		updateState()
+
+		return fmt.Sprintf(`{"id": "%s", "name": "server1", "state": "%s", "started": %t}`, serverID, state, started)
+	}, func(operation string, requestBody []byte) string {
+		updateState()
+		if state != ResourceStatusNormal {
+			return ResponseCodeResourceBusy
+		}
+
+		switch operation {
+		case "shutdownServer":
+			state = ResourceStatusPendingChange
+			shutdownStartTime = time.Now()
+		case "powerOffServer":
+			started = false
+		}
+
+		return ResponseCodeInProgress
+	}, WithShutdownGracePeriod(50*time.Millisecond))
+	defer testServer.Close()
+
+	server, err := client.EnsureServerPowerState(serverID, ServerPowerStateStopped)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsFalse("Server.Started", server.Started)
+
+	server, err = client.EnsureServerPowerState(serverID, ServerPowerStateStopped)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsFalse("Server.Started (already stopped)", server.Started)
+
+	expect.EqualsString("Operations", "shutdownServer,powerOffServer,powerOffServer", testServer.Operations())
+}
+
+// Stopping a server fails (without powering it off) if VMware Tools is not running.
+func TestClient_EnsureServerPowerState_VMwareToolsNotRunning(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	testServer, client := newServerOperationsTestServer(test, func() string {
+		return fmt.Sprintf(`{"id": "%s", "name": "server1", "state": "NORMAL", "started": true}`, serverID)
+	}, func(operation string, requestBody []byte) string {
+		return ResponseCodeVMwareToolsInvalidStatus
+	})
+	defer testServer.Close()
+
+	_, err := client.EnsureServerPowerState(serverID, ServerPowerStateStopped)
+	expect.IsTrue("IsVMwareToolsInvalidStatusError", IsVMwareToolsInvalidStatusError(err))
+	expect.IsTrue("Error message", err != nil && strings.Contains(err.Error(), "VMware Tools is not running"))
+	expect.EqualsString("Operations", "shutdownServer", testServer.Operations())
+}
@@ -56,11 +56,14 @@ func (client *Client) AddSCSIControllerToServer(serverID string, adapterType str
 	requestURI := fmt.Sprintf("%s/server/addScsiController",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV210(requestURI, http.MethodPost, &addSCSIControllerToServer{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &addSCSIControllerToServer{
 		ServerID:    serverID,
 		AdapterType: adapterType,
 		BusNumber:   busNumber,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -88,9 +91,12 @@ func (client *Client) RemoveSCSIControllerFromServer(controllerID string) error
 	requestURI := fmt.Sprintf("%s/server/removeScsiController",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodPost, &removeSCSIControllerFromServer{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &removeSCSIControllerFromServer{
 		ControllerID: controllerID,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -134,8 +140,11 @@ func (client *Client) AddDiskToSCSIController(controllerID string, scsiUnitID in
 		newDisk.Iops = iops
 	}
 
-	request, err := client.newRequestV210(requestURI, http.MethodPost, newDisk)
-	//request, err := client.newRequestV210(requestURI, http.MethodPost, &addDiskToSCSIController{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, newDisk)
+	if err != nil {
+		return "", err
+	}
+	//request, err := client.newRequestV2(requestURI, http.MethodPost, &addDiskToSCSIController{
 	//	SCSIController: scsiController{
 	//		ControllerID: controllerID,
 	//		SCSIUnitID:   scsiUnitID,
@@ -175,10 +184,13 @@ func (client *Client) ExpandDisk(diskID string, newSizeGB int) (response *APIRes
 	requestURI := fmt.Sprintf("%s/server/expandDisk",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodPost, &expandDisk{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &expandDisk{
 		DiskID:    diskID,
 		NewSizeGB: newSizeGB,
 	})
+	if err != nil {
+		return
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return
@@ -199,9 +211,12 @@ func (client *Client) RemoveDisk(diskID string) error {
 	requestURI := fmt.Sprintf("%s/server/removeDisk",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodPost, &removeDisk{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &removeDisk{
 		DiskID: diskID,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -0,0 +1,61 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// UpgradeServerVirtualHardwareWithRestart upgrades the virtual hardware of a server to the latest version, stopping the server first (if required) and then starting it again.
+//
+// The server is stopped using EnsureServerPowerState (graceful shutdown, falling back to power-off after the client's shutdown grace period).
+// If the server was not running to begin with, it is left stopped.
+// If the server's virtual hardware is already up-to-date, no changes are made.
+//
+// upgradeTimeout is the maximum time to wait for the upgrade itself to complete.
+// Returns the server, as it was once the sequence was complete.
+func (client *Client) UpgradeServerVirtualHardwareWithRestart(serverID string, upgradeTimeout time.Duration) (*Server, error) {
+	server, err := client.GetServer(serverID)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("no server was found with Id '%s'", serverID)
+	}
+	if server.VirtualHardware != nil && server.VirtualHardware.UpToDate {
+		return server, nil
+	}
+
+	wasStarted := server.Started
+	if wasStarted {
+		client.logInfo("Stopping server to upgrade its virtual hardware...", logField(LogFieldResourceID, serverID))
+
+		_, err = client.EnsureServerPowerState(serverID, ServerPowerStateStopped)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to stop server '%s' before upgrading its virtual hardware", serverID)
+		}
+	}
+
+	err = client.UpgradeServerVirtualHardware(serverID)
+	if err != nil {
+		return nil, err
+	}
+	server, err = client.waitForServerPowerState(serverID, "Upgrade virtual hardware", upgradeTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if !wasStarted {
+		return server, nil
+	}
+
+	client.logInfo("Starting server after upgrading its virtual hardware...", logField(LogFieldResourceID, serverID))
+
+	server, err = client.EnsureServerPowerState(serverID, ServerPowerStateStarted)
+	if err != nil {
+		return nil, errors.Wrapf(err, "upgraded virtual hardware of server '%s', but failed to start it again", serverID)
+	}
+
+	return server, nil
+}
@@ -0,0 +1,50 @@
+package compute
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Upgrading a running server's virtual hardware stops the server, upgrades it, and then starts it again.
+func TestClient_UpgradeServerVirtualHardwareWithRestart(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	started := true
+	upToDate := false
+	testServer, client := newServerOperationsTestServer(test, func() string {
+		return fmt.Sprintf(`{"id": "%s", "name": "server1", "state": "NORMAL", "started": %t, "virtualHardware": {"version": "vmx-08", "upToDate": %t}}`,
+			serverID, started, upToDate,
+		)
+	}, func(operation string, requestBody []byte) string {
+		switch operation {
+		case "shutdownServer":
+			started = false
+		case "upgradeVirtualHardware":
+			expect.IsFalse("Server.Started (upgrade)", started)
+			upToDate = true
+		case "startServer":
+			started = true
+		}
+
+		return ResponseCodeInProgress
+	})
+	defer testServer.Close()
+
+	server, err := client.UpgradeServerVirtualHardwareWithRestart(serverID, 10*time.Second)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.IsTrue("Server.Started", server.Started)
+	expect.IsTrue("Server.VirtualHardware.UpToDate", server.VirtualHardware.UpToDate)
+
+	// Already up-to-date; no further changes.
+	_, err = client.UpgradeServerVirtualHardwareWithRestart(serverID, 10*time.Second)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	expect.EqualsString("Operations", "shutdownServer,upgradeVirtualHardware,startServer", testServer.Operations())
+}
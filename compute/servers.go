@@ -10,19 +10,22 @@ import (
 
 // Server represents a virtual machine.
 type Server struct {
-	ID              string                        `json:"id"`
-	Name            string                        `json:"name"`
-	Description     string                        `json:"description"`
-	OperatingSystem OperatingSystem               `json:"operatingSystem"`
-	CPU             VirtualMachineCPU             `json:"cpu"`
-	MemoryGB        int                           `json:"memoryGb"`
-	SCSIControllers VirtualMachineSCSIControllers `json:"scsiController"`
-	Network         VirtualMachineNetwork         `json:"networkInfo"`
-	Backup          *ServerBackup                 `json:"backup,omitempty"`
-	SourceImageID   string                        `json:"sourceImageId"`
-	State           string                        `json:"state"`
-	Deployed        bool                          `json:"deployed"`
-	Started         bool                          `json:"started"`
+	ID              string                         `json:"id"`
+	Name            string                         `json:"name"`
+	Description     string                         `json:"description"`
+	OperatingSystem OperatingSystem                `json:"operatingSystem"`
+	CPU             VirtualMachineCPU              `json:"cpu"`
+	MemoryGB        int                            `json:"memoryGb"`
+	SCSIControllers VirtualMachineSCSIControllers  `json:"scsiController"`
+	Network         VirtualMachineNetwork          `json:"networkInfo"`
+	Backup          *ServerBackup                  `json:"backup,omitempty"`
+	SourceImageID   string                         `json:"sourceImageId"`
+	State           string                         `json:"state"`
+	Deployed        bool                           `json:"deployed"`
+	Started         bool                           `json:"started"`
+	VMwareTools     *VirtualMachineVMwareTools     `json:"vmwareTools,omitempty"`
+	VirtualHardware *VirtualMachineVirtualHardware `json:"virtualHardware,omitempty"`
+	SnapshotService *ServerSnapshotService         `json:"snapshotService,omitempty"`
 }
 
 // GetID returns the server's Id.
@@ -69,6 +72,86 @@ type Servers struct {
 	PagedResult
 }
 
+// serverV24 represents the parts of a server whose schema differs in v2.4 (and earlier) of the compute API.
+type serverV24 struct {
+	// Disks are reported directly under the server (rather than under its SCSI controllers).
+	Disks VirtualMachineDisks `json:"disk"`
+}
+
+// applyAPIVersion adjusts a server decoded from a response returned by the specified CloudControl 2.x minor version, so that it has the same shape regardless of the API version used.
+func (server *Server) applyAPIVersion(minorVersion int, legacyServer serverV24) {
+	if minorVersion == 0 {
+		return
+	}
+
+	if minorVersion <= 4 && len(server.SCSIControllers) == 0 && len(legacyServer.Disks) > 0 {
+		server.SCSIControllers = VirtualMachineSCSIControllers{
+			VirtualMachineSCSIController{
+				BusNumber: 0,
+				Disks:     legacyServer.Disks,
+			},
+		}
+	}
+
+	// Network adapter MAC addresses and keys are only supported by CloudControl v2.4 and higher.
+	if minorVersion < 4 {
+		server.Network.PrimaryAdapter.MACAddress = nil
+		server.Network.PrimaryAdapter.AdapterKey = nil
+		for index := range server.Network.AdditionalNetworkAdapters {
+			server.Network.AdditionalNetworkAdapters[index].MACAddress = nil
+			server.Network.AdditionalNetworkAdapters[index].AdapterKey = nil
+		}
+	}
+}
+
+// readServerJSON decodes a server from a response body returned by the specified CloudControl 2.x minor version (0 if unknown).
+func readServerJSON(responseBody []byte, minorVersion int) (*Server, error) {
+	server := &Server{}
+	err := json.Unmarshal(responseBody, server)
+	if err != nil {
+		return nil, err
+	}
+
+	var legacyServer serverV24
+	if minorVersion != 0 && minorVersion <= 4 {
+		err = json.Unmarshal(responseBody, &legacyServer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	server.applyAPIVersion(minorVersion, legacyServer)
+
+	return server, nil
+}
+
+// readServersJSON decodes a page of servers from a response body returned by the specified CloudControl 2.x minor version (0 if unknown).
+func readServersJSON(responseBody []byte, minorVersion int) (Servers, error) {
+	servers := Servers{}
+	err := json.Unmarshal(responseBody, &servers)
+	if err != nil {
+		return servers, err
+	}
+
+	var legacyServers struct {
+		Items []serverV24 `json:"server"`
+	}
+	if minorVersion != 0 && minorVersion <= 4 {
+		err = json.Unmarshal(responseBody, &legacyServers)
+		if err != nil {
+			return servers, err
+		}
+	}
+	for index := range servers.Items {
+		var legacyServer serverV24
+		if index < len(legacyServers.Items) {
+			legacyServer = legacyServers.Items[index]
+		}
+		servers.Items[index].applyAPIVersion(minorVersion, legacyServer)
+	}
+
+	return servers, nil
+}
+
 // ServerSummary respresents summary information for a server.
 type ServerSummary struct {
 	ID          string `json:"id"`
@@ -215,7 +298,7 @@ type startServer struct {
 	ID string `json:"id"`
 }
 
-// Request body when stopping or powering off a server.
+// Request body when stopping, powering off, rebooting or resetting a server (or updating its VMware Tools or virtual hardware).
 type stopServer struct {
 	// The server Id.
 	ID string `json:"id"`
@@ -251,7 +334,7 @@ func (client *Client) GetServer(id string) (server *Server, err error) {
 		url.QueryEscape(id),
 	)
 
-	request, err := client.newRequestV210(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -275,17 +358,22 @@ func (client *Client) GetServer(id string) (server *Server, err error) {
 		return nil, apiResponse.ToError("Request to retrieve Server failed with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	server = &Server{}
-	err = json.Unmarshal(responseBody, server)
-
-	return server, err
+	return readServerJSON(responseBody, getAPIMinorVersion(request.URL.Path))
 }
 
 // ListServersInNetworkDomain retrieves a page of servers in the specified network domain.
 func (client *Client) ListServersInNetworkDomain(networkDomainID string, paging *Paging) (servers Servers, err error) {
+	return client.ListServersInNetworkDomainWithFilter(networkDomainID, nil, paging)
+}
+
+// ListServersInNetworkDomainWithFilter retrieves a page of the servers in the specified network domain that match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results.
+func (client *Client) ListServersInNetworkDomainWithFilter(networkDomainID string, filter *Filter, paging *Paging) (servers Servers, err error) {
 	if paging == nil {
 		paging = &Paging{
 			PageNumber: 1,
+			PageSize:   client.defaultPageSize,
 		}
 	}
 	paging.ensureValidPageSize()
@@ -296,15 +384,22 @@ func (client *Client) ListServersInNetworkDomain(networkDomainID string, paging
 		return
 	}
 
-	requestURI := fmt.Sprintf("%s/server/server?networkDomainId=%s&pageNumber=%d&pageSize=%d",
+	var filterParameters string
+	filterParameters, err = filter.toQueryParameters(FilterFieldNetworkDomainID)
+	if err != nil {
+		return
+	}
+
+	requestURI := fmt.Sprintf("%s/server/server?networkDomainId=%s&pageNumber=%d&pageSize=%d%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
 		paging.PageNumber,
 		paging.PageSize,
+		filterParameters,
 	)
 
 	var request *http.Request
-	request, err = client.newRequestV25(requestURI, http.MethodGet, nil)
+	request, err = client.newRequestV2ForOperation("server/server#list", requestURI, http.MethodGet, nil)
 	if err != nil {
 		return
 	}
@@ -330,78 +425,124 @@ func (client *Client) ListServersInNetworkDomain(networkDomainID string, paging
 		return
 	}
 
-	servers = Servers{}
-	err = json.Unmarshal(responseBody, &servers)
+	servers, err = readServersJSON(responseBody, getAPIMinorVersion(request.URL.Path))
+
+	return
+}
+
+// AllServersInNetworkDomain retrieves all servers in the specified network domain (across all pages of results).
+func (client *Client) AllServersInNetworkDomain(networkDomainID string) (servers []Server, err error) {
+	return client.AllServersInNetworkDomainWithFilter(networkDomainID, nil)
+}
+
+// AllServersInNetworkDomainWithFilter retrieves all servers in the specified network domain that match the specified filter criteria (across all pages of results).
+func (client *Client) AllServersInNetworkDomainWithFilter(networkDomainID string, filter *Filter) (servers []Server, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		page, err := client.ListServersInNetworkDomainWithFilter(networkDomainID, filter, paging)
+		if err != nil {
+			return nil, err
+		}
+
+		return &page, nil
+	}, func(page PagedResults) {
+		servers = append(servers, page.(*Servers).Items...)
+	})
 
 	return
 }
 
 // DeployServer deploys a new virtual machine.
 func (client *Client) DeployServer(serverConfiguration ServerDeploymentConfiguration) (serverID string, err error) {
-	organizationID, err := client.getOrganizationID()
+	operation, err := client.DeployServerAsync(serverConfiguration)
 	if err != nil {
 		return "", err
 	}
 
+	return operation.ResourceID, nil
+}
+
+// DeployServerAsync is equivalent to DeployServer, but returns an Operation that can be used to wait for the deploy operation to complete.
+func (client *Client) DeployServerAsync(serverConfiguration ServerDeploymentConfiguration) (operation *Operation, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
 	requestURI := fmt.Sprintf("%s/server/deployServer",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV23(requestURI, http.MethodPost, &serverConfiguration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &serverConfiguration)
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeInProgress {
-		return "", apiResponse.ToError("Request to deploy server '%s' failed with status code %d (%s): %s", serverConfiguration.Name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to deploy server '%s' failed with status code %d (%s): %s", serverConfiguration.Name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
 	// Expected: "info" { "name": "serverId", "value": "the-Id-of-the-new-server" }
 	serverIDMessage := apiResponse.GetFieldMessage("serverId")
 	if serverIDMessage == nil {
-		return "", apiResponse.ToError("Received an unexpected response (missing 'serverId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Received an unexpected response (missing 'serverId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return *serverIDMessage, nil
+	return client.newPendingOperation(ResourceTypeServer, *serverIDMessage, "Deploy", ResourceStatusPendingAdd, false, apiResponse), nil
 }
 
 // DeployUncustomizedServer deploys a new virtual machine.
 func (client *Client) DeployUncustomizedServer(serverConfiguration UncustomizedServerDeploymentConfiguration) (serverID string, err error) {
-	organizationID, err := client.getOrganizationID()
+	operation, err := client.DeployUncustomizedServerAsync(serverConfiguration)
 	if err != nil {
 		return "", err
 	}
 
+	return operation.ResourceID, nil
+}
+
+// DeployUncustomizedServerAsync is equivalent to DeployUncustomizedServer, but returns an Operation that can be used to wait for the deploy operation to complete.
+func (client *Client) DeployUncustomizedServerAsync(serverConfiguration UncustomizedServerDeploymentConfiguration) (operation *Operation, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
 	requestURI := fmt.Sprintf("%s/server/deployUncustomizedServer",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodPost, &serverConfiguration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &serverConfiguration)
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeInProgress {
-		return "", apiResponse.ToError("Request to deploy uncustomised server '%s' failed with status code %d (%s): %s", serverConfiguration.Name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to deploy uncustomised server '%s' failed with status code %d (%s): %s", serverConfiguration.Name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
 	// Expected: "info" { "name": "serverId", "value": "the-Id-of-the-new-server" }
 	serverIDMessage := apiResponse.GetFieldMessage("serverId")
 	if serverIDMessage == nil {
-		return "", apiResponse.ToError("Received an unexpected response (missing 'serverId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Received an unexpected response (missing 'serverId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return *serverIDMessage, nil
+	return client.newPendingOperation(ResourceTypeServer, *serverIDMessage, "Deploy", ResourceStatusPendingAdd, false, apiResponse), nil
 }
 
 // EditServerMetadata modifies a server's name and / or description.
@@ -416,7 +557,7 @@ func (client *Client) EditServerMetadata(serverID string, name *string, descript
 	requestURI := fmt.Sprintf("%s/server/editServerMetadata",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV23(requestURI, http.MethodPost, &editServerMetadata{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &editServerMetadata{
 		ID:          serverID,
 		Name:        name,
 		Description: description,
@@ -479,7 +620,10 @@ func (client *Client) AddDiskToServer(serverID string, scsiBusNumber *int, scsiU
 		newDisk.Iops = iops
 	}
 
-	request, err := client.newRequestV210(requestURI, http.MethodPost, newDisk)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, newDisk)
+	if err != nil {
+		return "", err
+	}
 
 	responseBody, statusCode, err := client.executeRequest(request)
 
@@ -519,6 +663,9 @@ func (client *Client) ResizeServerDisk(serverID string, diskID string, newSizeGB
 	request, err := client.newRequestV1(requestURI, http.MethodPost, &resizeServerDisk{
 		NewSizeGB: newSizeGB,
 	})
+	if err != nil {
+		return
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return
@@ -543,19 +690,26 @@ func (client *Client) ChangeServerDiskSpeed(serverID string, diskID string, newS
 	var request *http.Request
 
 	if iops != nil {
-		request, err = client.newRequestV210(requestURI, http.MethodPost, &changeServerDiskSpeed{
+		request, err = client.newRequestV2(requestURI, http.MethodPost, &changeServerDiskSpeed{
 			ID:    diskID,
 			Speed: newSpeed,
 			Iops:  *iops,
 		})
+		if err != nil {
+			return nil, err
+		}
 	} else {
-		request, err = client.newRequestV210(requestURI, http.MethodPost, &changeServerDiskSpeed{
+		request, err = client.newRequestV2(requestURI, http.MethodPost, &changeServerDiskSpeed{
 			ID:    diskID,
 			Speed: newSpeed,
 		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	responseBody, statusCode, err := client.executeRequest(request)
+	// The request only identifies the disk, not the server.
+	responseBody, statusCode, err := client.WithLockKeys(LockKey{ResourceType: ResourceTypeServer, ID: serverID}).executeRequest(request)
 
 	if err != nil {
 		return
@@ -583,10 +737,13 @@ func (client *Client) ChangeServerDiskIops(diskID string, iops int) (apiResponse
 	requestURI := fmt.Sprintf("%s/server/changeDiskIops",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV210(requestURI, http.MethodPost, &changeServerDiskIops{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &changeServerDiskIops{
 		ID:   diskID,
 		Iops: iops,
 	})
+	if err != nil {
+		return
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return
@@ -616,9 +773,12 @@ func (client *Client) RemoveDiskFromServer(diskID string) error {
 	requestURI := fmt.Sprintf("%s/server/removeDisk",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &removeDiskFromServer{
+	request, err := client.newRequestV2ForOperation("server/removeDisk#fromServer", requestURI, http.MethodPost, &removeDiskFromServer{
 		DiskID: diskID,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -639,30 +799,40 @@ func (client *Client) RemoveDiskFromServer(diskID string) error {
 // DeleteServer deletes an existing Server.
 // Returns an error if the operation was not successful.
 func (client *Client) DeleteServer(id string) (err error) {
+	_, err = client.DeleteServerAsync(id)
+
+	return err
+}
+
+// DeleteServerAsync is equivalent to DeleteServer, but returns an Operation that can be used to wait for the delete operation to complete.
+func (client *Client) DeleteServerAsync(id string) (operation *Operation, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/server/deleteServer",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &deleteServer{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteServer{id})
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeInProgress {
-		return apiResponse.ToError("Request to delete server failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to delete server failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return nil
+	return client.newPendingOperation(ResourceTypeServer, id, "Delete", ResourceStatusPendingDelete, true, apiResponse), nil
 }
 
 // StartServer requests that the specified server be started.
@@ -675,7 +845,10 @@ func (client *Client) StartServer(id string) error {
 	requestURI := fmt.Sprintf("%s/server/startServer",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &startServer{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &startServer{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -703,7 +876,10 @@ func (client *Client) ShutdownServer(id string) error {
 	requestURI := fmt.Sprintf("%s/server/shutdownServer",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &stopServer{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &stopServer{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -731,7 +907,10 @@ func (client *Client) PowerOffServer(id string) error {
 	requestURI := fmt.Sprintf("%s/server/powerOffServer",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &stopServer{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &stopServer{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -749,6 +928,130 @@ func (client *Client) PowerOffServer(id string) error {
 	return nil
 }
 
+// RebootServer requests that the specified server be rebooted (gracefully; requires VMware Tools to be running on the server).
+func (client *Client) RebootServer(id string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/rebootServer",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &stopServer{id})
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to reboot server failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// ResetServer requests that the specified server be reset (hard restart).
+func (client *Client) ResetServer(id string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/resetServer",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &stopServer{id})
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to reset server failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// UpdateServerVMwareTools requests that VMware Tools be updated to the latest version on the specified server (the server must be running, with VMware Tools running).
+func (client *Client) UpdateServerVMwareTools(id string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/updateVmwareTools",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &stopServer{id})
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to update VMware Tools failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// UpgradeServerVirtualHardware requests that the virtual hardware of the specified server be upgraded to the latest version (the server must be stopped; see UpgradeServerVirtualHardwareWithRestart).
+func (client *Client) UpgradeServerVirtualHardware(id string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/server/upgradeVirtualHardware",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &stopServer{id})
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to upgrade virtual hardware failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
 // NotifyServerIPAddressChange notifies the system that the IP address for a server's network adapter has changed.
 // serverNetworkAdapterID is the Id of the server's network adapter.
 // Must specify at least one of newIPv4Address / newIPv6Address.
@@ -761,11 +1064,14 @@ func (client *Client) NotifyServerIPAddressChange(networkAdapterID string, newIP
 	requestURI := fmt.Sprintf("%s/server/notifyNicIpChange",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &notifyServerIPAddressChange{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &notifyServerIPAddressChange{
 		AdapterID:   networkAdapterID,
 		IPv4Address: newIPv4Address,
 		IPv6Address: newIPv6Address,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -794,13 +1100,16 @@ func (client *Client) ReconfigureServer(serverID string, memoryGB *int, cpuCount
 	requestURI := fmt.Sprintf("%s/server/reconfigureServer",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &reconfigureServer{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &reconfigureServer{
 		ServerID:          serverID,
 		MemoryGB:          memoryGB,
 		CPUCount:          cpuCount,
 		CPUCoresPerSocket: cpuCoresPerSocket,
 		CPUSpeed:          cpuSpeed,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -854,10 +1163,13 @@ func (client *Client) addNicToServer(serverID string, nicConfiguration *serverNi
 	requestURI := fmt.Sprintf("%s/server/addNic",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &addNicConfiguration{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &addNicConfiguration{
 		ServerID: serverID,
 		Nic:      *nicConfiguration,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -888,7 +1200,10 @@ func (client *Client) RemoveNicFromServer(networkAdapterID string) (err error) {
 	requestURI := fmt.Sprintf("%s/server/removeNic",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &deleteNic{ID: networkAdapterID})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteNic{ID: networkAdapterID})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -916,10 +1231,13 @@ func (client *Client) ChangeNetworkAdapterType(networkAdapterID string, networkA
 	requestURI := fmt.Sprintf("%s/server/changeNetworkAdapter",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &changeNicType{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &changeNicType{
 		ID:   networkAdapterID,
 		Type: networkAdapterType,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
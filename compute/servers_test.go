@@ -415,6 +415,42 @@ func TestClient_DeleteServer_Success(test *testing.T) {
 	// Pass
 }
 
+// Reboot Server (successful).
+func TestClient_RebootServer_Success(test *testing.T) {
+	expect := expect(test)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestBody, err := readRequestBodyAsString(request)
+		if err != nil {
+			test.Fatal("Failed to read request body: ", err)
+		}
+
+		expect.EqualsString("Request.URL.Path", "/caas/2.2/dummy-organization-id/server/rebootServer", request.URL.Path)
+		expect.EqualsString("Request.Body",
+			`{"id":"5b00a2ab-c665-4cd6-8291-0b931374fb3d"}`,
+			requestBody,
+		)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		fmt.Fprintln(writer, rebootServerTestResponse)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithBaseAddress(testServer.URL, "user1", "password")
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	err := client.RebootServer("5b00a2ab-c665-4cd6-8291-0b931374fb3d")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	// Pass
+}
+
 /*
  * Test requests.
  */
@@ -820,6 +856,14 @@ func verifyGetServerTestResponse(test *testing.T, server *Server) {
 	expect.EqualsString("Server.Name", "Production Web Server", server.Name)
 	expect.EqualsString("Server.State", ResourceStatusPendingChange, server.State)
 
+	expect.NotNil("Server.VMwareTools", server.VMwareTools)
+	expect.IsTrue("Server.VMwareTools.IsRunning", server.VMwareTools.IsRunning())
+	expect.EqualsString("Server.VMwareTools.VersionStatus", VMwareToolsVersionStatusCurrent, server.VMwareTools.VersionStatus)
+	expect.EqualsInt("Server.VMwareTools.APIVersion", 9354, server.VMwareTools.APIVersion)
+	expect.NotNil("Server.VirtualHardware", server.VirtualHardware)
+	expect.EqualsString("Server.VirtualHardware.Version", "vmx-08", server.VirtualHardware.Version)
+	expect.IsFalse("Server.VirtualHardware.UpToDate", server.VirtualHardware.UpToDate)
+
 	expect.EqualsInt("Server.SCSIControllers.Length", 1, len(server.SCSIControllers))
 
 	controller1 := server.SCSIControllers[0]
@@ -1146,3 +1190,12 @@ const getServerTestResponseV2 = `
 	   "datacenterId":"AU9"
 	}
 `
+
+const rebootServerTestResponse = `
+	{
+		"operation": "REBOOT_SERVER",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to reboot Server 'Production Web Server' has been accepted and is being processed.",
+		"requestId": "na9_20160321T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
@@ -0,0 +1,674 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// ServerSnapshotTypeSystem represents a snapshot taken automatically (during the server's snapshot window).
+	ServerSnapshotTypeSystem = "SYSTEM"
+
+	// ServerSnapshotTypeManual represents a snapshot taken on request (see InitiateManualServerSnapshot).
+	ServerSnapshotTypeManual = "MANUAL"
+
+	// SnapshotWindowDaily represents a snapshot window that occurs every day.
+	SnapshotWindowDaily = "DAILY"
+)
+
+// SnapshotServicePlan represents a plan for the snapshot service (determines how often snapshots are taken, and how long they are retained).
+type SnapshotServicePlan struct {
+	ID                  string `json:"id"`
+	DisplayName         string `json:"displayName"`
+	Description         string `json:"description"`
+	SnapshotFrequency   string `json:"snapshotFrequency"`
+	RetentionPeriodDays int    `json:"retentionPeriodInDays"`
+	Available           bool   `json:"available"`
+}
+
+// SnapshotServicePlans represents a page of SnapshotServicePlan results.
+type SnapshotServicePlans struct {
+	Items []SnapshotServicePlan `json:"snapshotServicePlan"`
+
+	PagedResult
+}
+
+// SnapshotWindow represents a window during which snapshots can be taken for servers that use the snapshot service.
+type SnapshotWindow struct {
+	ID             string `json:"id,omitempty"`
+	DayOfWeek      string `json:"dayOfWeek"`
+	StartHour      int    `json:"startHour"`
+	CapacityStatus string `json:"capacityStatus,omitempty"`
+}
+
+// SnapshotWindows represents a page of SnapshotWindow results.
+type SnapshotWindows struct {
+	Items []SnapshotWindow `json:"snapshotWindow"`
+
+	PagedResult
+}
+
+// ServerSnapshotService represents the snapshot service configuration for a server.
+type ServerSnapshotService struct {
+	ServicePlan              string         `json:"servicePlan"`
+	Window                   SnapshotWindow `json:"window"`
+	State                    string         `json:"state"`
+	ManualSnapshotInProgress bool           `json:"manualSnapshotInProgress"`
+}
+
+// ServerSnapshot represents a snapshot of a server.
+type ServerSnapshot struct {
+	ID               string `json:"id"`
+	ServerID         string `json:"serverId"`
+	Type             string `json:"type"`
+	Description      string `json:"description,omitempty"`
+	StartTime        string `json:"startTime"`
+	ExpiryTime       string `json:"expiryTime"`
+	ConsistencyLevel string `json:"consistencyLevel"`
+	IndexState       string `json:"indexState"`
+	State            string `json:"state"`
+}
+
+// GetID returns the snapshot's Id.
+func (snapshot *ServerSnapshot) GetID() string {
+	return snapshot.ID
+}
+
+// GetResourceType returns the snapshot's resource type.
+func (snapshot *ServerSnapshot) GetResourceType() ResourceType {
+	return ResourceTypeServerSnapshot
+}
+
+// GetName returns the snapshot's name (actually Id, since snapshots don't have names).
+func (snapshot *ServerSnapshot) GetName() string {
+	return snapshot.ID
+}
+
+// GetState returns the snapshot's current state.
+func (snapshot *ServerSnapshot) GetState() string {
+	return snapshot.State
+}
+
+// IsDeleted determines whether the snapshot has been deleted (is nil).
+func (snapshot *ServerSnapshot) IsDeleted() bool {
+	return snapshot == nil
+}
+
+// ToEntityReference creates an EntityReference representing the snapshot.
+func (snapshot *ServerSnapshot) ToEntityReference() EntityReference {
+	return EntityReference{
+		ID:   snapshot.ID,
+		Name: snapshot.ID,
+	}
+}
+
+var _ Resource = &ServerSnapshot{}
+
+// ServerSnapshots represents a page of ServerSnapshot results.
+type ServerSnapshots struct {
+	Items []ServerSnapshot `json:"snapshot"`
+
+	PagedResult
+}
+
+// SnapshotPreviewServerConfiguration represents the configuration for a server created from a snapshot (see CreateSnapshotPreviewServer).
+type SnapshotPreviewServerConfiguration struct {
+	Name                 string `json:"serverName"`
+	Description          string `json:"serverDescription,omitempty"`
+	TargetVLANID         string `json:"targetVlanId,omitempty"`
+	NICsConnected        bool   `json:"nicsConnected"`
+	Start                bool   `json:"serverStarted"`
+	PreserveMACAddresses bool   `json:"preserveMacAddresses"`
+}
+
+// Request body when enabling the snapshot service for a server.
+type enableSnapshotService struct {
+	ServerID    string                      `json:"serverId"`
+	ServicePlan string                      `json:"servicePlan"`
+	Window      snapshotWindowConfiguration `json:"window"`
+}
+
+// The snapshot window for a server (when enabling the snapshot service).
+type snapshotWindowConfiguration struct {
+	DayOfWeek string `json:"dayOfWeek"`
+	StartHour int    `json:"startHour"`
+}
+
+// Request body when disabling the snapshot service for a server.
+type disableSnapshotService struct {
+	ServerID string `json:"serverId"`
+}
+
+// Request body when initiating a manual snapshot of a server.
+type initiateManualSnapshot struct {
+	ServerID    string `json:"serverId"`
+	Description string `json:"description,omitempty"`
+}
+
+// Request body when creating a server from a snapshot.
+type createSnapshotPreviewServer struct {
+	SnapshotID string `json:"snapshotId"`
+
+	SnapshotPreviewServerConfiguration
+}
+
+// Request body when restoring files from a snapshot.
+type restoreFiles struct {
+	SnapshotID     string `json:"snapshotId"`
+	SourcePath     string `json:"sourcePath"`
+	TargetServerID string `json:"targetServerId"`
+	TargetPath     string `json:"targetPath"`
+}
+
+// ListSnapshotServicePlans retrieves a page of the available snapshot service plans.
+func (client *Client) ListSnapshotServicePlans(paging *Paging) (plans *SnapshotServicePlans, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	paging = client.ensurePaging(paging)
+	requestURI := fmt.Sprintf("%s/snapshot/servicePlan?%s",
+		url.QueryEscape(organizationID),
+		paging.toQueryParameters(),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, apiResponse.ToError("Request to list snapshot service plans failed with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	plans = &SnapshotServicePlans{}
+	err = json.Unmarshal(responseBody, plans)
+
+	return plans, err
+}
+
+// AllSnapshotServicePlans retrieves all available snapshot service plans (across all pages of results).
+func (client *Client) AllSnapshotServicePlans() (plans []SnapshotServicePlan, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListSnapshotServicePlans(paging)
+	}, func(page PagedResults) {
+		plans = append(plans, page.(*SnapshotServicePlans).Items...)
+	})
+
+	return
+}
+
+// ListSnapshotWindows retrieves a page of the snapshot windows available in the specified datacenter for the specified snapshot service plan.
+func (client *Client) ListSnapshotWindows(datacenterID string, servicePlan string, paging *Paging) (windows *SnapshotWindows, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	paging = client.ensurePaging(paging)
+	requestURI := fmt.Sprintf("%s/snapshot/window?datacenterId=%s&servicePlan=%s&%s",
+		url.QueryEscape(organizationID),
+		url.QueryEscape(datacenterID),
+		url.QueryEscape(servicePlan),
+		paging.toQueryParameters(),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, apiResponse.ToError("Request to list snapshot windows in datacenter '%s' failed with status code %d (%s): %s", datacenterID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	windows = &SnapshotWindows{}
+	err = json.Unmarshal(responseBody, windows)
+
+	return windows, err
+}
+
+// AllSnapshotWindows retrieves all snapshot windows available in the specified datacenter for the specified snapshot service plan (across all pages of results).
+func (client *Client) AllSnapshotWindows(datacenterID string, servicePlan string) (windows []SnapshotWindow, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListSnapshotWindows(datacenterID, servicePlan, paging)
+	}, func(page PagedResults) {
+		windows = append(windows, page.(*SnapshotWindows).Items...)
+	})
+
+	return
+}
+
+// EnableServerSnapshotService enables the snapshot service for the specified server, using the specified service plan and snapshot window (only DayOfWeek and StartHour are used).
+func (client *Client) EnableServerSnapshotService(serverID string, servicePlan string, window SnapshotWindow) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/snapshot/enableSnapshotService",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &enableSnapshotService{
+		ServerID:    serverID,
+		ServicePlan: servicePlan,
+		Window: snapshotWindowConfiguration{
+			DayOfWeek: window.DayOfWeek,
+			StartHour: window.StartHour,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeOK && apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to enable snapshot service for server '%s' failed with unexpected status code %d (%s): %s", serverID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// DisableServerSnapshotService disables the snapshot service for the specified server (its existing snapshots are deleted).
+func (client *Client) DisableServerSnapshotService(serverID string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/snapshot/disableSnapshotService",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &disableSnapshotService{serverID})
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeOK && apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to disable snapshot service for server '%s' failed with unexpected status code %d (%s): %s", serverID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
+
+// GetServerSnapshot retrieves the server snapshot with the specified Id.
+// Returns nil if no snapshot is found with the specified Id.
+func (client *Client) GetServerSnapshot(id string) (snapshot *ServerSnapshot, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/snapshot/snapshot/%s",
+		url.QueryEscape(organizationID),
+		url.QueryEscape(id),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return nil, err
+		}
+
+		if apiResponse.ResponseCode == ResponseCodeResourceNotFound {
+			return nil, nil // Not an error, but was not found.
+		}
+
+		return nil, apiResponse.ToError("Request to retrieve server snapshot '%s' failed with status code %d (%s): %s", id, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	snapshot = &ServerSnapshot{}
+	err = json.Unmarshal(responseBody, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// ListServerSnapshots retrieves a page of the snapshots for the specified server (most recent first).
+func (client *Client) ListServerSnapshots(serverID string, paging *Paging) (snapshots *ServerSnapshots, err error) {
+	return client.ListServerSnapshotsWithFilter(serverID, nil, paging)
+}
+
+// ListServerSnapshotsWithFilter retrieves a page of the snapshots for the specified server that match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results; unless it specifies a sort order, the most recent snapshots are returned first.
+func (client *Client) ListServerSnapshotsWithFilter(serverID string, filter *Filter, paging *Paging) (snapshots *ServerSnapshots, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	filterParameters, err := filter.toQueryParameters(FilterFieldServerID)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil || len(filter.orderBy) == 0 {
+		filterParameters += "&orderBy=" + FilterFieldStartTime + ".DESCENDING"
+	}
+
+	requestURI := fmt.Sprintf("%s/snapshot/snapshot?serverId=%s&%s%s",
+		url.QueryEscape(organizationID),
+		url.QueryEscape(serverID),
+		client.ensurePaging(paging).toQueryParameters(),
+		filterParameters,
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != http.StatusOK {
+		var apiResponse *APIResponseV2
+
+		apiResponse, err = readAPIResponseAsJSON(responseBody, statusCode)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, apiResponse.ToError("Request to list snapshots for server '%s' failed with status code %d (%s): %s", serverID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	snapshots = &ServerSnapshots{}
+	err = json.Unmarshal(responseBody, snapshots)
+
+	return snapshots, err
+}
+
+// AllServerSnapshots retrieves all snapshots for the specified server (across all pages of results, most recent first).
+func (client *Client) AllServerSnapshots(serverID string) (snapshots []ServerSnapshot, err error) {
+	return client.AllServerSnapshotsWithFilter(serverID, nil)
+}
+
+// AllServerSnapshotsWithFilter retrieves all snapshots for the specified server that match the specified filter criteria (across all pages of results).
+func (client *Client) AllServerSnapshotsWithFilter(serverID string, filter *Filter) (snapshots []ServerSnapshot, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListServerSnapshotsWithFilter(serverID, filter, paging)
+	}, func(page PagedResults) {
+		snapshots = append(snapshots, page.(*ServerSnapshots).Items...)
+	})
+
+	return
+}
+
+// InitiateManualServerSnapshot requests a manual snapshot of the specified server (whose snapshot service must be enabled).
+//
+// Returns the time at which the snapshot was requested; pass this to WaitForManualServerSnapshot to wait for the snapshot to complete.
+func (client *Client) InitiateManualServerSnapshot(serverID string, description string) (requestedAt time.Time, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	requestURI := fmt.Sprintf("%s/snapshot/initiateManualSnapshot",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &initiateManualSnapshot{
+		ServerID:    serverID,
+		Description: description,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	requestedAt = time.Now()
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return time.Time{}, apiResponse.ToError("Request to initiate manual snapshot of server '%s' failed with unexpected status code %d (%s): %s", serverID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return requestedAt, nil
+}
+
+// WaitForManualServerSnapshot waits for a manual snapshot of a server (see InitiateManualServerSnapshot) to complete.
+//
+// requestedAt is the time at which the snapshot was requested (as returned by InitiateManualServerSnapshot).
+// The wait continues until the server's snapshot service no longer indicates that a manual snapshot is in progress, and a manual snapshot that started at or after requestedAt is available.
+//
+// Returns the requested snapshot.
+func (client *Client) WaitForManualServerSnapshot(serverID string, requestedAt time.Time, timeout time.Duration) (snapshot *ServerSnapshot, err error) {
+	waitTimeout := time.NewTimer(timeout)
+	defer waitTimeout.Stop()
+
+	waiter := client.getWaiter()
+	startTime := time.Now()
+
+	actionDescription := "Manual snapshot"
+	operationDescription := fmt.Sprintf("Wait for manual snapshot of server '%s'", serverID)
+
+	for iteration := 1; ; iteration++ {
+		pollTimer := time.NewTimer(waiter.pollInterval(iteration))
+
+		select {
+		case <-client.Context().Done():
+			pollTimer.Stop()
+
+			return nil, client.checkCancellation(operationDescription)
+
+		case <-waitTimeout.C:
+			pollTimer.Stop()
+
+			return nil, &WaitTimeoutError{
+				ResourceType:      ResourceTypeServer,
+				ResourceID:        serverID,
+				ActionDescription: actionDescription,
+				Timeout:           timeout,
+			}
+
+		case <-pollTimer.C:
+			client.logDebug("Polling manual snapshot status for server...", logField(LogFieldResourceID, serverID))
+			err = client.checkCancellation(operationDescription)
+			if err != nil {
+				return nil, err
+			}
+
+			server, err := client.GetServer(serverID)
+			if err != nil {
+				return nil, err
+			}
+			if server == nil {
+				return nil, fmt.Errorf("no server was found with Id '%s'", serverID)
+			}
+			if server.SnapshotService == nil {
+				return nil, fmt.Errorf("cannot wait for manual snapshot of server '%s' because its snapshot service is not enabled", serverID)
+			}
+
+			if !server.SnapshotService.ManualSnapshotInProgress {
+				snapshot, err = client.findManualServerSnapshot(serverID, requestedAt)
+				if err != nil {
+					return nil, err
+				}
+				if snapshot != nil {
+					client.logInfo("Manual snapshot of server has successfully completed.", logField(LogFieldResourceID, serverID))
+
+					return snapshot, nil
+				}
+			}
+
+			// Snapshot is still in progress (or has not started yet).
+			client.logInfo("Manual snapshot of server is still in progress...", logField(LogFieldResourceID, serverID))
+			waiter.reportProgress(ResourceTypeServer, serverID, actionDescription, iteration, startTime, server, server.State)
+		}
+	}
+}
+
+// findManualServerSnapshot finds the earliest manual snapshot of a server that started at or after the specified time.
+//
+// Returns nil if no such snapshot was found.
+func (client *Client) findManualServerSnapshot(serverID string, startedAfter time.Time) (*ServerSnapshot, error) {
+	filter := NewFilter().
+		Equals(FilterFieldType, ServerSnapshotTypeManual).
+		Min(FilterFieldStartTime, formatFilterTime(startedAfter)).
+		OrderBy(FilterFieldStartTime)
+
+	snapshots, err := client.ListServerSnapshotsWithFilter(serverID, filter, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range snapshots.Items {
+		startTime, err := time.Parse(time.RFC3339, snapshot.StartTime)
+		if err != nil || startTime.Before(startedAfter.Truncate(time.Millisecond)) {
+			continue
+		}
+
+		return &snapshot, nil
+	}
+
+	return nil, nil
+}
+
+// CreateSnapshotPreviewServer creates a new server from the specified snapshot.
+//
+// Returns the Id of the new server.
+func (client *Client) CreateSnapshotPreviewServer(snapshotID string, configuration SnapshotPreviewServerConfiguration) (serverID string, err error) {
+	operation, err := client.CreateSnapshotPreviewServerAsync(snapshotID, configuration)
+	if err != nil {
+		return "", err
+	}
+
+	return operation.ResourceID, nil
+}
+
+// CreateSnapshotPreviewServerAsync is equivalent to CreateSnapshotPreviewServer, but returns an Operation that can be used to wait for the new server to be deployed.
+func (client *Client) CreateSnapshotPreviewServerAsync(snapshotID string, configuration SnapshotPreviewServerConfiguration) (operation *Operation, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/snapshot/createSnapshotPreviewServer",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &createSnapshotPreviewServer{
+		SnapshotID:                         snapshotID,
+		SnapshotPreviewServerConfiguration: configuration,
+	})
+	if err != nil {
+		return nil, err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return nil, apiResponse.ToError("Request to create server '%s' from snapshot '%s' failed with status code %d (%s): %s", configuration.Name, snapshotID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	// Expected: "info" { "name": "serverId", "value": "the-Id-of-the-new-server" }
+	serverIDMessage := apiResponse.GetFieldMessage("serverId")
+	if serverIDMessage == nil {
+		return nil, apiResponse.ToError("Received an unexpected response (missing 'serverId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return client.newPendingOperation(ResourceTypeServer, *serverIDMessage, "Deploy", ResourceStatusPendingAdd, false, apiResponse), nil
+}
+
+// RestoreServerSnapshotFiles restores files (or directories) from a snapshot to the specified path on the target server (which must be running, with VMware Tools running).
+//
+// targetServerID is usually the Id of the server that the snapshot was taken from; use WaitForChange (with ResourceTypeServer) to wait for the restore to complete.
+func (client *Client) RestoreServerSnapshotFiles(snapshotID string, sourcePath string, targetServerID string, targetPath string) error {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return err
+	}
+
+	requestURI := fmt.Sprintf("%s/snapshot/restoreFiles",
+		url.QueryEscape(organizationID),
+	)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &restoreFiles{
+		SnapshotID:     snapshotID,
+		SourcePath:     sourcePath,
+		TargetServerID: targetServerID,
+		TargetPath:     targetPath,
+	})
+	if err != nil {
+		return err
+	}
+	responseBody, statusCode, err := client.executeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
+	if err != nil {
+		return err
+	}
+
+	if apiResponse.ResponseCode != ResponseCodeInProgress {
+		return apiResponse.ToError("Request to restore files from snapshot '%s' failed with unexpected status code %d (%s): %s", snapshotID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// List server snapshots (successful).
+func TestClient_ListServerSnapshots_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			snapshots, err := client.ListServerSnapshots("5a32d6e4-9707-4813-a269-56ab4d989f4d", nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsInt("Snapshots.Items size", 2, len(snapshots.Items))
+			expect.IsTrue("Snapshots.IsLastPage", snapshots.IsLastPage())
+
+			snapshot := snapshots.Items[0]
+			expect.EqualsString("Snapshot[0].ID", "8f8f2ac9-0a6b-4a2e-9e7f-b7e2a0f0a8f1", snapshot.ID)
+			expect.EqualsString("Snapshot[0].Type", ServerSnapshotTypeManual, snapshot.Type)
+			expect.EqualsString("Snapshot[0].State", ResourceStatusNormal, snapshot.State)
+			expect.EqualsInt("Snapshot[0].ResourceType", int(ResourceTypeServerSnapshot), int(snapshot.GetResourceType()))
+			expect.EqualsString("Snapshot[1].Type", ServerSnapshotTypeSystem, snapshots.Items[1].Type)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Path", "/caas/2.10/my-organization-id/snapshot/snapshot", request.URL.Path)
+			expect.EqualsString("Request.URL.Query.serverId", "5a32d6e4-9707-4813-a269-56ab4d989f4d", request.URL.Query().Get("serverId"))
+			expect.EqualsString("Request.URL.Query.orderBy", "startTime.DESCENDING", request.URL.Query().Get("orderBy"))
+
+			return http.StatusOK, listServerSnapshotsTestResponse
+		},
+	})
+}
+
+// List server snapshots with a filter (successful).
+func TestClient_ListServerSnapshotsWithFilter_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			filter := NewFilter().Equals(FilterFieldType, ServerSnapshotTypeManual).OrderBy(FilterFieldStartTime)
+
+			_, err := client.ListServerSnapshotsWithFilter("5a32d6e4-9707-4813-a269-56ab4d989f4d", filter, nil)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			_, err = client.ListServerSnapshotsWithFilter("5a32d6e4-9707-4813-a269-56ab4d989f4d", NewFilter().Equals(FilterFieldServerID, "another-server-id"), nil)
+			expect.IsTrue("Filter on serverId is rejected", err != nil)
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			expect.EqualsString("Request.URL.Query.serverId", "5a32d6e4-9707-4813-a269-56ab4d989f4d", request.URL.Query().Get("serverId"))
+			expect.EqualsString("Request.URL.Query.type", ServerSnapshotTypeManual, request.URL.Query().Get("type"))
+			expect.EqualsString("Request.URL.Query.orderBy", "startTime", request.URL.Query().Get("orderBy"))
+
+			return http.StatusOK, listServerSnapshotsTestResponse
+		},
+	})
+}
+
+// Create snapshot preview server (successful).
+func TestClient_CreateSnapshotPreviewServer_Success(test *testing.T) {
+	expect := expect(test)
+
+	testClientRequest(test, &ClientTestConfig{
+		Request: func(test *testing.T, client *Client) {
+			operation, err := client.CreateSnapshotPreviewServerAsync("8f8f2ac9-0a6b-4a2e-9e7f-b7e2a0f0a8f1", SnapshotPreviewServerConfiguration{
+				Name:          "Preview Server",
+				NICsConnected: false,
+				Start:         true,
+			})
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("Operation.ResourceID", "0e9a4b1c-7ad1-4b6f-9f1e-2d3c4b5a6f70", operation.ResourceID)
+			expect.EqualsInt("Operation.ResourceType", int(ResourceTypeServer), int(operation.ResourceType))
+		},
+		Respond: func(test *testing.T, request *http.Request) (int, string) {
+			requestBody, err := readRequestBodyAsString(request)
+			if err != nil {
+				test.Fatal(err)
+			}
+
+			expect.EqualsString("Request.URL.Path", "/caas/2.10/my-organization-id/snapshot/createSnapshotPreviewServer", request.URL.Path)
+			expect.EqualsString("Request.Body",
+				`{"snapshotId":"8f8f2ac9-0a6b-4a2e-9e7f-b7e2a0f0a8f1","serverName":"Preview Server","nicsConnected":false,"serverStarted":true,"preserveMacAddresses":false}`,
+				requestBody,
+			)
+
+			return http.StatusOK, createSnapshotPreviewServerTestResponse
+		},
+	})
+}
+
+// Waiting for a manual snapshot waits until the server's snapshot service indicates that it is no longer in progress, then returns the snapshot that was requested (not an earlier one).
+func TestClient_WaitForManualServerSnapshot(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+	snapshotID := "6d8e0a2b-1c3f-4e5a-9b7c-8d9e0f1a2b3c"
+
+	var stateLock sync.Mutex
+	var snapshotStartTime time.Time
+	serverPollCount := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		stateLock.Lock()
+		defer stateLock.Unlock()
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		switch {
+		case strings.HasSuffix(request.URL.Path, "/snapshot/initiateManualSnapshot"):
+			snapshotStartTime = time.Now().UTC()
+			fmt.Fprint(writer, `{"operation": "INITIATE_MANUAL_SNAPSHOT", "responseCode": "IN_PROGRESS", "message": "Request in progress.", "requestId": "request-1"}`)
+
+		case strings.HasSuffix(request.URL.Path, "/snapshot/snapshot"):
+			expect.EqualsString("Request.URL.Query.type", ServerSnapshotTypeManual, request.URL.Query().Get("type"))
+
+			// The previous manual snapshot is excluded by the filter.
+			startTimeMin, err := time.Parse(time.RFC3339, request.URL.Query().Get("startTime.MIN"))
+			if err != nil {
+				test.Error(err)
+			}
+			if serverPollCount < 3 || snapshotStartTime.Before(startTimeMin) {
+				fmt.Fprint(writer, `{"snapshot": [], "pageNumber": 1, "pageCount": 0, "totalCount": 0, "pageSize": 50}`)
+
+				return
+			}
+			fmt.Fprintf(writer, `{"snapshot": [{"id": "%s", "serverId": "%s", "type": "MANUAL", "startTime": "%s", "state": "NORMAL"}], "pageNumber": 1, "pageCount": 1, "totalCount": 1, "pageSize": 50}`,
+				snapshotID, serverID, snapshotStartTime.Format("2006-01-02T15:04:05.000Z"),
+			)
+
+		default:
+			// Server remains NORMAL throughout; only its snapshot service indicates that the snapshot is in progress.
+			serverPollCount++
+			fmt.Fprintf(writer, `{"id": "%s", "name": "server1", "state": "NORMAL", "started": true, "snapshotService": {"servicePlan": "ONE_MONTH", "state": "NORMAL", "manualSnapshotInProgress": %t}}`,
+				serverID, serverPollCount < 3,
+			)
+		}
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithWaiter(&Waiter{InitialPollInterval: 5 * time.Millisecond}),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	requestedAt, err := client.InitiateManualServerSnapshot(serverID, "Pre-patching")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	snapshot, err := client.WaitForManualServerSnapshot(serverID, requestedAt, 10*time.Second)
+	if err != nil {
+		test.Fatal(err)
+	}
+	expect.NotNil("Snapshot", snapshot)
+	expect.EqualsString("Snapshot.ID", snapshotID, snapshot.ID)
+
+	stateLock.Lock()
+	defer stateLock.Unlock()
+
+	expect.EqualsInt("Server poll count", 3, serverPollCount)
+}
+
+/*
+ * Test responses.
+ */
+
+const listServerSnapshotsTestResponse = `
+	{
+		"snapshot": [
+			{
+				"id": "8f8f2ac9-0a6b-4a2e-9e7f-b7e2a0f0a8f1",
+				"serverId": "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+				"type": "MANUAL",
+				"description": "Pre-patching",
+				"startTime": "2018-03-02T10:31:33.000Z",
+				"expiryTime": "2018-04-01T10:31:33.000Z",
+				"consistencyLevel": "CRASH_CONSISTENT",
+				"indexState": "INDEX_COMPLETE",
+				"state": "NORMAL"
+			},
+			{
+				"id": "2c1b7e0f-3d6a-4c8e-8f5b-9a0d1e2f3a4b",
+				"serverId": "5a32d6e4-9707-4813-a269-56ab4d989f4d",
+				"type": "SYSTEM",
+				"startTime": "2018-03-01T08:00:00.000Z",
+				"expiryTime": "2018-03-31T08:00:00.000Z",
+				"consistencyLevel": "CRASH_CONSISTENT",
+				"indexState": "INDEX_COMPLETE",
+				"state": "NORMAL"
+			}
+		],
+		"pageNumber": 1,
+		"pageCount": 2,
+		"totalCount": 2,
+		"pageSize": 50
+	}
+`
+
+const createSnapshotPreviewServerTestResponse = `
+	{
+		"operation": "CREATE_SNAPSHOT_PREVIEW_SERVER",
+		"responseCode": "IN_PROGRESS",
+		"message": "Request to create Snapshot Preview Server 'Preview Server' has been accepted and is being processed.",
+		"info": [
+			{
+				"name": "serverId",
+				"value": "0e9a4b1c-7ad1-4b6f-9f1e-2d3c4b5a6f70"
+			}
+		],
+		"requestId": "na9_20180302T074626030-0400_7e9fffe7-190b-46f2-9107-9d52fe57d0ad"
+	}
+`
@@ -85,9 +85,9 @@ func (client *Client) ListSSLCertificateChainsInNetworkDomain(networkDomainID st
 	requestURI := fmt.Sprintf("%s/networkDomainVip/sslCertificateChain?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +130,7 @@ func (client *Client) GetSSLCertificateChain(id string) (pool *SSLCertificateCha
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -173,12 +173,15 @@ func (client *Client) ImportSSLCertificateChain(networkDomainID string, name str
 	requestURI := fmt.Sprintf("%s/networkDomainVip/importSslCertificateChain",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &importSSLCertificateChain{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &importSSLCertificateChain{
 		Name:             name,
 		Description:      description,
 		CertificateChain: certificateChain,
 		NetworkDomainID:  networkDomainID,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -214,7 +217,10 @@ func (client *Client) DeleteSSLCertificateChain(id string) (err error) {
 	requestURI := fmt.Sprintf("%s/networkDomainVip/deleteSslCertificateChain",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &deleteSSLCertificateChain{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteSSLCertificateChain{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
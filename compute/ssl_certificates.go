@@ -86,9 +86,9 @@ func (client *Client) ListSSLDomainCertificatesInNetworkDomain(networkDomainID s
 	requestURI := fmt.Sprintf("%s/networkDomainVip/sslDomainCertificate?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +130,7 @@ func (client *Client) GetSSLDomainCertificate(id string) (pool *SSLDomainCertifi
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -173,13 +173,16 @@ func (client *Client) ImportSSLDomainCertificate(networkDomainID string, name st
 	requestURI := fmt.Sprintf("%s/networkDomainVip/importSslDomainCertificate",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &importSSLDomainCertificate{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &importSSLDomainCertificate{
 		Name:            name,
 		Description:     description,
 		Certificate:     certificate,
 		Key:             key,
 		NetworkDomainID: networkDomainID,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -215,7 +218,10 @@ func (client *Client) DeleteSSLDomainCertificate(id string) (err error) {
 	requestURI := fmt.Sprintf("%s/networkDomainVip/deleteSslDomainCertificate",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &deleteSSLDomainCertificate{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteSSLDomainCertificate{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -101,9 +101,9 @@ func (client *Client) ListSSLOffloadProfilesInNetworkDomain(networkDomainID stri
 	requestURI := fmt.Sprintf("%s/networkDomainVip/sslOffloadProfile?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +146,7 @@ func (client *Client) GetSSLOffloadProfile(id string) (pool *SSLOffloadProfile,
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -189,7 +189,7 @@ func (client *Client) CreateSSLOffloadProfile(networkDomainID string, name strin
 	requestURI := fmt.Sprintf("%s/networkDomainVip/createSslOffloadProfile",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &createSSLOffloadProfile{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &createSSLOffloadProfile{
 		Name:                   name,
 		Description:            description,
 		Ciphers:                ciphers,
@@ -197,6 +197,9 @@ func (client *Client) CreateSSLOffloadProfile(networkDomainID string, name strin
 		SSLCertificateChainID:  sslCertificateChainID,
 		NetworkDomainID:        networkDomainID,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -230,7 +233,7 @@ func (client *Client) EditSSLOffloadProfile(sslOffloadProfile SSLOffloadProfile)
 	requestURI := fmt.Sprintf("%s/networkDomainVip/editSslOffloadProfile",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &editSSLOffloadProfile{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &editSSLOffloadProfile{
 		ID:                     sslOffloadProfile.ID,
 		Name:                   sslOffloadProfile.Name,
 		Description:            sslOffloadProfile.Description,
@@ -239,6 +242,9 @@ func (client *Client) EditSSLOffloadProfile(sslOffloadProfile SSLOffloadProfile)
 		SSLCertificateChainID:  sslOffloadProfile.SSLCertificateChain.ID,
 		NetworkDomainID:        sslOffloadProfile.NetworkDomainID,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -268,7 +274,10 @@ func (client *Client) DeleteSSLOffloadProfile(id string) (err error) {
 	requestURI := fmt.Sprintf("%s/networkDomainVip/deleteSslOffloadProfile",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &deleteSSLOffloadProfile{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteSSLOffloadProfile{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -3,7 +3,6 @@ package compute
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 )
@@ -152,7 +151,7 @@ func (client *Client) CreateStaticRoute(networkDomainId string, name string, des
 	}
 
 	requestURI := fmt.Sprintf("%s/network/createStaticRoute", url.QueryEscape(organizationID))
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &CreateStaticRoute{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &CreateStaticRoute{
 		NetworkDomainId:           networkDomainId,
 		Name:                      name,
 		Description:               description,
@@ -161,6 +160,9 @@ func (client *Client) CreateStaticRoute(networkDomainId string, name string, des
 		DestinationPrefixSize:     destinationPrefixSize,
 		NextHopAddress:            nextHopAddress,
 	})
+	if err != nil {
+		return "", err
+	}
 
 	responseBody, statusCode, err := client.executeRequest(request)
 
@@ -175,8 +177,11 @@ func (client *Client) CreateStaticRoute(networkDomainId string, name string, des
 			return "", nil // Not an error, but was not found.
 		}
 
-		log.Printf("Request to create Static Route failed with status_code:%d response_code:%s  Msg: %s",
-			statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		client.logError("Request to create Static Route failed.",
+			logField(LogFieldStatus, statusCode),
+			logField(LogFieldResponseCode, apiResponse.ResponseCode),
+			logField("message", apiResponse.Message),
+		)
 
 		return "", apiResponse.ToError(
 			"Request to create Static Route failed with status code %d (%s): %s",
@@ -202,10 +207,10 @@ func (client *Client) ListStaticRoute(paging *Paging) (staticRoutes *StaticRoute
 
 	requestURI := fmt.Sprintf("%s/network/staticRoute?%s",
 		url.QueryEscape(organizationID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
 
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -245,11 +250,11 @@ func (client *Client) ListStaticRouteSystem(paging *Paging, networkDomainId stri
 
 	requestURI := fmt.Sprintf("%s/network/staticRoute?%s&networkDomainId=%s&type=SYSTEM",
 		url.QueryEscape(organizationID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 		url.QueryEscape(networkDomainId),
 	)
 
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +323,7 @@ func (client *Client) GetStaticRouteByName(name string, domainId string) (static
 		url.QueryEscape(domainId),
 	)
 
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -355,7 +360,7 @@ func (client *Client) GetStaticRouteByName(name string, domainId string) (static
 
 // Get static route by ID
 func (client *Client) GetStaticRoute(id string) (staticRoute *StaticRoute, err error) {
-	log.Printf("GetStaticRoute with ID: %s", id)
+	client.logDebug("GetStaticRoute", logField(LogFieldResourceID, id))
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
@@ -366,7 +371,7 @@ func (client *Client) GetStaticRoute(id string) (staticRoute *StaticRoute, err e
 		url.QueryEscape(id),
 	)
 
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -403,13 +408,13 @@ func (client *Client) DeleteStaticRoute(id string) (err error) {
 	if err != nil {
 		return err
 	}
-	log.Printf("DeleteStaticRoute with ID: %s  organization:%s", id, organizationID)
+	client.logDebug("DeleteStaticRoute", logField(LogFieldResourceID, id), logField("organization_id", organizationID))
 
 	requestURI := fmt.Sprintf("%s/network/deleteStaticRoute",
 		url.QueryEscape(organizationID),
 	)
 
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &deleteStaticRoute{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteStaticRoute{id})
 	if err != nil {
 		return err
 	}
@@ -443,7 +448,10 @@ func (client *Client) RestoreStaticRoute(networkDomainId string) (err error) {
 	requestURI := fmt.Sprintf("%s/network/restoreStaticRoutes",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV24(requestURI, http.MethodPost, &restoreStaticRoute{networkDomainId})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &restoreStaticRoute{networkDomainId})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
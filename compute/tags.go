@@ -107,7 +107,7 @@ func (client *Client) GetAssetTagsByType(assetType string, datacenterID string,
 			url.QueryEscape(datacenterID),
 		)
 	}
-	request, err := client.newRequestV25(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2ForOperation("tag/tag#byType", requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +153,7 @@ func (client *Client) GetAssetTags(assetID string, assetType string, paging *Pag
 		url.QueryEscape(assetType),
 		paging.toQueryParameters(),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +190,7 @@ func (client *Client) ApplyAssetTags(assetID string, assetType string, tags ...T
 	requestURI := fmt.Sprintf("%s/tag/applyTags",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodPost, &applyTags{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &applyTags{
 		AssetID:   assetID,
 		AssetType: assetType,
 		Tags:      tags,
@@ -216,7 +216,7 @@ func (client *Client) RemoveAssetTags(assetID string, assetType string, tagNames
 	requestURI := fmt.Sprintf("%s/tag/removeTags",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV213(requestURI, http.MethodPost, &removeTags{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &removeTags{
 		AssetID:   assetID,
 		AssetType: assetType,
 		TagNames:  tagNames,
@@ -240,11 +240,11 @@ func (client *Client) GetTagKey(id string) (tagKey *TagKey, err error) {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/tags/tagKey/%s",
+	requestURI := fmt.Sprintf("%s/tag/tagKey/%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -288,7 +288,7 @@ func (client *Client) ListTagKeys(paging *Paging) (tagKeys *TagKeys, err error)
 		url.QueryEscape(organizationID),
 		paging.toQueryParameters(),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -315,6 +315,17 @@ func (client *Client) ListTagKeys(paging *Paging) (tagKeys *TagKeys, err error)
 	return tagKeys, err
 }
 
+// AllTagKeys retrieves all tag keys (across all pages of results).
+func (client *Client) AllTagKeys() (tagKeys []TagKey, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListTagKeys(paging)
+	}, func(page PagedResults) {
+		tagKeys = append(tagKeys, page.(*TagKeys).Items...)
+	})
+
+	return
+}
+
 // CreateTagKey creates a new tag key.
 func (client *Client) CreateTagKey(name string, description string, isValueRequired bool, displayOnReports bool) (tagKeyID string, err error) {
 	organizationID, err := client.getOrganizationID()
@@ -325,12 +336,15 @@ func (client *Client) CreateTagKey(name string, description string, isValueRequi
 	requestURI := fmt.Sprintf("%s/tag/createTagKey",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodPost, &tagKey{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &tagKey{
 		Name:             name,
 		Description:      description,
 		IsValueRequired:  isValueRequired,
 		DisplayOnReports: displayOnReports,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -363,9 +377,12 @@ func (client *Client) DeleteTagKey(id string) error {
 	requestURI := fmt.Sprintf("%s/tag/deleteTagKey",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV25(requestURI, http.MethodPost,
+	request, err := client.newRequestV2(requestURI, http.MethodPost,
 		&deleteTagKey{id},
 	)
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
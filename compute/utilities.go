@@ -17,6 +17,14 @@ func intToPtr(value int) *int {
 	return &value
 }
 
+func ptrToString(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
 // Get the request body, replacing it with a copy of the original
 func getRequestBody(request *http.Request) (requestBody []byte, err error) {
 	if request.Body != nil {
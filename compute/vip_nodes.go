@@ -97,6 +97,11 @@ func (node *VIPNode) GetState() string {
 	return node.State
 }
 
+// GetProgress returns the node's current progress (if any).
+func (node *VIPNode) GetProgress() string {
+	return node.Progress
+}
+
 // IsDeleted determines whether the node has been deleted (is nil).
 func (node *VIPNode) IsDeleted() bool {
 	return node == nil
@@ -189,9 +194,9 @@ func (client *Client) ListVIPNodesInNetworkDomain(networkDomainID string, paging
 	requestURI := fmt.Sprintf("%s/networkDomainVip/node?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -221,6 +226,17 @@ func (client *Client) ListVIPNodesInNetworkDomain(networkDomainID string, paging
 	return nodes, nil
 }
 
+// AllVIPNodesInNetworkDomain retrieves all VIP nodes in the specified network domain (across all pages of results).
+func (client *Client) AllVIPNodesInNetworkDomain(networkDomainID string) (nodes []VIPNode, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListVIPNodesInNetworkDomain(networkDomainID, paging)
+	}, func(page PagedResults) {
+		nodes = append(nodes, page.(*VIPNodes).Items...)
+	})
+
+	return
+}
+
 // GetVIPNode retrieves the VIP node with the specified Id.
 // Returns nil if no VIP node is found with the specified Id.
 func (client *Client) GetVIPNode(id string) (node *VIPNode, err error) {
@@ -233,7 +249,7 @@ func (client *Client) GetVIPNode(id string) (node *VIPNode, err error) {
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +293,10 @@ func (client *Client) CreateVIPNode(nodeConfiguration NewVIPNodeConfiguration) (
 	requestURI := fmt.Sprintf("%s/networkDomainVip/createNode",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &nodeConfiguration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &nodeConfiguration)
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -314,7 +333,10 @@ func (client *Client) EditVIPNode(id string, nodeConfiguration EditVIPNodeConfig
 	requestURI := fmt.Sprintf("%s/networkDomainVip/editNode",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, editNodeConfiguration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, editNodeConfiguration)
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -343,7 +365,10 @@ func (client *Client) DeleteVIPNode(id string) (err error) {
 	requestURI := fmt.Sprintf("%s/networkDomainVip/deleteNode",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &deleteVIPNode{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteVIPNode{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -56,9 +56,9 @@ func (client *Client) ListVIPPoolMembers(poolID string, paging *Paging) (members
 	requestURI := fmt.Sprintf("%s/networkDomainVip/poolMember?poolId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(poolID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -98,9 +98,9 @@ func (client *Client) ListVIPPoolMembershipsInNetworkDomain(networkDomainID stri
 	requestURI := fmt.Sprintf("%s/networkDomainVip/poolMember?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +142,7 @@ func (client *Client) GetVIPPoolMember(id string) (member *VIPPoolMember, err er
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -187,12 +187,15 @@ func (client *Client) AddVIPPoolMember(poolID string, nodeID string, status stri
 	requestURI := fmt.Sprintf("%s/networkDomainVip/addPoolMember",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &addPoolMember{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &addPoolMember{
 		PoolID: poolID,
 		NodeID: nodeID,
 		Status: status,
 		Port:   port,
 	})
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -227,10 +230,13 @@ func (client *Client) EditVIPPoolMember(id string, status string) error {
 	requestURI := fmt.Sprintf("%s/networkDomainVip/editPoolMember",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &editPoolMember{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &editPoolMember{
 		ID:     id,
 		Status: status,
 	})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -258,7 +264,10 @@ func (client *Client) RemoveVIPPoolMember(id string) error {
 	requestURI := fmt.Sprintf("%s/networkDomainVip/removePoolMember",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &removePoolMember{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &removePoolMember{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -170,9 +170,9 @@ func (client *Client) ListVIPPoolsInNetworkDomain(networkDomainID string, paging
 	requestURI := fmt.Sprintf("%s/networkDomainVip/pool?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +202,17 @@ func (client *Client) ListVIPPoolsInNetworkDomain(networkDomainID string, paging
 	return pools, nil
 }
 
+// AllVIPPoolsInNetworkDomain retrieves all VIP pools in the specified network domain (across all pages of results).
+func (client *Client) AllVIPPoolsInNetworkDomain(networkDomainID string) (pools []VIPPool, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListVIPPoolsInNetworkDomain(networkDomainID, paging)
+	}, func(page PagedResults) {
+		pools = append(pools, page.(*VIPPools).Items...)
+	})
+
+	return
+}
+
 // GetVIPPool retrieves the VIP pool with the specified Id.
 // Returns nil if no VIP pool is found with the specified Id.
 func (client *Client) GetVIPPool(id string) (pool *VIPPool, err error) {
@@ -214,7 +225,7 @@ func (client *Client) GetVIPPool(id string) (pool *VIPPool, err error) {
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -250,43 +261,67 @@ func (client *Client) GetVIPPool(id string) (pool *VIPPool, err error) {
 // CreateVIPPool creates a new VIP pool.
 // Returns the Id of the new pool.
 func (client *Client) CreateVIPPool(poolConfiguration NewVIPPoolConfiguration) (poolID string, err error) {
-	organizationID, err := client.getOrganizationID()
+	operation, err := client.CreateVIPPoolAsync(poolConfiguration)
 	if err != nil {
 		return "", err
 	}
 
+	return operation.ResourceID, nil
+}
+
+// CreateVIPPoolAsync is equivalent to CreateVIPPool, but returns an Operation that can be used to wait for the create operation to complete.
+//
+// CloudControl completes this operation synchronously, so the returned Operation has already completed.
+func (client *Client) CreateVIPPoolAsync(poolConfiguration NewVIPPoolConfiguration) (operation *Operation, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
 	requestURI := fmt.Sprintf("%s/networkDomainVip/createPool",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &poolConfiguration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &poolConfiguration)
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeOK {
-		return "", apiResponse.ToError("Request to create VIP pool '%s' failed with status code %d (%s): %s", poolConfiguration.Name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to create VIP pool '%s' failed with status code %d (%s): %s", poolConfiguration.Name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
 	// Expected: "info" { "name": "poolId", "value": "the-Id-of-the-new-pool" }
 	poolIDMessage := apiResponse.GetFieldMessage("poolId")
 	if poolIDMessage == nil {
-		return "", apiResponse.ToError("Received an unexpected response (missing 'poolId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Received an unexpected response (missing 'poolId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return *poolIDMessage, nil
+	return client.newCompletedOperation(ResourceTypeVIPPool, *poolIDMessage, "Create", apiResponse), nil
 }
 
 // EditVIPPool updates an existing VIP pool.
 func (client *Client) EditVIPPool(id string, poolConfiguration EditVIPPoolConfiguration) error {
+	_, err := client.EditVIPPoolAsync(id, poolConfiguration)
+
+	return err
+}
+
+// EditVIPPoolAsync is equivalent to EditVIPPool, but returns an Operation that can be used to wait for the edit operation to complete.
+//
+// CloudControl completes this operation synchronously, so the returned Operation has already completed.
+func (client *Client) EditVIPPoolAsync(id string, poolConfiguration EditVIPPoolConfiguration) (operation *Operation, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	editPoolConfiguration := &poolConfiguration
@@ -295,49 +330,64 @@ func (client *Client) EditVIPPool(id string, poolConfiguration EditVIPPoolConfig
 	requestURI := fmt.Sprintf("%s/networkDomainVip/editPool",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, editPoolConfiguration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, editPoolConfiguration)
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if statusCode != http.StatusOK {
-		return apiResponse.ToError("Request to edit VIP pool '%s' failed with status code %d (%s): %s", poolConfiguration.ID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to edit VIP pool '%s' failed with status code %d (%s): %s", poolConfiguration.ID, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return nil
+	return client.newCompletedOperation(ResourceTypeVIPPool, id, "Edit", apiResponse), nil
 }
 
 // DeleteVIPPool deletes an existing VIP pool.
 // Returns an error if the operation was not successful.
 func (client *Client) DeleteVIPPool(id string) (err error) {
+	_, err = client.DeleteVIPPoolAsync(id)
+
+	return err
+}
+
+// DeleteVIPPoolAsync is equivalent to DeleteVIPPool, but returns an Operation that can be used to wait for the delete operation to complete.
+//
+// CloudControl completes this operation synchronously, so the returned Operation has already completed.
+func (client *Client) DeleteVIPPoolAsync(id string) (operation *Operation, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/networkDomainVip/deletePool",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodPost, &deleteVIPPool{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteVIPPool{id})
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeOK {
-		return apiResponse.ToError("Request to delete VIP pool '%s' failed with unexpected status code %d (%s): %s", id, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to delete VIP pool '%s' failed with unexpected status code %d (%s): %s", id, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return nil
+	return client.newCompletedOperation(ResourceTypeVIPPool, id, "Delete", apiResponse), nil
 }
@@ -194,9 +194,9 @@ func (client *Client) ListVirtualListenersInNetworkDomain(networkDomainID string
 	requestURI := fmt.Sprintf("%s/networkDomainVip/virtualListener?networkDomainId=%s&%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -226,6 +226,17 @@ func (client *Client) ListVirtualListenersInNetworkDomain(networkDomainID string
 	return listeners, nil
 }
 
+// AllVirtualListenersInNetworkDomain retrieves all virtual listeners in the specified network domain (across all pages of results).
+func (client *Client) AllVirtualListenersInNetworkDomain(networkDomainID string) (listeners []VirtualListener, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListVirtualListenersInNetworkDomain(networkDomainID, paging)
+	}, func(page PagedResults) {
+		listeners = append(listeners, page.(*VirtualListeners).Items...)
+	})
+
+	return
+}
+
 // GetVirtualListener retrieves the virtual listener with the specified Id.
 // Returns nil if no virtual listener is found with the specified Id.
 func (client *Client) GetVirtualListener(id string) (listener *VirtualListener, err error) {
@@ -238,7 +249,7 @@ func (client *Client) GetVirtualListener(id string) (listener *VirtualListener,
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +293,10 @@ func (client *Client) CreateVirtualListener(listenerConfiguration NewVirtualList
 	requestURI := fmt.Sprintf("%s/networkDomainVip/createVirtualListener",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &listenerConfiguration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &listenerConfiguration)
+	if err != nil {
+		return "", err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return "", err
@@ -319,7 +333,10 @@ func (client *Client) EditVirtualListener(id string, listenerConfiguration EditV
 	requestURI := fmt.Sprintf("%s/networkDomainVip/editVirtualListener",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, editListenerConfiguration)
+	request, err := client.newRequestV2(requestURI, http.MethodPost, editListenerConfiguration)
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -348,7 +365,10 @@ func (client *Client) DeleteVirtualListener(id string) (err error) {
 	requestURI := fmt.Sprintf("%s/networkDomainVip/deleteVirtualListener",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV26(requestURI, http.MethodPost, &deleteVirtualListener{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &deleteVirtualListener{id})
+	if err != nil {
+		return err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
 		return err
@@ -174,7 +174,7 @@ func (client *Client) GetVLAN(id string) (vlan *VLAN, err error) {
 		url.QueryEscape(organizationID),
 		url.QueryEscape(id),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +216,7 @@ func (client *Client) GetVLANByName(name string, networkDomainID string) (*VLAN,
 		url.QueryEscape(name),
 		url.QueryEscape(networkDomainID),
 	)
-	request, err := client.newRequestV22(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2ForOperation("network/vlan#byName", requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -254,19 +254,31 @@ func (client *Client) GetVLANByName(name string, networkDomainID string) (*VLAN,
 }
 
 // ListVLANs retrieves a list of all VLANs in the specified network domain.
-// TODO: Support filtering and sorting.
 func (client *Client) ListVLANs(networkDomainID string, paging *Paging) (vlans *VLANs, err error) {
+	return client.ListVLANsWithFilter(networkDomainID, nil, paging)
+}
+
+// ListVLANsWithFilter retrieves a list of the VLANs in the specified network domain that match the specified filter criteria.
+//
+// filter (if not nil) specifies criteria used to filter and sort the results.
+func (client *Client) ListVLANsWithFilter(networkDomainID string, filter *Filter, paging *Paging) (vlans *VLANs, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
 		return nil, err
 	}
 
-	requestURI := fmt.Sprintf("%s/network/vlan?networkDomainId=%s&%s",
+	filterParameters, err := filter.toQueryParameters(FilterFieldNetworkDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	requestURI := fmt.Sprintf("%s/network/vlan?networkDomainId=%s&%s%s",
 		url.QueryEscape(organizationID),
 		url.QueryEscape(networkDomainID),
-		paging.EnsurePaging().toQueryParameters(),
+		client.ensurePaging(paging).toQueryParameters(),
+		filterParameters,
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodGet, nil)
+	request, err := client.newRequestV2(requestURI, http.MethodGet, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -293,14 +305,41 @@ func (client *Client) ListVLANs(networkDomainID string, paging *Paging) (vlans *
 	return vlans, err
 }
 
+// AllVLANs retrieves all VLANs in the specified network domain (across all pages of results).
+func (client *Client) AllVLANs(networkDomainID string) (vlans []VLAN, err error) {
+	return client.AllVLANsWithFilter(networkDomainID, nil)
+}
+
+// AllVLANsWithFilter retrieves all VLANs in the specified network domain that match the specified filter criteria (across all pages of results).
+func (client *Client) AllVLANsWithFilter(networkDomainID string, filter *Filter) (vlans []VLAN, err error) {
+	err = client.collectPages(func(paging *Paging) (PagedResults, error) {
+		return client.ListVLANsWithFilter(networkDomainID, filter, paging)
+	}, func(page PagedResults) {
+		vlans = append(vlans, page.(*VLANs).VLANs...)
+	})
+
+	return
+}
+
 // DeployVLAN deploys a new VLAN into a network domain.
 func (client *Client) DeployVLAN(networkDomainID string, name string, description string, ipv4BaseAddress string,
 	ipv4PrefixSize int, attachedVlanGatewayAddressing string, detachedVlanIpv4GatewayAddress string) (vlanID string, err error) {
-	organizationID, err := client.getOrganizationID()
+	operation, err := client.DeployVLANAsync(networkDomainID, name, description, ipv4BaseAddress, ipv4PrefixSize, attachedVlanGatewayAddressing, detachedVlanIpv4GatewayAddress)
 	if err != nil {
 		return "", err
 	}
 
+	return operation.ResourceID, nil
+}
+
+// DeployVLANAsync is equivalent to DeployVLAN, but returns an Operation that can be used to wait for the deploy operation to complete.
+func (client *Client) DeployVLANAsync(networkDomainID string, name string, description string, ipv4BaseAddress string,
+	ipv4PrefixSize int, attachedVlanGatewayAddressing string, detachedVlanIpv4GatewayAddress string) (operation *Operation, err error) {
+	organizationID, err := client.getOrganizationID()
+	if err != nil {
+		return nil, err
+	}
+
 	requestURI := fmt.Sprintf("%s/network/deployVlan",
 		url.QueryEscape(organizationID),
 	)
@@ -319,7 +358,7 @@ func (client *Client) DeployVLAN(networkDomainID string, name string, descriptio
 			AttachedVlan:    attachedVlan,
 		}
 
-		request, err = client.newRequestV29(requestURI, http.MethodPost, &vlan)
+		request, err = client.newRequestV2(requestURI, http.MethodPost, &vlan)
 
 	} else if detachedVlanIpv4GatewayAddress != "" {
 		detachedVlan := DetachedVlanGateway{detachedVlanIpv4GatewayAddress}
@@ -332,7 +371,7 @@ func (client *Client) DeployVLAN(networkDomainID string, name string, descriptio
 			DetachedVlan:    detachedVlan,
 		}
 
-		request, err = client.newRequestV29(requestURI, http.MethodPost, &vlan)
+		request, err = client.newRequestV2(requestURI, http.MethodPost, &vlan)
 	} else {
 		attachedVlan := AttachedVlanGateway{"LOW"}
 		vlan := &DeployAttachedVLAN{
@@ -344,92 +383,117 @@ func (client *Client) DeployVLAN(networkDomainID string, name string, descriptio
 			AttachedVlan:    attachedVlan,
 		}
 
-		request, err = client.newRequestV29(requestURI, http.MethodPost, &vlan)
+		request, err = client.newRequestV2(requestURI, http.MethodPost, &vlan)
 
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeInProgress {
-		return "", apiResponse.ToError("Request to deploy VLAN '%s' failed with status code %d (%s): %s", name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to deploy VLAN '%s' failed with status code %d (%s): %s", name, statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
 	// Expected: "info" { "name": "vlanId", "value": "the-Id-of-the-new-VLAN" }
 	vlanIDMessage := apiResponse.GetFieldMessage("vlanId")
 	if vlanIDMessage == nil {
-		return "", apiResponse.ToError("Received an unexpected response (missing 'vlanId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Received an unexpected response (missing 'vlanId') with status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return *vlanIDMessage, nil
+	return client.newPendingOperation(ResourceTypeVLAN, *vlanIDMessage, "Deploy", ResourceStatusPendingAdd, false, apiResponse), nil
 }
 
 // EditVLAN updates an existing VLAN.
 // Pass an empty string for any field to retain its existing value.
 // Returns an error if the operation was not successful.
 func (client *Client) EditVLAN(id string, name *string, description *string) (err error) {
+	_, err = client.EditVLANAsync(id, name, description)
+
+	return err
+}
+
+// EditVLANAsync is equivalent to EditVLAN, but returns an Operation that can be used to wait for the edit operation to complete.
+//
+// CloudControl completes this operation synchronously, so the returned Operation has already completed.
+func (client *Client) EditVLANAsync(id string, name *string, description *string) (operation *Operation, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/network/editVlan",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &EditVLAN{
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &EditVLAN{
 		ID:          id,
 		Name:        name,
 		Description: description,
 	})
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeOK {
-		return apiResponse.ToError("Request to edit VLAN failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to edit VLAN failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return nil
+	return client.newCompletedOperation(ResourceTypeVLAN, id, "Edit", apiResponse), nil
 }
 
 // DeleteVLAN deletes an existing VLAN.
 // Returns an error if the operation was not successful.
 func (client *Client) DeleteVLAN(id string) (err error) {
+	_, err = client.DeleteVLANAsync(id)
+
+	return err
+}
+
+// DeleteVLANAsync is equivalent to DeleteVLAN, but returns an Operation that can be used to wait for the delete operation to complete.
+func (client *Client) DeleteVLANAsync(id string) (operation *Operation, err error) {
 	organizationID, err := client.getOrganizationID()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	requestURI := fmt.Sprintf("%s/network/deleteVlan",
 		url.QueryEscape(organizationID),
 	)
-	request, err := client.newRequestV29(requestURI, http.MethodPost, &DeleteVLAN{id})
+	request, err := client.newRequestV2(requestURI, http.MethodPost, &DeleteVLAN{id})
+	if err != nil {
+		return nil, err
+	}
 	responseBody, statusCode, err := client.executeRequest(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	apiResponse, err := readAPIResponseAsJSON(responseBody, statusCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if apiResponse.ResponseCode != ResponseCodeInProgress {
-		return apiResponse.ToError("Request to delete VLAN failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
+		return nil, apiResponse.ToError("Request to delete VLAN failed with unexpected status code %d (%s): %s", statusCode, apiResponse.ResponseCode, apiResponse.Message)
 	}
 
-	return nil
+	return client.newPendingOperation(ResourceTypeVLAN, id, "Delete", ResourceStatusPendingDelete, true, apiResponse), nil
 }
@@ -2,7 +2,6 @@ package compute
 
 import (
 	"fmt"
-	"log"
 	"time"
 )
 
@@ -50,29 +49,38 @@ func (client *Client) WaitForServerBackupStatus(serverID string, actionDescripti
 	waitTimeout := time.NewTimer(timeout)
 	defer waitTimeout.Stop()
 
-	pollTicker := time.NewTicker(5 * time.Second)
-	defer pollTicker.Stop()
+	waiter := client.getWaiter()
+	startTime := time.Now()
+
+	operationDescription := fmt.Sprintf("Wait for %s of server '%s'",
+		actionDescription,
+		serverID,
+	)
+
+	for iteration := 1; ; iteration++ {
+		pollTimer := time.NewTimer(waiter.pollInterval(iteration))
 
-	for {
 		select {
+		case <-client.Context().Done():
+			pollTimer.Stop()
+
+			return nil, client.checkCancellation(operationDescription)
+
 		case <-waitTimeout.C:
-			return nil, fmt.Errorf("Timed out after waiting %d seconds for %s of server '%s' to complete",
-				timeout/time.Second,
-				actionDescription,
-				serverID,
-			)
-
-		case <-pollTicker.C:
-			log.Printf("Polling status for server '%s'...", serverID)
-			if client.isCancellationRequested {
-				log.Printf("Client indicates that cancellation of pending requests has been requested.")
-
-				return nil, &OperationCancelledError{
-					OperationDescription: fmt.Sprintf("Wait for %s of server '%s'",
-						actionDescription,
-						serverID,
-					),
-				}
+			pollTimer.Stop()
+
+			return nil, &WaitTimeoutError{
+				ResourceType:      ResourceTypeServer,
+				ResourceID:        serverID,
+				ActionDescription: actionDescription,
+				Timeout:           timeout,
+			}
+
+		case <-pollTimer.C:
+			client.logDebug("Polling backup status for server...", logField(LogFieldResourceID, serverID))
+			err = client.checkCancellation(operationDescription)
+			if err != nil {
+				return nil, err
 			}
 
 			server, err := client.GetServer(serverID)
@@ -93,26 +101,25 @@ func (client *Client) WaitForServerBackupStatus(serverID string, actionDescripti
 
 			switch server.Backup.State {
 			case ResourceStatusNormal:
-				log.Printf("%s of server '%s' has successfully completed.", actionDescription, serverID)
+				client.logInfo(fmt.Sprintf("%s of server has successfully completed.", actionDescription), logField(LogFieldResourceID, serverID))
 
 				return server, nil
 
-			case ResourceStatusPendingAdd:
-				log.Printf("%s of server '%s' is still in progress...", actionDescription, serverID)
-
-				continue
-			case ResourceStatusPendingChange:
-				log.Printf("%s of server '%s' is still in progress...", actionDescription, serverID)
-
-				continue
-			case ResourceStatusPendingDelete:
-				log.Printf("%s of server '%s' is still in progress...", actionDescription, serverID)
+			case ResourceStatusPendingAdd, ResourceStatusPendingChange, ResourceStatusPendingDelete:
+				client.logInfo(fmt.Sprintf("%s of server is still in progress...", actionDescription), logField(LogFieldResourceID, serverID), logField(LogFieldState, server.Backup.State))
+				waiter.reportProgress(ResourceTypeServer, serverID, actionDescription, iteration, startTime, server, server.Backup.State)
 
 				continue
 			default:
-				log.Printf("Unexpected backup status for server '%s' ('%s').", serverID, server.Backup.State)
-
-				return nil, fmt.Errorf("%s failed for server '%s' ('%s'): encountered unexpected state '%s'", actionDescription, serverID, server.Name, server.Backup.State)
+				client.logError("Unexpected backup status for server.", logField(LogFieldResourceID, serverID), logField(LogFieldState, server.Backup.State))
+
+				return nil, &ResourceFailedError{
+					ResourceType:      ResourceTypeServer,
+					ResourceID:        serverID,
+					ResourceName:      server.Name,
+					ActionDescription: actionDescription,
+					State:             server.Backup.State,
+				}
 			}
 		}
 	}
@@ -130,46 +137,56 @@ func (client *Client) waitForResourceStatus(resourceType ResourceType, id string
 	waitTimeout := time.NewTimer(timeout)
 	defer waitTimeout.Stop()
 
-	pollTicker := time.NewTicker(5 * time.Second)
-	defer pollTicker.Stop()
+	waiter := client.getWaiter()
+	startTime := time.Now()
 
 	resourceDescription, err := GetResourceDescription(resourceType)
 	if err != nil {
 		return nil, err
 	}
 
-	for {
+	operationDescription := fmt.Sprintf("Wait for %s of %s '%s'",
+		actionDescription,
+		resourceDescription,
+		id,
+	)
+
+	for iteration := 1; ; iteration++ {
+		pollTimer := time.NewTimer(waiter.pollInterval(iteration))
+
 		select {
+		case <-client.Context().Done():
+			pollTimer.Stop()
+
+			return nil, client.checkCancellation(operationDescription)
+
 		case <-waitTimeout.C:
-			return nil, fmt.Errorf("Timed out after waiting %d seconds for %s of %s '%s' to complete",
-				timeout/time.Second,
-				actionDescription,
-				resourceDescription,
-				id,
-			)
-
-		case <-pollTicker.C:
-			log.Printf("Polling status for %s '%s'...", resourceDescription, id)
-			if client.isCancellationRequested {
-				log.Printf("Client indicates that cancellation of pending requests has been requested.")
-
-				return nil, &OperationCancelledError{
-					OperationDescription: fmt.Sprintf("Wait for %s of %s '%s'",
-						actionDescription,
-						resourceDescription,
-						id,
-					),
-				}
+			pollTimer.Stop()
+
+			return nil, &WaitTimeoutError{
+				ResourceType:      resourceType,
+				ResourceID:        id,
+				ActionDescription: actionDescription,
+				Timeout:           timeout,
 			}
 
-			resource, err := client.GetResource(id, resourceType)
+		case <-pollTimer.C:
+			client.logDebug("Polling resource status...", logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, id))
+			err = client.checkCancellation(operationDescription)
+			if err != nil {
+				return nil, err
+			}
+
+			pollClient, finishPoll := client.startPoll(resourceDescription, id, iteration)
+			resource, err := pollClient.GetResource(id, resourceType)
+			finishPoll(resource, err)
 			if err != nil {
 				return nil, err
 			}
 
 			if resource == nil || resource.IsDeleted() {
 				if isDelete {
-					log.Printf("%s '%s' has been successfully deleted.", resourceDescription, id)
+					client.logInfo("Resource has been successfully deleted.", logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, id))
 
 					return nil, nil
 				}
@@ -179,26 +196,19 @@ func (client *Client) waitForResourceStatus(resourceType ResourceType, id string
 
 			switch resource.GetState() {
 			case ResourceStatusNormal:
-				log.Printf("%s of %s '%s' has successfully completed.", actionDescription, resourceDescription, id)
+				client.logInfo(fmt.Sprintf("%s has successfully completed.", actionDescription), logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, id))
 
 				return resource, nil
 
-			case ResourceStatusPendingAdd:
-				log.Printf("%s of %s '%s' is still in progress...", actionDescription, resourceDescription, id)
-
-				continue
-			case ResourceStatusPendingChange:
-				log.Printf("%s of %s '%s' is still in progress...", actionDescription, resourceDescription, id)
-
-				continue
-			case ResourceStatusPendingDelete:
-				log.Printf("%s of %s '%s' is still in progress...", actionDescription, resourceDescription, id)
+			case ResourceStatusPendingAdd, ResourceStatusPendingChange, ResourceStatusPendingDelete:
+				client.logInfo(fmt.Sprintf("%s is still in progress...", actionDescription), logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, id), logField(LogFieldState, resource.GetState()))
+				waiter.reportProgress(resourceType, id, actionDescription, iteration, startTime, resource, resource.GetState())
 
 				continue
 			default:
-				log.Printf("Unexpected status for %s '%s' ('%s').", resourceDescription, id, resource.GetState())
+				client.logError("Unexpected resource status.", logField(LogFieldResourceType, resourceDescription), logField(LogFieldResourceID, id), logField(LogFieldState, resource.GetState()))
 
-				return nil, fmt.Errorf("%s failed for %s '%s' ('%s'): encountered unexpected state '%s'", actionDescription, resourceDescription, id, resource.GetName(), resource.GetState())
+				return nil, newResourceFailedError(resourceType, id, resource, actionDescription)
 			}
 		}
 	}
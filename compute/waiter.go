@@ -0,0 +1,251 @@
+package compute
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Waiter configures how a Client polls a resource's status while waiting for a pending operation to complete (e.g. WaitForDeploy, or Operation.Wait).
+//
+// The interval between polls starts at InitialPollInterval and is increased by Multiplier after each poll, up to MaxPollInterval.
+type Waiter struct {
+	// The interval before the first poll.
+	InitialPollInterval time.Duration
+
+	// The maximum interval between polls (0 for no maximum).
+	MaxPollInterval time.Duration
+
+	// The factor by which the poll interval is increased after each poll (values less than 1 are treated as 1, i.e. no backoff).
+	Multiplier float64
+
+	// The fraction (between 0 and 1) of each poll interval that is randomised, to avoid synchronised polling from multiple clients.
+	Jitter float64
+
+	// An optional function that is called after each poll (while the resource's pending operation is still in progress).
+	OnProgress func(progress WaitProgress)
+}
+
+// NewWaiter creates a new Waiter with default settings (poll every 5 seconds, without backoff or jitter).
+func NewWaiter() *Waiter {
+	return &Waiter{
+		InitialPollInterval: 5 * time.Second,
+		MaxPollInterval:     5 * time.Second,
+		Multiplier:          1.0,
+	}
+}
+
+// NewBackoffWaiter creates a new Waiter that polls after initialPollInterval, doubling the interval after each poll (up to maxPollInterval) with 20% jitter.
+func NewBackoffWaiter(initialPollInterval time.Duration, maxPollInterval time.Duration) *Waiter {
+	return &Waiter{
+		InitialPollInterval: initialPollInterval,
+		MaxPollInterval:     maxPollInterval,
+		Multiplier:          2.0,
+		Jitter:              0.2,
+	}
+}
+
+// WaitProgress describes the progress of a pending operation, as observed by polling the resource's status.
+type WaitProgress struct {
+	// The type of resource being waited on.
+	ResourceType ResourceType
+
+	// The Id of the resource being waited on.
+	ResourceID string
+
+	// A short description of the operation being waited on (e.g. "Deploy").
+	ActionDescription string
+
+	// The poll iteration (starting at 1).
+	Iteration int
+
+	// The time elapsed since waiting started.
+	Elapsed time.Duration
+
+	// The resource, as it was when polled.
+	Resource Resource
+
+	// The resource's state (e.g. ResourceStatusPendingAdd), or the server's backup status for WaitForServerBackupStatus.
+	State string
+
+	// The resource's current progress (if reported by the resource type, e.g. NetworkDomain.Progress or VIPNode.Progress).
+	Progress string
+}
+
+// ResourceWithProgress represents a resource that reports the progress of its pending operation (if any).
+type ResourceWithProgress interface {
+	Resource
+
+	// GetProgress retrieves the resource's current progress (if any).
+	GetProgress() string
+}
+
+// pollInterval calculates the interval before the specified poll iteration (starting at 1).
+func (waiter *Waiter) pollInterval(iteration int) time.Duration {
+	multiplier := waiter.Multiplier
+	if multiplier < 1.0 {
+		multiplier = 1.0
+	}
+
+	interval := float64(waiter.InitialPollInterval) * math.Pow(multiplier, float64(iteration-1))
+	if waiter.MaxPollInterval > 0 && interval > float64(waiter.MaxPollInterval) {
+		interval = float64(waiter.MaxPollInterval)
+	}
+
+	if waiter.Jitter > 0 {
+		jitter := math.Min(waiter.Jitter, 1.0)
+		interval -= interval * jitter * rand.Float64()
+	}
+
+	return time.Duration(interval)
+}
+
+// reportProgress calls the waiter's progress callback (if any).
+//
+// state is usually the resource's state, but may differ when waiting for some other status (e.g. WaitForServerBackupStatus).
+func (waiter *Waiter) reportProgress(resourceType ResourceType, id string, actionDescription string, iteration int, startTime time.Time, resource Resource, state string) {
+	if waiter.OnProgress == nil {
+		return
+	}
+
+	progress := WaitProgress{
+		ResourceType:      resourceType,
+		ResourceID:        id,
+		ActionDescription: actionDescription,
+		Iteration:         iteration,
+		Elapsed:           time.Since(startTime),
+		Resource:          resource,
+		State:             state,
+	}
+	if resourceWithProgress, ok := resource.(ResourceWithProgress); ok {
+		progress.Progress = resourceWithProgress.GetProgress()
+	}
+
+	waiter.OnProgress(progress)
+}
+
+// IsResourceFailedError determines if an error is a ResourceFailedError.
+func IsResourceFailedError(err error) bool {
+	_, isResourceFailedError := err.(*ResourceFailedError)
+
+	return isResourceFailedError
+}
+
+// ResourceFailedError is the error returned when waiting for a pending operation on a resource, if the resource enters a state that indicates the operation has failed (e.g. ResourceStatusFailedAdd).
+type ResourceFailedError struct {
+	// The type of resource that the operation affected.
+	ResourceType ResourceType
+
+	// The Id of the resource that the operation affected.
+	ResourceID string
+
+	// The name of the resource that the operation affected.
+	ResourceName string
+
+	// A short description of the operation (e.g. "Deploy").
+	ActionDescription string
+
+	// The resource's state (e.g. ResourceStatusFailedAdd or ResourceStatusRequiresSupport).
+	State string
+}
+
+// Error creates a string representation of the error.
+func (err *ResourceFailedError) Error() string {
+	resourceDescription, descriptionErr := GetResourceDescription(err.ResourceType)
+	if descriptionErr != nil {
+		resourceDescription = fmt.Sprintf("resource type %d", err.ResourceType)
+	}
+
+	if !err.IsFailureState() {
+		return fmt.Sprintf("%s failed for %s '%s' ('%s'): encountered unexpected state '%s'", err.ActionDescription, resourceDescription, err.ResourceID, err.ResourceName, err.State)
+	}
+
+	return fmt.Sprintf("%s failed for %s '%s' ('%s'): resource entered failure state '%s'", err.ActionDescription, resourceDescription, err.ResourceID, err.ResourceName, err.State)
+}
+
+// IsFailureState determines whether the resource's state is a well-known failure state (ResourceStatusFailedAdd, ResourceStatusFailedChange, ResourceStatusFailedDelete or ResourceStatusRequiresSupport), rather than an unrecognised state.
+func (err *ResourceFailedError) IsFailureState() bool {
+	switch err.State {
+	case ResourceStatusFailedAdd, ResourceStatusFailedChange, ResourceStatusFailedDelete, ResourceStatusRequiresSupport:
+		return true
+	default:
+		return false
+	}
+}
+
+var _ error = &ResourceFailedError{}
+
+// newResourceFailedError creates a new ResourceFailedError for a resource that has entered an unexpected state.
+func newResourceFailedError(resourceType ResourceType, id string, resource Resource, actionDescription string) *ResourceFailedError {
+	return &ResourceFailedError{
+		ResourceType:      resourceType,
+		ResourceID:        id,
+		ResourceName:      resource.GetName(),
+		ActionDescription: actionDescription,
+		State:             resource.GetState(),
+	}
+}
+
+// IsWaitTimeoutError determines if an error is a WaitTimeoutError.
+func IsWaitTimeoutError(err error) bool {
+	_, isWaitTimeoutError := err.(*WaitTimeoutError)
+
+	return isWaitTimeoutError
+}
+
+// WaitTimeoutError is the error returned when waiting for a pending operation on a resource, if the operation does not complete before the wait times out.
+type WaitTimeoutError struct {
+	// The type of resource that the operation affects.
+	ResourceType ResourceType
+
+	// The Id of the resource that the operation affects.
+	ResourceID string
+
+	// A short description of the operation (e.g. "Deploy").
+	ActionDescription string
+
+	// The length of time that was spent waiting for the operation to complete.
+	Timeout time.Duration
+}
+
+// Error creates a string representation of the error.
+func (err *WaitTimeoutError) Error() string {
+	resourceDescription, descriptionErr := GetResourceDescription(err.ResourceType)
+	if descriptionErr != nil {
+		resourceDescription = fmt.Sprintf("resource type %d", err.ResourceType)
+	}
+
+	return fmt.Sprintf("Timed out after waiting %d seconds for %s of %s '%s' to complete",
+		err.Timeout/time.Second,
+		err.ActionDescription,
+		resourceDescription,
+		err.ResourceID,
+	)
+}
+
+var _ error = &WaitTimeoutError{}
+
+// SetWaiter configures how the client polls a resource's status while waiting for a pending operation to complete.
+// Set waiter to nil to use the default settings (see NewWaiter).
+func (client *Client) SetWaiter(waiter *Waiter) {
+	client.waiter.Store(waiterHolder{waiter})
+}
+
+// getWaiter retrieves the client's waiter (or the default waiter, if none has been configured).
+func (client *Client) getWaiter() *Waiter {
+	holder, ok := client.waiter.Load().(waiterHolder)
+	if !ok || holder.waiter == nil {
+		return defaultWaiter
+	}
+
+	return holder.waiter
+}
+
+// The waiter used by clients that have not been configured with a waiter.
+var defaultWaiter = NewWaiter()
+
+// waiterHolder enables storage of a (possibly nil) Waiter in an atomic.Value.
+type waiterHolder struct {
+	waiter *Waiter
+}
@@ -0,0 +1,135 @@
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The poll interval increases by the waiter's multiplier, up to its maximum.
+func TestWaiter_PollInterval_Backoff(test *testing.T) {
+	expect := expect(test)
+
+	waiter := &Waiter{
+		InitialPollInterval: 1 * time.Second,
+		MaxPollInterval:     5 * time.Second,
+		Multiplier:          2.0,
+	}
+
+	expect.IsTrue("Iteration 1", waiter.pollInterval(1) == 1*time.Second)
+	expect.IsTrue("Iteration 2", waiter.pollInterval(2) == 2*time.Second)
+	expect.IsTrue("Iteration 3", waiter.pollInterval(3) == 4*time.Second)
+	expect.IsTrue("Iteration 4", waiter.pollInterval(4) == 5*time.Second)
+
+	waiter.Jitter = 0.5
+	for iteration := 1; iteration <= 10; iteration++ {
+		interval := waiter.pollInterval(iteration)
+		expect.IsTrue(fmt.Sprintf("Iteration %d (%s) with jitter is within range", iteration, interval), interval > 0 && interval <= 5*time.Second)
+	}
+
+	expect.IsTrue("Default waiter", NewWaiter().pollInterval(10) == 5*time.Second)
+}
+
+// The waiter's progress callback receives the resource's progress, and failure states are reported as a ResourceFailedError.
+func TestClient_WaitForDeploy_ProgressAndFailure(test *testing.T) {
+	expect := expect(test)
+
+	networkDomainID := "484174a2-ae74-4658-9e56-50fc90e086cf"
+
+	var stateLock sync.Mutex
+	getCount := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		stateLock.Lock()
+		defer stateLock.Unlock()
+
+		getCount++
+		state := ResourceStatusPendingAdd
+		progress := fmt.Sprintf("Step %d of 3", getCount)
+		if getCount >= 3 {
+			state = ResourceStatusRequiresSupport
+			progress = ""
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprintf(writer, `{"id": "%s", "name": "Production Network Domain", "state": "%s", "progress": "%s"}`, networkDomainID, state, progress)
+	}))
+	defer testServer.Close()
+
+	var progressLock sync.Mutex
+	var progressReports []WaitProgress
+	waiter := NewBackoffWaiter(5*time.Millisecond, 20*time.Millisecond)
+	waiter.OnProgress = func(progress WaitProgress) {
+		progressLock.Lock()
+		defer progressLock.Unlock()
+
+		progressReports = append(progressReports, progress)
+	}
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithWaiter(waiter),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	_, err := client.WaitForDeploy(ResourceTypeNetworkDomain, networkDomainID, 10*time.Second)
+	expect.IsTrue("IsResourceFailedError", IsResourceFailedError(err))
+
+	resourceFailedErr, ok := err.(*ResourceFailedError)
+	if !ok {
+		test.Fatalf("Expected *ResourceFailedError but got %#v", err)
+	}
+	expect.EqualsString("ResourceFailedError.ResourceID", networkDomainID, resourceFailedErr.ResourceID)
+	expect.EqualsString("ResourceFailedError.ResourceName", "Production Network Domain", resourceFailedErr.ResourceName)
+	expect.EqualsString("ResourceFailedError.ActionDescription", "Deploy", resourceFailedErr.ActionDescription)
+	expect.EqualsString("ResourceFailedError.State", ResourceStatusRequiresSupport, resourceFailedErr.State)
+	expect.IsTrue("ResourceFailedError.IsFailureState", resourceFailedErr.IsFailureState())
+
+	progressLock.Lock()
+	defer progressLock.Unlock()
+
+	expect.EqualsInt("Progress report count", 2, len(progressReports))
+	for index, progress := range progressReports {
+		expect.EqualsInt(fmt.Sprintf("Progress[%d].Iteration", index), index+1, progress.Iteration)
+		expect.EqualsString(fmt.Sprintf("Progress[%d].State", index), ResourceStatusPendingAdd, progress.State)
+		expect.EqualsString(fmt.Sprintf("Progress[%d].Progress", index), fmt.Sprintf("Step %d of 3", index+1), progress.Progress)
+		expect.IsTrue(fmt.Sprintf("Progress[%d].ResourceType", index), progress.ResourceType == ResourceTypeNetworkDomain)
+	}
+}
+
+// If the pending operation does not complete before the wait times out, a WaitTimeoutError is returned.
+func TestClient_WaitForChange_Timeout(test *testing.T) {
+	expect := expect(test)
+
+	serverID := "5a32d6e4-9707-4813-a269-56ab4d989f4d"
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprintf(writer, `{"id": "%s", "name": "server1", "state": "PENDING_CHANGE"}`, serverID)
+	}))
+	defer testServer.Close()
+
+	client := NewClientWithOptions("", "user1", "password",
+		WithBaseAddress(testServer.URL),
+		WithWaiter(&Waiter{InitialPollInterval: 5 * time.Millisecond}),
+	)
+	client.setAccount(&Account{
+		OrganizationID: "dummy-organization-id",
+	})
+
+	_, err := client.WaitForChange(ResourceTypeServer, serverID, "Shut down server", 50*time.Millisecond)
+	expect.IsTrue("IsWaitTimeoutError", IsWaitTimeoutError(err))
+
+	waitTimeoutErr, ok := err.(*WaitTimeoutError)
+	if !ok {
+		test.Fatalf("Expected *WaitTimeoutError but got %#v", err)
+	}
+	expect.EqualsString("WaitTimeoutError.ResourceID", serverID, waitTimeoutErr.ResourceID)
+	expect.EqualsString("WaitTimeoutError.ActionDescription", "Shut down server", waitTimeoutErr.ActionDescription)
+}
@@ -0,0 +1,522 @@
+package computetest
+
+import (
+	"encoding/binary"
+	"net"
+	"sort"
+
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute"
+)
+
+// Request body for deploying a network domain.
+type deployNetworkDomain struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Type         string `json:"type"`
+	DatacenterID string `json:"datacenterId"`
+}
+
+// Request body for editing a network domain.
+type editNetworkDomain struct {
+	ID          string  `json:"id"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Type        *string `json:"type"`
+}
+
+// Request body for deploying a VLAN.
+type deployVLAN struct {
+	NetworkDomainID string                       `json:"networkDomainId"`
+	Name            string                       `json:"name"`
+	Description     string                       `json:"description"`
+	IPv4BaseAddress string                       `json:"privateIpv4NetworkAddress"`
+	IPv4PrefixSize  int                          `json:"privateIpv4PrefixSize"`
+	AttachedVLAN    *compute.AttachedVlanGateway `json:"attachedVlan"`
+	DetachedVLAN    *compute.DetachedVlanGateway `json:"detachedVlan"`
+}
+
+// Request body for editing a firewall rule.
+type editFirewallRule struct {
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Request body for creating a NAT rule.
+type createNATRule struct {
+	NetworkDomainID   string  `json:"networkDomainId"`
+	InternalIPAddress string  `json:"internalIp"`
+	ExternalIPAddress *string `json:"externalIp"`
+}
+
+// Request body for adding a public IP block.
+type addPublicIPBlock struct {
+	NetworkDomainID string `json:"networkDomainId"`
+}
+
+func (server *Server) listNetworkDomains(request *apiRequest) *apiResponse {
+	return server.listResources(kindNetworkDomain, request)
+}
+
+func (server *Server) getNetworkDomain(request *apiRequest) *apiResponse {
+	return server.getResource(kindNetworkDomain, request)
+}
+
+func (server *Server) deployNetworkDomain(request *apiRequest) *apiResponse {
+	var deployRequest deployNetworkDomain
+	if errorResponse := request.readBody(&deployRequest); errorResponse != nil {
+		return errorResponse
+	}
+	if deployRequest.Name == "" {
+		return invalidInputData("A network domain name must be specified.")
+	}
+	if deployRequest.DatacenterID == "" {
+		deployRequest.DatacenterID = server.datacenterID
+	}
+	if errorResponse := server.ensureUniqueName(kindNetworkDomain, "", deployRequest.Name); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain := &compute.NetworkDomain{
+		ID:             server.nextID(),
+		Name:           deployRequest.Name,
+		Description:    deployRequest.Description,
+		Type:           deployRequest.Type,
+		NatIPv4Address: server.allocatePublicIPv4Addresses(1),
+		OutsideTransitVLANIPv4Subnet: compute.IPv4Range{
+			BaseAddress: "100.64.0.0",
+			PrefixSize:  24,
+		},
+		CreateTime:   server.createTime(),
+		DatacenterID: deployRequest.DatacenterID,
+	}
+	server.addResource(kindNetworkDomain, networkDomain.ID, "", networkDomain, &networkDomain.State, true, nil)
+
+	return inProgress("networkDomainId", networkDomain.ID, "Request to deploy network domain '%s' has been accepted.", networkDomain.Name)
+}
+
+func (server *Server) editNetworkDomain(request *apiRequest) *apiResponse {
+	var editRequest editNetworkDomain
+	if errorResponse := request.readBody(&editRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kindNetworkDomain, editRequest.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain := resource.data.(*compute.NetworkDomain)
+	if editRequest.Name != nil {
+		networkDomain.Name = *editRequest.Name
+	}
+	if editRequest.Description != nil {
+		networkDomain.Description = *editRequest.Description
+	}
+	if editRequest.Type != nil {
+		networkDomain.Type = *editRequest.Type
+	}
+
+	return ok("", "", "Network domain '%s' has been updated.", networkDomain.ID)
+}
+
+func (server *Server) deleteNetworkDomain(request *apiRequest) *apiResponse {
+	var deleteRequest struct {
+		ID string `json:"id"`
+	}
+	if errorResponse := request.readBody(&deleteRequest); errorResponse != nil {
+		return errorResponse
+	}
+	if errorResponse := server.ensureNoDependencies(deleteRequest.ID); errorResponse != nil {
+		return errorResponse
+	}
+
+	return server.deleteResource(kindNetworkDomain, request, true)
+}
+
+func (server *Server) listVLANs(request *apiRequest) *apiResponse {
+	return server.listResources(kindVLAN, request)
+}
+
+func (server *Server) getVLAN(request *apiRequest) *apiResponse {
+	return server.getResource(kindVLAN, request)
+}
+
+func (server *Server) deployVLAN(request *apiRequest) *apiResponse {
+	var deployRequest deployVLAN
+	if errorResponse := request.readBody(&deployRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(deployRequest.NetworkDomainID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+	if errorResponse := server.ensureUniqueName(kindVLAN, networkDomain.ID, deployRequest.Name); errorResponse != nil {
+		return errorResponse
+	}
+
+	baseAddress := net.ParseIP(deployRequest.IPv4BaseAddress).To4()
+	if baseAddress == nil || deployRequest.IPv4PrefixSize < 16 || deployRequest.IPv4PrefixSize > 29 {
+		return invalidInputData("Invalid private IPv4 network '%s/%d'.", deployRequest.IPv4BaseAddress, deployRequest.IPv4PrefixSize)
+	}
+
+	vlan := &compute.VLAN{
+		ID:          server.nextID(),
+		Name:        deployRequest.Name,
+		Description: deployRequest.Description,
+		NetworkDomain: compute.EntityReference{
+			ID:   networkDomain.ID,
+			Name: networkDomain.Name,
+		},
+		IPv4Range: compute.IPv4Range{
+			BaseAddress: baseAddress.String(),
+			PrefixSize:  deployRequest.IPv4PrefixSize,
+		},
+		GatewayAddressing: "LOW",
+		CreateTime:        server.createTime(),
+		DataCenterID:      networkDomain.DatacenterID,
+	}
+	switch {
+	case deployRequest.DetachedVLAN != nil:
+		vlan.GatewayAddressing = ""
+		vlan.IPv4GatewayAddress = deployRequest.DetachedVLAN.Ipv4GatewayAddress
+	case deployRequest.AttachedVLAN != nil && deployRequest.AttachedVLAN.GatewayAddressing == "HIGH":
+		vlan.GatewayAddressing = "HIGH"
+		vlan.IPv4GatewayAddress = offsetIPv4Address(baseAddress, 1<<uint(32-deployRequest.IPv4PrefixSize)-2)
+	default:
+		vlan.IPv4GatewayAddress = offsetIPv4Address(baseAddress, 1)
+	}
+	server.addResource(kindVLAN, vlan.ID, networkDomain.ID, vlan, &vlan.State, true, nil)
+
+	return inProgress("vlanId", vlan.ID, "Request to deploy VLAN '%s' has been accepted.", vlan.Name)
+}
+
+func (server *Server) editVLAN(request *apiRequest) *apiResponse {
+	var editRequest compute.EditVLAN
+	if errorResponse := request.readBody(&editRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kindVLAN, editRequest.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	vlan := resource.data.(*compute.VLAN)
+	if editRequest.Name != nil {
+		vlan.Name = *editRequest.Name
+	}
+	if editRequest.Description != nil {
+		vlan.Description = *editRequest.Description
+	}
+
+	return ok("", "", "VLAN '%s' has been updated.", vlan.ID)
+}
+
+func (server *Server) deleteVLAN(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindVLAN, request, true)
+}
+
+func (server *Server) listFirewallRules(request *apiRequest) *apiResponse {
+	return server.listResources(kindFirewallRule, request)
+}
+
+func (server *Server) getFirewallRule(request *apiRequest) *apiResponse {
+	return server.getResource(kindFirewallRule, request)
+}
+
+func (server *Server) createFirewallRule(request *apiRequest) *apiResponse {
+	var configuration compute.FirewallRuleConfiguration
+	if errorResponse := request.readBody(&configuration); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(configuration.NetworkDomainID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+	if errorResponse := server.ensureUniqueName(kindFirewallRule, networkDomain.ID, configuration.Name); errorResponse != nil {
+		return errorResponse
+	}
+
+	rule := &compute.FirewallRule{
+		ID:              server.nextID(),
+		Name:            configuration.Name,
+		Action:          configuration.Action,
+		IPVersion:       configuration.IPVersion,
+		Protocol:        configuration.Protocol,
+		Source:          configuration.Source,
+		Destination:     configuration.Destination,
+		Enabled:         configuration.Enabled,
+		NetworkDomainID: networkDomain.ID,
+		DataCenterID:    networkDomain.DatacenterID,
+		RuleType:        "CLIENT_RULE",
+	}
+	server.addResource(kindFirewallRule, rule.ID, networkDomain.ID, rule, &rule.State, false, nil)
+
+	return ok("firewallRuleId", rule.ID, "Firewall rule '%s' has been created.", rule.Name)
+}
+
+func (server *Server) editFirewallRule(request *apiRequest) *apiResponse {
+	var editRequest editFirewallRule
+	if errorResponse := request.readBody(&editRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kindFirewallRule, editRequest.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	rule := resource.data.(*compute.FirewallRule)
+	rule.Enabled = editRequest.Enabled
+
+	return ok("", "", "Firewall rule '%s' has been updated.", rule.ID)
+}
+
+func (server *Server) deleteFirewallRule(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindFirewallRule, request, false)
+}
+
+func (server *Server) listNATRules(request *apiRequest) *apiResponse {
+	return server.listResources(kindNATRule, request)
+}
+
+func (server *Server) getNATRule(request *apiRequest) *apiResponse {
+	return server.getResource(kindNATRule, request)
+}
+
+func (server *Server) createNATRule(request *apiRequest) *apiResponse {
+	var createRequest createNATRule
+	if errorResponse := request.readBody(&createRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(createRequest.NetworkDomainID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+	if net.ParseIP(createRequest.InternalIPAddress) == nil {
+		return invalidInputData("Invalid internal IP address '%s'.", createRequest.InternalIPAddress)
+	}
+
+	var externalIPAddress string
+	if createRequest.ExternalIPAddress != nil {
+		externalIPAddress = *createRequest.ExternalIPAddress
+	} else {
+		externalIPAddress, errorResponse = server.allocateNetworkDomainPublicIPv4Address(networkDomain.ID)
+		if errorResponse != nil {
+			return errorResponse
+		}
+	}
+
+	rule := &compute.NATRule{
+		ID:                server.nextID(),
+		NetworkDomainID:   networkDomain.ID,
+		InternalIPAddress: createRequest.InternalIPAddress,
+		ExternalIPAddress: externalIPAddress,
+		CreateTime:        server.createTime(),
+		DataCenterID:      networkDomain.DatacenterID,
+	}
+	server.addResource(kindNATRule, rule.ID, networkDomain.ID, rule, &rule.State, false, nil)
+
+	return ok("natRuleId", rule.ID, "NAT rule '%s' has been created.", rule.ID)
+}
+
+func (server *Server) deleteNATRule(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindNATRule, request, false)
+}
+
+func (server *Server) listPublicIPBlocks(request *apiRequest) *apiResponse {
+	return server.listResources(kindPublicIPBlock, request)
+}
+
+func (server *Server) getPublicIPBlock(request *apiRequest) *apiResponse {
+	return server.getResource(kindPublicIPBlock, request)
+}
+
+func (server *Server) addPublicIPBlock(request *apiRequest) *apiResponse {
+	var addRequest addPublicIPBlock
+	if errorResponse := request.readBody(&addRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(addRequest.NetworkDomainID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	block := &compute.PublicIPBlock{
+		ID:              server.nextID(),
+		NetworkDomainID: networkDomain.ID,
+		DataCenterID:    networkDomain.DatacenterID,
+		BaseIP:          server.allocatePublicIPv4Addresses(2),
+		Size:            2,
+		CreateTime:      server.createTime(),
+	}
+	server.addResource(kindPublicIPBlock, block.ID, networkDomain.ID, block, &block.State, false, nil)
+
+	return ok("ipBlockId", block.ID, "Public IP block '%s' has been added.", block.ID)
+}
+
+func (server *Server) removePublicIPBlock(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindPublicIPBlock, request, false)
+}
+
+// listReservedPublicIPAddresses lists the public IPv4 addresses in a network domain's public IP blocks that are in use (by NAT rules or virtual listeners).
+func (server *Server) listReservedPublicIPAddresses(request *apiRequest) *apiResponse {
+	query := request.URL.Query()
+	pageNumber, pageSize, errorResponse := getPaging(query)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	reservedIPs := server.getReservedPublicIPv4Addresses(query.Get("networkDomainId"))
+	items := []compute.ReservedPublicIP{}
+	startIndex := (pageNumber - 1) * pageSize
+	for index := startIndex; index < len(reservedIPs) && index < startIndex+pageSize; index++ {
+		items = append(items, reservedIPs[index])
+	}
+
+	return resourceBody(&compute.ReservedPublicIPs{
+		IPs: items,
+		PagedResult: compute.PagedResult{
+			PageNumber: pageNumber,
+			PageCount:  len(items),
+			TotalCount: len(reservedIPs),
+			PageSize:   pageSize,
+		},
+	})
+}
+
+func (server *Server) listStaticRoutes(request *apiRequest) *apiResponse {
+	return server.listResources(kindStaticRoute, request)
+}
+
+func (server *Server) getStaticRoute(request *apiRequest) *apiResponse {
+	return server.getResource(kindStaticRoute, request)
+}
+
+func (server *Server) createStaticRoute(request *apiRequest) *apiResponse {
+	var createRequest compute.CreateStaticRoute
+	if errorResponse := request.readBody(&createRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(createRequest.NetworkDomainId)
+	if errorResponse != nil {
+		return errorResponse
+	}
+	if errorResponse := server.ensureUniqueName(kindStaticRoute, networkDomain.ID, createRequest.Name); errorResponse != nil {
+		return errorResponse
+	}
+
+	route := &compute.StaticRoute{
+		ID:                        server.nextID(),
+		NetworkDomainId:           networkDomain.ID,
+		Name:                      createRequest.Name,
+		Description:               createRequest.Description,
+		Type:                      "CLIENT",
+		IpVersion:                 createRequest.IpVersion,
+		DestinationNetworkAddress: createRequest.DestinationNetworkAddress,
+		DestinationPrefixSize:     createRequest.DestinationPrefixSize,
+		NextHopAddress:            createRequest.NextHopAddress,
+		CreateTime:                server.createTime(),
+		DataCenter:                networkDomain.DatacenterID,
+	}
+	server.addResource(kindStaticRoute, route.ID, networkDomain.ID, route, &route.State, false, nil)
+
+	return ok("staticRouteId", route.ID, "Static route '%s' has been created.", route.Name)
+}
+
+func (server *Server) deleteStaticRoute(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindStaticRoute, request, false)
+}
+
+// getPublicIPv4Addresses retrieves the addresses in each of a network domain's public IP blocks (in order).
+func (server *Server) getPublicIPv4Addresses(networkDomainID string) (addresses []string, blocks []*compute.PublicIPBlock) {
+	var blockResources []*resource
+	for _, resource := range server.resources {
+		if resource.kind == kindPublicIPBlock && resource.networkDomainID == networkDomainID {
+			blockResources = append(blockResources, resource)
+		}
+	}
+	sort.Slice(blockResources, func(index1 int, index2 int) bool {
+		return blockResources[index1].sequence < blockResources[index2].sequence
+	})
+
+	for _, blockResource := range blockResources {
+		block := blockResource.data.(*compute.PublicIPBlock)
+		for offset := 0; offset < block.Size; offset++ {
+			addresses = append(addresses, offsetIPv4Address(net.ParseIP(block.BaseIP), offset))
+			blocks = append(blocks, block)
+		}
+	}
+
+	return
+}
+
+// getReservedPublicIPv4Addresses retrieves the addresses in a network domain's public IP blocks that are in use (by NAT rules or virtual listeners).
+func (server *Server) getReservedPublicIPv4Addresses(networkDomainID string) []compute.ReservedPublicIP {
+	usedAddresses := make(map[string]bool)
+	for _, resource := range server.resources {
+		if resource.networkDomainID != networkDomainID {
+			continue
+		}
+
+		switch data := resource.data.(type) {
+		case *compute.NATRule:
+			usedAddresses[data.ExternalIPAddress] = true
+		case *compute.VirtualListener:
+			usedAddresses[data.ListenerIPAddress] = true
+		}
+	}
+
+	reservedIPs := []compute.ReservedPublicIP{}
+	addresses, blocks := server.getPublicIPv4Addresses(networkDomainID)
+	for index, address := range addresses {
+		if usedAddresses[address] {
+			reservedIPs = append(reservedIPs, compute.ReservedPublicIP{
+				IPBlockID:       blocks[index].ID,
+				DataCenterID:    blocks[index].DataCenterID,
+				NetworkDomainID: networkDomainID,
+				Address:         address,
+			})
+		}
+	}
+
+	return reservedIPs
+}
+
+// allocateNetworkDomainPublicIPv4Address allocates an unused public IPv4 address from a network domain's public IP blocks.
+func (server *Server) allocateNetworkDomainPublicIPv4Address(networkDomainID string) (string, *apiResponse) {
+	reservedAddresses := make(map[string]bool)
+	for _, reservedIP := range server.getReservedPublicIPv4Addresses(networkDomainID) {
+		reservedAddresses[reservedIP.Address] = true
+	}
+
+	addresses, _ := server.getPublicIPv4Addresses(networkDomainID)
+	for _, address := range addresses {
+		if !reservedAddresses[address] {
+			return address, nil
+		}
+	}
+
+	return "", newErrorResponse(compute.ResponseCodeNoIPAddressAvailable, "No public IPv4 address is available in network domain '%s'.", networkDomainID)
+}
+
+// allocatePublicIPv4Addresses allocates a contiguous range of public IPv4 addresses, returning the first address in the range.
+func (server *Server) allocatePublicIPv4Addresses(count int) string {
+	baseAddress := offsetIPv4Address(net.IPv4(168, 128, 0, 0), server.publicAddressCount)
+	server.publicAddressCount += count
+
+	return baseAddress
+}
+
+// offsetIPv4Address calculates the IPv4 address at the specified offset from a base address.
+func offsetIPv4Address(baseAddress net.IP, offset int) string {
+	address := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(address, binary.BigEndian.Uint32(baseAddress.To4())+uint32(offset))
+
+	return address.String()
+}
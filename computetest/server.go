@@ -0,0 +1,781 @@
+// Package computetest provides an in-memory fake of the CloudControl API for testing code that uses the compute package.
+//
+// The fake server is stateful: resources created via the API can subsequently be retrieved, listed, modified and deleted.
+// Operations that CloudControl performs asynchronously (e.g. deploying a network domain or server) leave the resource in a pending state (e.g. PENDING_ADD) until the server's pending duration has elapsed (or CompletePendingOperations is called), so that WaitForDeploy, WaitForDelete, etc can be exercised end-to-end.
+package computetest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute"
+)
+
+// Default values for Server configuration.
+const (
+	// DefaultOrganizationID is the default organisation Id for the fake server's account.
+	DefaultOrganizationID = "b2b8b3c5-4b62-4b15-8b9e-5a7cd7d3b6a1"
+
+	// DefaultUsername is the default user name accepted by the fake server.
+	DefaultUsername = "user1"
+
+	// DefaultPassword is the default password accepted by the fake server.
+	DefaultPassword = "password1"
+
+	// DefaultDatacenterID is the Id of the data centre in which resources are created by default.
+	DefaultDatacenterID = "AU9"
+
+	// DefaultPendingDuration is the default length of time that resources remain in a pending state after an asynchronous operation.
+	DefaultPendingDuration = 1 * time.Second
+)
+
+// Server is a fake CloudControl API server.
+type Server struct {
+	*httptest.Server
+
+	organizationID  string
+	username        string
+	password        string
+	datacenterID    string
+	pendingDuration time.Duration
+
+	stateLock          sync.Mutex
+	resources          map[string]*resource
+	tags               map[string][]compute.TagDetail
+	sequence           int
+	publicAddressCount int
+	now                func() time.Time
+}
+
+// Option customises a Server created using NewServer.
+type Option func(server *Server)
+
+// WithCredentials configures the user name and password accepted by the fake server.
+func WithCredentials(username string, password string) Option {
+	return func(server *Server) {
+		server.username = username
+		server.password = password
+	}
+}
+
+// WithOrganizationID configures the organisation Id for the fake server's account.
+func WithOrganizationID(organizationID string) Option {
+	return func(server *Server) {
+		server.organizationID = organizationID
+	}
+}
+
+// WithDatacenterID configures the Id of the data centre in which network domains are deployed (if not specified by the request).
+func WithDatacenterID(datacenterID string) Option {
+	return func(server *Server) {
+		server.datacenterID = datacenterID
+	}
+}
+
+// WithPendingDuration configures the length of time that resources remain in a pending state after an asynchronous operation.
+//
+// Specify 0 to complete asynchronous operations immediately (resources will never be observed in a pending state).
+func WithPendingDuration(pendingDuration time.Duration) Option {
+	return func(server *Server) {
+		server.pendingDuration = pendingDuration
+	}
+}
+
+// NewServer creates and starts a new fake CloudControl API server.
+//
+// Call Close when the server is no longer required.
+func NewServer(options ...Option) *Server {
+	server := &Server{
+		organizationID:  DefaultOrganizationID,
+		username:        DefaultUsername,
+		password:        DefaultPassword,
+		datacenterID:    DefaultDatacenterID,
+		pendingDuration: DefaultPendingDuration,
+		resources:       make(map[string]*resource),
+		tags:            make(map[string][]compute.TagDetail),
+		now:             time.Now,
+	}
+	for _, option := range options {
+		option(server)
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handleRequest))
+
+	return server
+}
+
+// NewClient creates a new compute API client that uses the fake server (with the fake server's credentials).
+func (server *Server) NewClient(options ...compute.Option) *compute.Client {
+	options = append([]compute.Option{
+		compute.WithBaseAddress(server.URL),
+	}, options...)
+
+	return compute.NewClientWithOptions("", server.username, server.password, options...)
+}
+
+// OrganizationID returns the organisation Id for the fake server's account.
+func (server *Server) OrganizationID() string {
+	return server.organizationID
+}
+
+// CompletePendingOperations immediately completes all pending asynchronous operations (without waiting for the pending duration to elapse).
+func (server *Server) CompletePendingOperations() {
+	server.stateLock.Lock()
+	defer server.stateLock.Unlock()
+
+	for _, resource := range server.resources {
+		resource.pendingUntil = time.Time{}
+	}
+	server.completePendingOperations()
+}
+
+// ResourceState retrieves the current state of the resource with the specified Id.
+//
+// Returns false if no resource exists with the specified Id.
+func (server *Server) ResourceState(id string) (state string, exists bool) {
+	server.stateLock.Lock()
+	defer server.stateLock.Unlock()
+
+	server.completePendingOperations()
+
+	resource, exists := server.resources[id]
+	if !exists {
+		return "", false
+	}
+
+	return resource.getState(), true
+}
+
+// resource represents a resource stored by the fake server.
+type resource struct {
+	// The resource kind.
+	kind *resourceKind
+
+	// The resource Id.
+	id string
+
+	// The Id of the network domain (if any) that contains the resource.
+	networkDomainID string
+
+	// The resource data (a pointer to the corresponding data contract from the compute package).
+	data interface{}
+
+	// A pointer to the resource data's State field (if any).
+	state *string
+
+	// The order in which the resource was created.
+	sequence int
+
+	// The time at which the resource's pending operation (if any) completes.
+	pendingUntil time.Time
+
+	// A function (if any) to call when the resource's pending operation completes.
+	onComplete func()
+
+	// Delete the resource when its pending operation completes?
+	deleteOnComplete bool
+}
+
+// getState retrieves the resource's current state.
+func (resource *resource) getState() string {
+	if resource.state == nil {
+		return compute.ResourceStatusNormal
+	}
+
+	return *resource.state
+}
+
+// isPending determines whether the resource has a pending operation.
+func (resource *resource) isPending() bool {
+	return resource.getState() != compute.ResourceStatusNormal
+}
+
+// setState updates the resource's current state.
+func (resource *resource) setState(state string) {
+	if resource.state != nil {
+		*resource.state = state
+	}
+}
+
+// resourceKind describes a kind of resource stored by the fake server.
+type resourceKind struct {
+	// The name of the resource kind (used in error messages).
+	name string
+
+	// The name of the property containing the resources in a list response.
+	listProperty string
+}
+
+// Well-known resource kinds.
+var (
+	kindNetworkDomain   = &resourceKind{name: "network domain", listProperty: "networkDomain"}
+	kindVLAN            = &resourceKind{name: "VLAN", listProperty: "vlan"}
+	kindServer          = &resourceKind{name: "server", listProperty: "server"}
+	kindFirewallRule    = &resourceKind{name: "firewall rule", listProperty: "firewallRule"}
+	kindNATRule         = &resourceKind{name: "NAT rule", listProperty: "natRule"}
+	kindPublicIPBlock   = &resourceKind{name: "public IP block", listProperty: "publicIpBlock"}
+	kindStaticRoute     = &resourceKind{name: "static route", listProperty: "staticRoute"}
+	kindVIPNode         = &resourceKind{name: "VIP node", listProperty: "node"}
+	kindVIPPool         = &resourceKind{name: "VIP pool", listProperty: "pool"}
+	kindVirtualListener = &resourceKind{name: "virtual listener", listProperty: "virtualListener"}
+)
+
+// requestHandler is a function that handles a request to the fake server (called while the server's state lock is held).
+type requestHandler func(server *Server, request *apiRequest) *apiResponse
+
+// apiRequest represents a request to the fake server's v2 API.
+type apiRequest struct {
+	// The HTTP request.
+	*http.Request
+
+	// The request path (relative to the organisation Id).
+	path string
+
+	// The request body (if any).
+	body []byte
+}
+
+// readBody reads the request body (as JSON) into the specified target.
+func (request *apiRequest) readBody(target interface{}) *apiResponse {
+	err := json.Unmarshal(request.body, target)
+	if err != nil {
+		return invalidInputData("Invalid request body: %s", err)
+	}
+
+	return nil
+}
+
+// apiResponse represents a response from the fake server's v2 API.
+type apiResponse struct {
+	statusCode int
+	body       interface{}
+}
+
+// The fake server's v2 API routes (keyed by HTTP method and path relative to the organisation Id).
+//
+// A trailing "/" in a route's path matches any path that starts with the route's path.
+var routes map[string]requestHandler
+
+func init() {
+	routes = map[string]requestHandler{
+		"GET network/networkDomain":                   (*Server).listNetworkDomains,
+		"GET network/networkDomain/":                  (*Server).getNetworkDomain,
+		"POST network/deployNetworkDomain":            (*Server).deployNetworkDomain,
+		"POST network/editNetworkDomain":              (*Server).editNetworkDomain,
+		"POST network/deleteNetworkDomain":            (*Server).deleteNetworkDomain,
+		"GET network/vlan":                            (*Server).listVLANs,
+		"GET network/vlan/":                           (*Server).getVLAN,
+		"POST network/deployVlan":                     (*Server).deployVLAN,
+		"POST network/editVlan":                       (*Server).editVLAN,
+		"POST network/deleteVlan":                     (*Server).deleteVLAN,
+		"GET network/firewallRule":                    (*Server).listFirewallRules,
+		"GET network/firewallRule/":                   (*Server).getFirewallRule,
+		"POST network/createFirewallRule":             (*Server).createFirewallRule,
+		"POST network/editFirewallRule":               (*Server).editFirewallRule,
+		"POST network/deleteFirewallRule":             (*Server).deleteFirewallRule,
+		"GET network/natRule":                         (*Server).listNATRules,
+		"GET network/natRule/":                        (*Server).getNATRule,
+		"POST network/createNatRule":                  (*Server).createNATRule,
+		"POST network/deleteNatRule":                  (*Server).deleteNATRule,
+		"GET network/publicIpBlock":                   (*Server).listPublicIPBlocks,
+		"GET network/publicIpBlock/":                  (*Server).getPublicIPBlock,
+		"POST network/addPublicIpBlock":               (*Server).addPublicIPBlock,
+		"POST network/removePublicIpBlock":            (*Server).removePublicIPBlock,
+		"GET network/reservedPublicIpv4Address":       (*Server).listReservedPublicIPAddresses,
+		"GET network/staticRoute":                     (*Server).listStaticRoutes,
+		"GET network/staticRoute/":                    (*Server).getStaticRoute,
+		"POST network/createStaticRoute":              (*Server).createStaticRoute,
+		"POST network/deleteStaticRoute":              (*Server).deleteStaticRoute,
+		"GET server/server":                           (*Server).listServers,
+		"GET server/server/":                          (*Server).getServer,
+		"POST server/deployServer":                    (*Server).deployServer,
+		"POST server/editServerMetadata":              (*Server).editServerMetadata,
+		"POST server/deleteServer":                    (*Server).deleteServer,
+		"POST server/startServer":                     (*Server).startServer,
+		"POST server/shutdownServer":                  (*Server).shutdownServer,
+		"POST server/powerOffServer":                  (*Server).powerOffServer,
+		"GET networkDomainVip/node":                   (*Server).listVIPNodes,
+		"GET networkDomainVip/node/":                  (*Server).getVIPNode,
+		"POST networkDomainVip/createNode":            (*Server).createVIPNode,
+		"POST networkDomainVip/editNode":              (*Server).editVIPNode,
+		"POST networkDomainVip/deleteNode":            (*Server).deleteVIPNode,
+		"GET networkDomainVip/pool":                   (*Server).listVIPPools,
+		"GET networkDomainVip/pool/":                  (*Server).getVIPPool,
+		"POST networkDomainVip/createPool":            (*Server).createVIPPool,
+		"POST networkDomainVip/editPool":              (*Server).editVIPPool,
+		"POST networkDomainVip/deletePool":            (*Server).deleteVIPPool,
+		"GET networkDomainVip/virtualListener":        (*Server).listVirtualListeners,
+		"GET networkDomainVip/virtualListener/":       (*Server).getVirtualListener,
+		"POST networkDomainVip/createVirtualListener": (*Server).createVirtualListener,
+		"POST networkDomainVip/editVirtualListener":   (*Server).editVirtualListener,
+		"POST networkDomainVip/deleteVirtualListener": (*Server).deleteVirtualListener,
+		"GET tag/tag":                                 (*Server).listTags,
+		"POST tag/applyTags":                          (*Server).applyTags,
+		"POST tag/removeTags":                         (*Server).removeTags,
+	}
+}
+
+// handleRequest handles a request to the fake server.
+func (server *Server) handleRequest(writer http.ResponseWriter, request *http.Request) {
+	username, password, ok := request.BasicAuth()
+	if !ok || username != server.username || password != server.password {
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	if request.URL.Path == "/oec/0.9/myaccount" && request.Method == http.MethodGet {
+		server.handleMyAccount(writer)
+
+		return
+	}
+
+	// Expected: /caas/2.x/{organizationId}/{path}
+	pathSegments := strings.SplitN(strings.TrimPrefix(request.URL.Path, "/"), "/", 4)
+	if len(pathSegments) != 4 || pathSegments[0] != "caas" || !strings.HasPrefix(pathSegments[1], "2.") {
+		writeJSON(writer, resourceNotFound("Unsupported API end-point '%s'.", request.URL.Path))
+
+		return
+	}
+	if pathSegments[2] != server.organizationID {
+		writeJSON(writer, &apiResponse{
+			statusCode: http.StatusUnauthorized,
+			body:       newAPIResponse(compute.ResponseCodeAuthorizationFailure, "Organization '%s' is not accessible.", pathSegments[2]),
+		})
+
+		return
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		writeJSON(writer, invalidInputData("Unable to read request body: %s", err))
+
+		return
+	}
+
+	apiRequest := &apiRequest{
+		Request: request,
+		path:    pathSegments[3],
+		body:    body,
+	}
+	handler := findRoute(request.Method, apiRequest.path)
+	if handler == nil {
+		writeJSON(writer, resourceNotFound("Unsupported API end-point '%s %s'.", request.Method, request.URL.Path))
+
+		return
+	}
+
+	server.stateLock.Lock()
+	defer server.stateLock.Unlock()
+
+	server.completePendingOperations()
+	writeJSON(writer, handler(server, apiRequest))
+}
+
+// handleMyAccount handles a request for the current user's account details (v1 API).
+func (server *Server) handleMyAccount(writer http.ResponseWriter) {
+	account := &compute.Account{
+		UserName:       server.username,
+		FullName:       "Test User",
+		FirstName:      "Test",
+		LastName:       "User",
+		EmailAddress:   server.username + "@example.com",
+		Department:     "Testing",
+		OrganizationID: server.organizationID,
+		AssignedRoles: []compute.Role{
+			{Name: "primary administrator"},
+		},
+	}
+
+	responseBody, err := xml.Marshal(account)
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(responseBody)
+}
+
+// findRoute finds the handler for the specified HTTP method and path.
+func findRoute(method string, path string) requestHandler {
+	handler, ok := routes[method+" "+path]
+	if ok {
+		return handler
+	}
+
+	lastSeparator := strings.LastIndex(path, "/")
+	if lastSeparator == -1 {
+		return nil
+	}
+
+	return routes[method+" "+path[:lastSeparator+1]]
+}
+
+// writeJSON writes a response as JSON.
+func writeJSON(writer http.ResponseWriter, response *apiResponse) {
+	responseBody, err := json.Marshal(response.body)
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(response.statusCode)
+	writer.Write(responseBody)
+}
+
+// newAPIResponse creates a new v2 API response.
+func newAPIResponse(responseCode string, messageOrFormat string, formatArgs ...interface{}) *compute.APIResponseV2 {
+	return &compute.APIResponseV2{
+		ResponseCode: responseCode,
+		Message:      fmt.Sprintf(messageOrFormat, formatArgs...),
+		RequestID:    fmt.Sprintf("fake/%s", time.Now().UTC().Format(time.RFC3339Nano)),
+	}
+}
+
+// ok creates a response indicating that an operation completed successfully.
+func ok(fieldName string, fieldValue string, messageOrFormat string, formatArgs ...interface{}) *apiResponse {
+	return operationResponse(compute.ResponseCodeOK, fieldName, fieldValue, messageOrFormat, formatArgs...)
+}
+
+// inProgress creates a response indicating that an asynchronous operation has started.
+func inProgress(fieldName string, fieldValue string, messageOrFormat string, formatArgs ...interface{}) *apiResponse {
+	return operationResponse(compute.ResponseCodeInProgress, fieldName, fieldValue, messageOrFormat, formatArgs...)
+}
+
+// operationResponse creates a response for an operation (with an optional field message, e.g. the Id of a new resource).
+func operationResponse(responseCode string, fieldName string, fieldValue string, messageOrFormat string, formatArgs ...interface{}) *apiResponse {
+	response := newAPIResponse(responseCode, messageOrFormat, formatArgs...)
+	if fieldName != "" {
+		response.FieldMessages = []compute.FieldMessage{
+			{FieldName: fieldName, Message: fieldValue},
+		}
+	}
+
+	return &apiResponse{
+		statusCode: http.StatusOK,
+		body:       response,
+	}
+}
+
+// newErrorResponse creates an error response.
+func newErrorResponse(responseCode string, messageOrFormat string, formatArgs ...interface{}) *apiResponse {
+	return &apiResponse{
+		statusCode: http.StatusBadRequest,
+		body:       newAPIResponse(responseCode, messageOrFormat, formatArgs...),
+	}
+}
+
+// resourceNotFound creates a RESOURCE_NOT_FOUND response.
+func resourceNotFound(messageOrFormat string, formatArgs ...interface{}) *apiResponse {
+	return newErrorResponse(compute.ResponseCodeResourceNotFound, messageOrFormat, formatArgs...)
+}
+
+// invalidInputData creates an INVALID_INPUT_DATA response.
+func invalidInputData(messageOrFormat string, formatArgs ...interface{}) *apiResponse {
+	return newErrorResponse(compute.ResponseCodeInvalidInputData, messageOrFormat, formatArgs...)
+}
+
+// resourceBody creates a response containing a single resource.
+func resourceBody(data interface{}) *apiResponse {
+	return &apiResponse{
+		statusCode: http.StatusOK,
+		body:       data,
+	}
+}
+
+// nextID generates a new (unique) resource Id.
+func (server *Server) nextID() string {
+	server.sequence++
+
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", server.sequence, server.sequence)
+}
+
+// createTime generates a creation timestamp for a new resource.
+func (server *Server) createTime() string {
+	return server.now().UTC().Format(time.RFC3339)
+}
+
+// addResource adds a new resource.
+//
+// If pending is true, the resource's state is set to PENDING_ADD until the server's pending duration has elapsed.
+func (server *Server) addResource(kind *resourceKind, id string, networkDomainID string, data interface{}, state *string, pending bool, onComplete func()) *resource {
+	resource := &resource{
+		kind:            kind,
+		id:              id,
+		networkDomainID: networkDomainID,
+		data:            data,
+		state:           state,
+		sequence:        server.sequence,
+	}
+	server.resources[id] = resource
+
+	if pending {
+		server.beginPendingOperation(resource, compute.ResourceStatusPendingAdd, onComplete)
+	} else {
+		resource.setState(compute.ResourceStatusNormal)
+		if onComplete != nil {
+			onComplete()
+		}
+	}
+
+	return resource
+}
+
+// beginPendingOperation places a resource into the specified pending state until the server's pending duration has elapsed.
+func (server *Server) beginPendingOperation(resource *resource, pendingState string, onComplete func()) {
+	resource.setState(pendingState)
+	resource.pendingUntil = server.now().Add(server.pendingDuration)
+	resource.onComplete = onComplete
+	resource.deleteOnComplete = pendingState == compute.ResourceStatusPendingDelete
+
+	server.completePendingOperations()
+}
+
+// completePendingOperations completes any pending operations whose pending duration has elapsed.
+func (server *Server) completePendingOperations() {
+	now := server.now()
+	for id, resource := range server.resources {
+		if !resource.isPending() || now.Before(resource.pendingUntil) {
+			continue
+		}
+
+		if resource.onComplete != nil {
+			resource.onComplete()
+			resource.onComplete = nil
+		}
+
+		if resource.deleteOnComplete {
+			delete(server.resources, id)
+			delete(server.tags, id)
+
+			continue
+		}
+
+		resource.setState(compute.ResourceStatusNormal)
+	}
+}
+
+// findResource finds the resource of the specified kind with the specified Id.
+func (server *Server) findResource(kind *resourceKind, id string) (*resource, *apiResponse) {
+	resource, exists := server.resources[id]
+	if !exists || resource.kind != kind {
+		return nil, resourceNotFound("No %s was found with Id '%s'.", kind.name, id)
+	}
+
+	return resource, nil
+}
+
+// findResourceForUpdate finds the resource of the specified kind with the specified Id, ensuring that it does not have a pending operation.
+func (server *Server) findResourceForUpdate(kind *resourceKind, id string) (*resource, *apiResponse) {
+	resource, errorResponse := server.findResource(kind, id)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if resource.isPending() {
+		return nil, resourceBusy(resource)
+	}
+
+	return resource, nil
+}
+
+// resourceBusy creates a RESOURCE_BUSY response for the specified resource.
+func resourceBusy(resource *resource) *apiResponse {
+	return newErrorResponse(compute.ResponseCodeResourceBusy, "The %s '%s' is currently busy (%s).", resource.kind.name, resource.id, resource.getState())
+}
+
+// ensureNetworkDomain ensures that the specified network domain exists (and is not being deleted).
+func (server *Server) ensureNetworkDomain(networkDomainID string) (*compute.NetworkDomain, *apiResponse) {
+	resource, errorResponse := server.findResource(kindNetworkDomain, networkDomainID)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+	if resource.getState() == compute.ResourceStatusPendingDelete {
+		return nil, resourceBusy(resource)
+	}
+
+	return resource.data.(*compute.NetworkDomain), nil
+}
+
+// ensureUniqueName ensures that no other resource of the specified kind in the same network domain has the specified name.
+func (server *Server) ensureUniqueName(kind *resourceKind, networkDomainID string, name string) *apiResponse {
+	for _, resource := range server.resources {
+		if resource.kind != kind || resource.networkDomainID != networkDomainID {
+			continue
+		}
+
+		if namedEntity, ok := resource.data.(interface{ GetName() string }); ok && namedEntity.GetName() == name {
+			return newErrorResponse(compute.ResponseCodeResourceNameNotUnique, "A %s named '%s' already exists.", kind.name, name)
+		}
+	}
+
+	return nil
+}
+
+// ensureNoDependencies ensures that the specified network domain contains no other resources.
+func (server *Server) ensureNoDependencies(networkDomainID string) *apiResponse {
+	for _, resource := range server.resources {
+		if resource.networkDomainID == networkDomainID && resource.kind != kindNetworkDomain {
+			return newErrorResponse(compute.ResponseCodeResourceHasDependency, "The network domain '%s' cannot be deleted because it contains a %s ('%s').", networkDomainID, resource.kind.name, resource.id)
+		}
+	}
+
+	return nil
+}
+
+// getResource handles a request to retrieve a resource of the specified kind (whose Id is the last segment of the request path).
+func (server *Server) getResource(kind *resourceKind, request *apiRequest) *apiResponse {
+	id := request.path[strings.LastIndex(request.path, "/")+1:]
+	resource, errorResponse := server.findResource(kind, id)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	return resourceBody(resource.data)
+}
+
+// listResources handles a request to list resources of the specified kind.
+//
+// The request's query parameters can specify the Id of the containing network domain ("networkDomainId"), exact-match filters on other top-level properties of the resource (e.g. "name" or "state"), and paging ("pageNumber" and "pageSize").
+func (server *Server) listResources(kind *resourceKind, request *apiRequest) *apiResponse {
+	query := request.URL.Query()
+
+	var matchingResources []*resource
+	for _, resource := range server.resources {
+		if resource.kind != kind {
+			continue
+		}
+
+		isMatch, errorResponse := resourceMatchesQuery(resource, query)
+		if errorResponse != nil {
+			return errorResponse
+		}
+		if isMatch {
+			matchingResources = append(matchingResources, resource)
+		}
+	}
+	sort.Slice(matchingResources, func(index1 int, index2 int) bool {
+		return matchingResources[index1].sequence < matchingResources[index2].sequence
+	})
+
+	pageNumber, pageSize, errorResponse := getPaging(query)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	items := []interface{}{}
+	startIndex := (pageNumber - 1) * pageSize
+	for index := startIndex; index < len(matchingResources) && index < startIndex+pageSize; index++ {
+		items = append(items, matchingResources[index].data)
+	}
+
+	return resourceBody(map[string]interface{}{
+		kind.listProperty: items,
+		"pageNumber":      pageNumber,
+		"pageCount":       len(items),
+		"totalCount":      len(matchingResources),
+		"pageSize":        pageSize,
+	})
+}
+
+// getPaging retrieves paging parameters from a request's query parameters.
+func getPaging(query map[string][]string) (pageNumber int, pageSize int, errorResponse *apiResponse) {
+	pageNumber = 1
+	pageSize = 250
+
+	var err error
+	if values, ok := query["pageNumber"]; ok {
+		pageNumber, err = strconv.Atoi(values[0])
+		if err != nil || pageNumber < 1 {
+			return 0, 0, invalidInputData("Invalid page number '%s'.", values[0])
+		}
+	}
+	if values, ok := query["pageSize"]; ok {
+		pageSize, err = strconv.Atoi(values[0])
+		if err != nil || pageSize < 1 {
+			return 0, 0, invalidInputData("Invalid page size '%s'.", values[0])
+		}
+	}
+
+	return pageNumber, pageSize, nil
+}
+
+// resourceMatchesQuery determines whether a resource matches the filter criteria in a request's query parameters.
+func resourceMatchesQuery(resource *resource, query map[string][]string) (bool, *apiResponse) {
+	var properties map[string]interface{}
+	for name, values := range query {
+		switch name {
+		case "pageNumber", "pageSize", "orderBy":
+			continue
+		case "networkDomainId":
+			if resource.networkDomainID != values[0] {
+				return false, nil
+			}
+
+			continue
+		}
+
+		if strings.Contains(name, ".") {
+			return false, invalidInputData("Unsupported filter '%s' (the fake server only supports exact-match filters).", name)
+		}
+
+		if properties == nil {
+			data, err := json.Marshal(resource.data)
+			if err != nil {
+				return false, newErrorResponse(compute.ResponseCodeUnexpectedError, "Unable to serialise %s: %s", resource.kind.name, err)
+			}
+			err = json.Unmarshal(data, &properties)
+			if err != nil {
+				return false, newErrorResponse(compute.ResponseCodeUnexpectedError, "Unable to serialise %s: %s", resource.kind.name, err)
+			}
+		}
+
+		if fmt.Sprint(properties[name]) != values[0] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// deleteResource handles a request to delete a resource of the specified kind (whose Id is specified by the "id" property of the request body).
+//
+// If async is true, the resource is placed into the PENDING_DELETE state until the server's pending duration has elapsed (otherwise, it is deleted immediately).
+func (server *Server) deleteResource(kind *resourceKind, request *apiRequest, async bool) *apiResponse {
+	var deleteRequest struct {
+		ID string `json:"id"`
+	}
+	if errorResponse := request.readBody(&deleteRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kind, deleteRequest.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	if !async {
+		delete(server.resources, resource.id)
+		delete(server.tags, resource.id)
+
+		return ok("", "", "The %s has been deleted.", kind.name)
+	}
+
+	server.beginPendingOperation(resource, compute.ResourceStatusPendingDelete, nil)
+
+	return inProgress("", "", "Request to delete %s has been accepted.", kind.name)
+}
@@ -0,0 +1,332 @@
+package computetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute"
+)
+
+// Deploy and delete a network domain, waiting for each operation to complete.
+func TestServer_NetworkDomain_WaitForDeployAndDelete(test *testing.T) {
+	if testing.Short() {
+		test.Skip("Skipping test that waits for CloudControl poll intervals (short mode).")
+	}
+
+	server := NewServer()
+	defer server.Close()
+
+	client := server.NewClient()
+
+	networkDomainID, err := client.DeployNetworkDomain("domain1", "Network domain 1", "ESSENTIALS", "AU9")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	networkDomain, err := client.GetNetworkDomain(networkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if networkDomain.State != compute.ResourceStatusPendingAdd {
+		test.Fatalf("NetworkDomain.State: expected '%s' but was '%s'", compute.ResourceStatusPendingAdd, networkDomain.State)
+	}
+
+	// Deleting a network domain while it is being deployed fails.
+	err = client.DeleteNetworkDomain(networkDomainID)
+	if !compute.IsResourceBusyError(err) {
+		test.Fatalf("DeleteNetworkDomain: expected RESOURCE_BUSY error but was %#v", err)
+	}
+
+	resource, err := client.WaitForDeploy(compute.ResourceTypeNetworkDomain, networkDomainID, 30*time.Second)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if resource.GetState() != compute.ResourceStatusNormal {
+		test.Fatalf("NetworkDomain.State: expected '%s' but was '%s'", compute.ResourceStatusNormal, resource.GetState())
+	}
+
+	err = client.DeleteNetworkDomain(networkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	err = client.WaitForDelete(compute.ResourceTypeNetworkDomain, networkDomainID, 30*time.Second)
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	networkDomain, err = client.GetNetworkDomain(networkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if networkDomain != nil {
+		test.Fatalf("GetNetworkDomain: expected nil but was %#v", networkDomain)
+	}
+}
+
+// Deploy a VLAN and a server, then create firewall rules, NAT rules, VIPs, tags and static routes.
+func TestServer_Resources(test *testing.T) {
+	server := NewServer(WithPendingDuration(0))
+	defer server.Close()
+
+	client := server.NewClient()
+
+	account, err := client.GetAccount()
+	if err != nil {
+		test.Fatal(err)
+	}
+	if account.OrganizationID != server.OrganizationID() {
+		test.Fatalf("Account.OrganizationID: expected '%s' but was '%s'", server.OrganizationID(), account.OrganizationID)
+	}
+
+	networkDomainID, err := client.DeployNetworkDomain("domain1", "Network domain 1", "ESSENTIALS", "AU9")
+	if err != nil {
+		test.Fatal(err)
+	}
+
+	_, err = client.DeployNetworkDomain("domain1", "Duplicate network domain", "ESSENTIALS", "AU9")
+	if !compute.IsAPIErrorCode(err, compute.ResponseCodeResourceNameNotUnique) {
+		test.Fatalf("DeployNetworkDomain: expected NAME_NOT_UNIQUE error but was %#v", err)
+	}
+
+	vlanID, err := client.DeployVLAN(networkDomainID, "vlan1", "VLAN 1", "192.168.1.0", 24, "LOW", "")
+	if err != nil {
+		test.Fatal(err)
+	}
+	vlan, err := client.GetVLAN(vlanID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if vlan.State != compute.ResourceStatusNormal || vlan.IPv4GatewayAddress != "192.168.1.1" || vlan.NetworkDomain.ID != networkDomainID {
+		test.Fatalf("GetVLAN: unexpected VLAN %#v", vlan)
+	}
+
+	vlans, err := client.AllVLANs(networkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if len(vlans) != 1 || vlans[0].ID != vlanID {
+		test.Fatalf("AllVLANs: unexpected VLANs %#v", vlans)
+	}
+
+	serverID, err := client.DeployServer(compute.ServerDeploymentConfiguration{
+		Name:                  "server1",
+		ImageID:               "image1",
+		AdministratorPassword: "sn4u$ag3s!",
+		MemoryGB:              8,
+		Network: compute.VirtualMachineNetwork{
+			NetworkDomainID: networkDomainID,
+			PrimaryAdapter: compute.VirtualMachineNetworkAdapter{
+				VLANID: &vlanID,
+			},
+		},
+		Start: true,
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+	deployedServer, err := client.GetServer(serverID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if !deployedServer.Deployed || !deployedServer.Started || deployedServer.Network.PrimaryAdapter.PrivateIPv4Address == nil {
+		test.Fatalf("GetServer: unexpected server %#v", deployedServer)
+	}
+
+	err = client.DeleteNetworkDomain(networkDomainID)
+	if !compute.IsAPIErrorCode(err, compute.ResponseCodeResourceHasDependency) {
+		test.Fatalf("DeleteNetworkDomain: expected HAS_DEPENDENCY error but was %#v", err)
+	}
+
+	err = client.ShutdownServer(serverID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if state, _ := server.ResourceState(serverID); state != compute.ResourceStatusNormal {
+		test.Fatalf("Server.State: expected '%s' but was '%s'", compute.ResourceStatusNormal, state)
+	}
+
+	firewallRuleID, err := client.CreateFirewallRule(compute.FirewallRuleConfiguration{
+		Name:            "rule1",
+		Action:          "ACCEPT_DECISIVELY",
+		Enabled:         true,
+		IPVersion:       "IPV4",
+		Protocol:        "TCP",
+		NetworkDomainID: networkDomainID,
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+	err = client.EditFirewallRule(firewallRuleID, false)
+	if err != nil {
+		test.Fatal(err)
+	}
+	firewallRule, err := client.GetFirewallRule(firewallRuleID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if firewallRule.Enabled {
+		test.Fatalf("FirewallRule.Enabled: expected false")
+	}
+
+	_, err = client.AddNATRule(networkDomainID, "192.168.1.10", nil)
+	if !compute.IsNoIPAddressAvailableError(err) {
+		test.Fatalf("AddNATRule: expected NO_IP_ADDRESS_AVAILABLE error but was %#v", err)
+	}
+
+	_, err = client.AddPublicIPBlock(networkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	natRuleID, err := client.AddNATRule(networkDomainID, "192.168.1.10", nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+	natRule, err := client.GetNATRule(natRuleID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	availableIPs, err := client.GetAvailablePublicIPAddresses(networkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if _, isAvailable := availableIPs[natRule.ExternalIPAddress]; isAvailable || len(availableIPs) != 1 {
+		test.Fatalf("GetAvailablePublicIPAddresses: unexpected addresses %#v (NAT rule uses '%s')", availableIPs, natRule.ExternalIPAddress)
+	}
+
+	nodeID, err := client.CreateVIPNode(compute.NewVIPNodeConfiguration{
+		Name:            "node1",
+		IPv4Address:     "192.168.1.10",
+		Status:          "ENABLED",
+		NetworkDomainID: networkDomainID,
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+	poolID, err := client.CreateVIPPool(compute.NewVIPPoolConfiguration{
+		Name:              "pool1",
+		LoadBalanceMethod: "ROUND_ROBIN",
+		ServiceDownAction: "NONE",
+		NetworkDomainID:   networkDomainID,
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+	listenerID, err := client.CreateVirtualListener(compute.NewVirtualListenerConfiguration{
+		Name:            "listener1",
+		Type:            "STANDARD",
+		Protocol:        "HTTP",
+		Port:            80,
+		Enabled:         true,
+		PoolID:          &poolID,
+		NetworkDomainID: networkDomainID,
+	})
+	if err != nil {
+		test.Fatal(err)
+	}
+	listener, err := client.GetVirtualListener(listenerID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if listener.Pool.ID != poolID || listener.ListenerIPAddress == "" {
+		test.Fatalf("GetVirtualListener: unexpected listener %#v", listener)
+	}
+	node, err := client.GetVIPNode(nodeID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if node.Name != "node1" {
+		test.Fatalf("GetVIPNode: unexpected node %#v", node)
+	}
+
+	_, err = client.ApplyAssetTags(serverID, compute.AssetTypeServer,
+		compute.Tag{Name: "role", Value: "web"},
+		compute.Tag{Name: "owner", Value: "ops"},
+	)
+	if err != nil {
+		test.Fatal(err)
+	}
+	_, err = client.RemoveAssetTags(serverID, compute.AssetTypeServer, "owner")
+	if err != nil {
+		test.Fatal(err)
+	}
+	tags, err := client.GetAssetTags(serverID, compute.AssetTypeServer, nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if len(tags.Items) != 1 || tags.Items[0].Name != "role" || tags.Items[0].Value != "web" || tags.Items[0].AssetName != "server1" {
+		test.Fatalf("GetAssetTags: unexpected tags %#v", tags.Items)
+	}
+
+	staticRouteID, err := client.CreateStaticRoute(networkDomainID, "route1", "Route 1", "IPV4", "10.0.0.0", 8, "192.168.1.254")
+	if err != nil {
+		test.Fatal(err)
+	}
+	staticRoute, err := client.GetStaticRouteByName("route1", networkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if staticRoute == nil || staticRoute.ID != staticRouteID {
+		test.Fatalf("GetStaticRouteByName: unexpected static route %#v", staticRoute)
+	}
+
+	err = client.DeleteServer(serverID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	deletedServer, err := client.GetServer(serverID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if deletedServer != nil {
+		test.Fatalf("GetServer: expected nil but was %#v", deletedServer)
+	}
+	tags, err = client.GetAssetTags(serverID, compute.AssetTypeServer, nil)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if len(tags.Items) != 0 {
+		test.Fatalf("GetAssetTags: expected no tags for deleted server but found %#v", tags.Items)
+	}
+}
+
+// Resources remain pending until the pending duration has elapsed (or pending operations are completed explicitly).
+func TestServer_CompletePendingOperations(test *testing.T) {
+	server := NewServer(WithPendingDuration(1 * time.Hour))
+	defer server.Close()
+
+	client := server.NewClient()
+
+	networkDomainID, err := client.DeployNetworkDomain("domain1", "Network domain 1", "ESSENTIALS", "")
+	if err != nil {
+		test.Fatal(err)
+	}
+	if state, _ := server.ResourceState(networkDomainID); state != compute.ResourceStatusPendingAdd {
+		test.Fatalf("NetworkDomain.State: expected '%s' but was '%s'", compute.ResourceStatusPendingAdd, state)
+	}
+
+	server.CompletePendingOperations()
+
+	networkDomain, err := client.GetNetworkDomain(networkDomainID)
+	if err != nil {
+		test.Fatal(err)
+	}
+	if networkDomain.State != compute.ResourceStatusNormal || networkDomain.DatacenterID != DefaultDatacenterID {
+		test.Fatalf("GetNetworkDomain: unexpected network domain %#v", networkDomain)
+	}
+}
+
+// Requests with invalid credentials are rejected.
+func TestServer_InvalidCredentials(test *testing.T) {
+	server := NewServer(WithCredentials("user2", "password2"))
+	defer server.Close()
+
+	client := compute.NewClientWithOptions("", "user2", "wrong-password",
+		compute.WithBaseAddress(server.URL),
+	)
+
+	_, err := client.GetAccount()
+	if err == nil {
+		test.Fatal("GetAccount: expected error for invalid credentials")
+	}
+}
@@ -0,0 +1,167 @@
+package computetest
+
+import (
+	"net"
+
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute"
+)
+
+// Request body for editing a server's metadata.
+type editServerMetadata struct {
+	ID          string  `json:"id"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (server *Server) listServers(request *apiRequest) *apiResponse {
+	return server.listResources(kindServer, request)
+}
+
+func (server *Server) getServer(request *apiRequest) *apiResponse {
+	return server.getResource(kindServer, request)
+}
+
+func (server *Server) deployServer(request *apiRequest) *apiResponse {
+	var configuration compute.ServerDeploymentConfiguration
+	if errorResponse := request.readBody(&configuration); errorResponse != nil {
+		return errorResponse
+	}
+	if configuration.Name == "" || configuration.ImageID == "" {
+		return invalidInputData("A server name and image Id must be specified.")
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(configuration.Network.NetworkDomainID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	network := configuration.Network
+	network.PrimaryAdapter, errorResponse = server.configureNetworkAdapter(networkDomain.ID, network.PrimaryAdapter)
+	if errorResponse != nil {
+		return errorResponse
+	}
+	additionalAdapters := make([]compute.VirtualMachineNetworkAdapter, len(network.AdditionalNetworkAdapters))
+	for index, adapter := range network.AdditionalNetworkAdapters {
+		additionalAdapters[index], errorResponse = server.configureNetworkAdapter(networkDomain.ID, adapter)
+		if errorResponse != nil {
+			return errorResponse
+		}
+	}
+	network.AdditionalNetworkAdapters = additionalAdapters
+
+	deployedServer := &compute.Server{
+		ID:              server.nextID(),
+		Name:            configuration.Name,
+		Description:     configuration.Description,
+		CPU:             configuration.CPU,
+		MemoryGB:        configuration.MemoryGB,
+		SCSIControllers: configuration.SCSIControllers,
+		Network:         network,
+		SourceImageID:   configuration.ImageID,
+	}
+	start := configuration.Start
+	server.addResource(kindServer, deployedServer.ID, networkDomain.ID, deployedServer, &deployedServer.State, true, func() {
+		deployedServer.Deployed = true
+		deployedServer.Started = start
+	})
+
+	return inProgress("serverId", deployedServer.ID, "Request to deploy server '%s' has been accepted.", deployedServer.Name)
+}
+
+// configureNetworkAdapter validates a network adapter's configuration, and assigns it an Id (and, if required, a private IPv4 address).
+func (server *Server) configureNetworkAdapter(networkDomainID string, adapter compute.VirtualMachineNetworkAdapter) (compute.VirtualMachineNetworkAdapter, *apiResponse) {
+	if adapter.VLANID == nil && adapter.PrivateIPv4Address == nil {
+		return adapter, invalidInputData("A VLAN Id or private IPv4 address must be specified for each network adapter.")
+	}
+
+	adapterID := server.nextID()
+	adapter.ID = &adapterID
+
+	state := compute.ResourceStatusNormal
+	adapter.State = &state
+
+	if adapter.VLANID == nil {
+		return adapter, nil
+	}
+
+	resource, errorResponse := server.findResource(kindVLAN, *adapter.VLANID)
+	if errorResponse != nil {
+		return adapter, errorResponse
+	}
+	if resource.networkDomainID != networkDomainID {
+		return adapter, invalidInputData("VLAN '%s' is not in network domain '%s'.", *adapter.VLANID, networkDomainID)
+	}
+
+	vlan := resource.data.(*compute.VLAN)
+	adapter.VLANName = &vlan.Name
+	if adapter.PrivateIPv4Address == nil {
+		privateIPv4Address := offsetIPv4Address(net.ParseIP(vlan.IPv4Range.BaseAddress), 10+server.sequence%200)
+		adapter.PrivateIPv4Address = &privateIPv4Address
+	}
+
+	return adapter, nil
+}
+
+func (server *Server) editServerMetadata(request *apiRequest) *apiResponse {
+	var editRequest editServerMetadata
+	if errorResponse := request.readBody(&editRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kindServer, editRequest.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	existingServer := resource.data.(*compute.Server)
+	if editRequest.Name != nil {
+		existingServer.Name = *editRequest.Name
+	}
+	if editRequest.Description != nil {
+		existingServer.Description = *editRequest.Description
+	}
+
+	return ok("", "", "Server '%s' has been updated.", existingServer.ID)
+}
+
+func (server *Server) deleteServer(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindServer, request, true)
+}
+
+func (server *Server) startServer(request *apiRequest) *apiResponse {
+	return server.changeServerPowerState(request, "start", true)
+}
+
+func (server *Server) shutdownServer(request *apiRequest) *apiResponse {
+	return server.changeServerPowerState(request, "shut down", false)
+}
+
+func (server *Server) powerOffServer(request *apiRequest) *apiResponse {
+	return server.changeServerPowerState(request, "power off", false)
+}
+
+// changeServerPowerState handles a request to start or stop a server.
+func (server *Server) changeServerPowerState(request *apiRequest, actionDescription string, started bool) *apiResponse {
+	var powerRequest struct {
+		ID string `json:"id"`
+	}
+	if errorResponse := request.readBody(&powerRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kindServer, powerRequest.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	existingServer := resource.data.(*compute.Server)
+	if existingServer.Started == started {
+		return newErrorResponse(compute.ResponseCodeOperationNotSupported, "Cannot %s server '%s' (it is already in the requested power state).", actionDescription, existingServer.ID)
+	}
+
+	server.beginPendingOperation(resource, compute.ResourceStatusPendingChange, func() {
+		existingServer.Started = started
+	})
+
+	return inProgress("", "", "Request to %s server '%s' has been accepted.", actionDescription, existingServer.ID)
+}
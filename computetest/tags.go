@@ -0,0 +1,180 @@
+package computetest
+
+import (
+	"sort"
+
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute"
+)
+
+// Request body for applying tags to an asset.
+type applyTags struct {
+	AssetType string        `json:"assetType"`
+	AssetID   string        `json:"assetId"`
+	Tags      []compute.Tag `json:"tag"`
+}
+
+// Request body for removing tags from an asset.
+type removeTags struct {
+	AssetType string   `json:"assetType"`
+	AssetID   string   `json:"assetId"`
+	TagNames  []string `json:"tagKeyName"`
+}
+
+// The resource kinds that correspond to taggable asset types.
+var assetTypeKinds = map[string]*resourceKind{
+	compute.AssetTypeServer:        kindServer,
+	compute.AssetTypeNetworkDomain: kindNetworkDomain,
+	compute.AssetTypeVLAN:          kindVLAN,
+	compute.AssetTypePublicIPBlock: kindPublicIPBlock,
+}
+
+// listTags lists the tags applied to assets.
+//
+// The request's query parameters can specify the asset Id ("assetId"), asset type ("assetType") and data centre ("datacenterId"), and paging ("pageNumber" and "pageSize").
+func (server *Server) listTags(request *apiRequest) *apiResponse {
+	query := request.URL.Query()
+
+	var assetIDs []string
+	for assetID := range server.tags {
+		assetIDs = append(assetIDs, assetID)
+	}
+	sort.Slice(assetIDs, func(index1 int, index2 int) bool {
+		return server.resources[assetIDs[index1]].sequence < server.resources[assetIDs[index2]].sequence
+	})
+
+	var matchingTags []compute.TagDetail
+	for _, assetID := range assetIDs {
+		for _, tag := range server.tags[assetID] {
+			if query.Get("assetId") != "" && query.Get("assetId") != tag.AssetID {
+				continue
+			}
+			if query.Get("assetType") != "" && query.Get("assetType") != tag.AssetType {
+				continue
+			}
+			if query.Get("datacenterId") != "" && query.Get("datacenterId") != tag.DataCenterID {
+				continue
+			}
+
+			matchingTags = append(matchingTags, tag)
+		}
+	}
+
+	pageNumber, pageSize, errorResponse := getPaging(query)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	items := []compute.TagDetail{}
+	startIndex := (pageNumber - 1) * pageSize
+	for index := startIndex; index < len(matchingTags) && index < startIndex+pageSize; index++ {
+		items = append(items, matchingTags[index])
+	}
+
+	return resourceBody(&compute.TagDetails{
+		Items: items,
+		PagedResult: compute.PagedResult{
+			PageNumber: pageNumber,
+			PageCount:  len(items),
+			TotalCount: len(matchingTags),
+			PageSize:   pageSize,
+		},
+	})
+}
+
+func (server *Server) applyTags(request *apiRequest) *apiResponse {
+	var applyRequest applyTags
+	if errorResponse := request.readBody(&applyRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	asset, errorResponse := server.findAsset(applyRequest.AssetType, applyRequest.AssetID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	for _, tag := range applyRequest.Tags {
+		tagDetail := compute.TagDetail{
+			AssetType:    applyRequest.AssetType,
+			AssetID:      asset.id,
+			DataCenterID: getDatacenterID(asset),
+			TagKeyID:     server.getTagKeyID(tag.Name),
+			Name:         tag.Name,
+			Value:        tag.Value,
+		}
+		if namedEntity, ok := asset.data.(interface{ GetName() string }); ok {
+			tagDetail.AssetName = namedEntity.GetName()
+		}
+
+		server.removeTag(asset.id, tag.Name)
+		server.tags[asset.id] = append(server.tags[asset.id], tagDetail)
+	}
+
+	return ok("", "", "Tags have been applied to %s '%s'.", asset.kind.name, asset.id)
+}
+
+func (server *Server) removeTags(request *apiRequest) *apiResponse {
+	var removeRequest removeTags
+	if errorResponse := request.readBody(&removeRequest); errorResponse != nil {
+		return errorResponse
+	}
+
+	asset, errorResponse := server.findAsset(removeRequest.AssetType, removeRequest.AssetID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	for _, tagName := range removeRequest.TagNames {
+		server.removeTag(asset.id, tagName)
+	}
+
+	return ok("", "", "Tags have been removed from %s '%s'.", asset.kind.name, asset.id)
+}
+
+// findAsset finds the taggable asset with the specified type and Id.
+func (server *Server) findAsset(assetType string, assetID string) (*resource, *apiResponse) {
+	kind, ok := assetTypeKinds[assetType]
+	if !ok {
+		return nil, invalidInputData("Unsupported asset type '%s'.", assetType)
+	}
+
+	return server.findResource(kind, assetID)
+}
+
+// removeTag removes the tag (if any) with the specified name from an asset.
+func (server *Server) removeTag(assetID string, tagName string) {
+	tags := server.tags[assetID][:0]
+	for _, tag := range server.tags[assetID] {
+		if tag.Name != tagName {
+			tags = append(tags, tag)
+		}
+	}
+
+	server.tags[assetID] = tags
+}
+
+// getTagKeyID retrieves (or generates) the Id of the tag key with the specified name.
+func (server *Server) getTagKeyID(tagName string) string {
+	for _, tags := range server.tags {
+		for _, tag := range tags {
+			if tag.Name == tagName {
+				return tag.TagKeyID
+			}
+		}
+	}
+
+	return server.nextID()
+}
+
+// getDatacenterID retrieves the Id of the data centre in which an asset is located.
+func getDatacenterID(asset *resource) string {
+	switch data := asset.data.(type) {
+	case *compute.NetworkDomain:
+		return data.DatacenterID
+	case *compute.VLAN:
+		return data.DataCenterID
+	case *compute.PublicIPBlock:
+		return data.DataCenterID
+	default:
+		return ""
+	}
+}
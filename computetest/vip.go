@@ -0,0 +1,311 @@
+package computetest
+
+import (
+	"github.com/DimensionDataResearch/go-dd-cloud-compute/compute"
+)
+
+func (server *Server) listVIPNodes(request *apiRequest) *apiResponse {
+	return server.listResources(kindVIPNode, request)
+}
+
+func (server *Server) getVIPNode(request *apiRequest) *apiResponse {
+	return server.getResource(kindVIPNode, request)
+}
+
+func (server *Server) createVIPNode(request *apiRequest) *apiResponse {
+	var configuration compute.NewVIPNodeConfiguration
+	if errorResponse := request.readBody(&configuration); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(configuration.NetworkDomainID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+	if errorResponse := server.ensureUniqueName(kindVIPNode, networkDomain.ID, configuration.Name); errorResponse != nil {
+		return errorResponse
+	}
+	if configuration.IPv4Address == "" && configuration.IPv6Address == "" {
+		return invalidInputData("An IPv4 or IPv6 address must be specified for the VIP node.")
+	}
+
+	node := &compute.VIPNode{
+		ID:                  server.nextID(),
+		Name:                configuration.Name,
+		Description:         configuration.Description,
+		IPv4Address:         configuration.IPv4Address,
+		IPv6Address:         configuration.IPv6Address,
+		Status:              configuration.Status,
+		ConnectionLimit:     configuration.ConnectionLimit,
+		ConnectionRateLimit: configuration.ConnectionRateLimit,
+		NetworkDomainID:     networkDomain.ID,
+		DataCenterID:        networkDomain.DatacenterID,
+		CreateTime:          server.createTime(),
+	}
+	if configuration.HealthMonitorID != "" {
+		node.HealthMonitor.ID = configuration.HealthMonitorID
+	}
+	server.addResource(kindVIPNode, node.ID, networkDomain.ID, node, &node.State, false, nil)
+
+	return ok("nodeId", node.ID, "VIP node '%s' has been created.", node.Name)
+}
+
+func (server *Server) editVIPNode(request *apiRequest) *apiResponse {
+	var configuration compute.EditVIPNodeConfiguration
+	if errorResponse := request.readBody(&configuration); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kindVIPNode, configuration.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	node := resource.data.(*compute.VIPNode)
+	if configuration.Description != nil {
+		node.Description = *configuration.Description
+	}
+	if configuration.Status != nil {
+		node.Status = *configuration.Status
+	}
+	if configuration.HealthMonitorID != nil {
+		node.HealthMonitor.ID = *configuration.HealthMonitorID
+	}
+	if configuration.ConnectionLimit != nil {
+		node.ConnectionLimit = *configuration.ConnectionLimit
+	}
+	if configuration.ConnectionRateLimit != nil {
+		node.ConnectionRateLimit = *configuration.ConnectionRateLimit
+	}
+
+	return ok("", "", "VIP node '%s' has been updated.", node.ID)
+}
+
+func (server *Server) deleteVIPNode(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindVIPNode, request, false)
+}
+
+func (server *Server) listVIPPools(request *apiRequest) *apiResponse {
+	return server.listResources(kindVIPPool, request)
+}
+
+func (server *Server) getVIPPool(request *apiRequest) *apiResponse {
+	return server.getResource(kindVIPPool, request)
+}
+
+func (server *Server) createVIPPool(request *apiRequest) *apiResponse {
+	var configuration compute.NewVIPPoolConfiguration
+	if errorResponse := request.readBody(&configuration); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(configuration.NetworkDomainID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+	if errorResponse := server.ensureUniqueName(kindVIPPool, networkDomain.ID, configuration.Name); errorResponse != nil {
+		return errorResponse
+	}
+
+	pool := &compute.VIPPool{
+		ID:                server.nextID(),
+		Name:              configuration.Name,
+		Description:       configuration.Description,
+		LoadBalanceMethod: configuration.LoadBalanceMethod,
+		HealthMonitors:    toEntityReferences(configuration.HealthMonitorIDs),
+		ServiceDownAction: configuration.ServiceDownAction,
+		SlowRampTime:      configuration.SlowRampTime,
+		NetworkDomainID:   networkDomain.ID,
+		DataCenterID:      networkDomain.DatacenterID,
+		CreateTime:        server.createTime(),
+	}
+	server.addResource(kindVIPPool, pool.ID, networkDomain.ID, pool, &pool.State, false, nil)
+
+	return ok("poolId", pool.ID, "VIP pool '%s' has been created.", pool.Name)
+}
+
+func (server *Server) editVIPPool(request *apiRequest) *apiResponse {
+	var configuration compute.EditVIPPoolConfiguration
+	if errorResponse := request.readBody(&configuration); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kindVIPPool, configuration.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	pool := resource.data.(*compute.VIPPool)
+	if configuration.Description != nil {
+		pool.Description = *configuration.Description
+	}
+	if configuration.LoadBalanceMethod != nil {
+		pool.LoadBalanceMethod = *configuration.LoadBalanceMethod
+	}
+	if configuration.HealthMonitorIDs != nil {
+		pool.HealthMonitors = toEntityReferences(*configuration.HealthMonitorIDs)
+	}
+	if configuration.ServiceDownAction != nil {
+		pool.ServiceDownAction = *configuration.ServiceDownAction
+	}
+	if configuration.SlowRampTime != nil {
+		pool.SlowRampTime = *configuration.SlowRampTime
+	}
+
+	return ok("", "", "VIP pool '%s' has been updated.", pool.ID)
+}
+
+func (server *Server) deleteVIPPool(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindVIPPool, request, false)
+}
+
+func (server *Server) listVirtualListeners(request *apiRequest) *apiResponse {
+	return server.listResources(kindVirtualListener, request)
+}
+
+func (server *Server) getVirtualListener(request *apiRequest) *apiResponse {
+	return server.getResource(kindVirtualListener, request)
+}
+
+func (server *Server) createVirtualListener(request *apiRequest) *apiResponse {
+	var configuration compute.NewVirtualListenerConfiguration
+	if errorResponse := request.readBody(&configuration); errorResponse != nil {
+		return errorResponse
+	}
+
+	networkDomain, errorResponse := server.ensureNetworkDomain(configuration.NetworkDomainID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+	if errorResponse := server.ensureUniqueName(kindVirtualListener, networkDomain.ID, configuration.Name); errorResponse != nil {
+		return errorResponse
+	}
+
+	listener := &compute.VirtualListener{
+		ID:                     server.nextID(),
+		Name:                   configuration.Name,
+		Description:            configuration.Description,
+		Type:                   configuration.Type,
+		Protocol:               configuration.Protocol,
+		Port:                   configuration.Port,
+		Enabled:                configuration.Enabled,
+		ConnectionLimit:        configuration.ConnectionLimit,
+		ConnectionRateLimit:    configuration.ConnectionRateLimit,
+		SourcePortPreservation: configuration.SourcePortPreservation,
+		IRules:                 toEntityReferences(configuration.IRuleIDs),
+		CreateTime:             server.createTime(),
+		NetworkDomainID:        networkDomain.ID,
+		DataCenterID:           networkDomain.DatacenterID,
+	}
+	if configuration.ListenerIPAddress != nil {
+		listener.ListenerIPAddress = *configuration.ListenerIPAddress
+	} else {
+		listener.ListenerIPAddress, errorResponse = server.allocateNetworkDomainPublicIPv4Address(networkDomain.ID)
+		if errorResponse != nil {
+			return errorResponse
+		}
+	}
+	if errorResponse := server.setVirtualListenerPool(listener, configuration.PoolID); errorResponse != nil {
+		return errorResponse
+	}
+	if configuration.PersistenceProfileID != nil {
+		listener.PersistenceProfile.ID = *configuration.PersistenceProfileID
+	}
+	if configuration.FallbackPersistenceProfileID != nil {
+		listener.FallbackPersistenceProfile.ID = *configuration.FallbackPersistenceProfileID
+	}
+	if configuration.SSLOffloadProfileID != nil {
+		listener.SSLOffloadProfile.ID = *configuration.SSLOffloadProfileID
+	}
+	if configuration.OptimizationProfile != nil {
+		listener.OptimizationProfile = *configuration.OptimizationProfile
+	}
+	server.addResource(kindVirtualListener, listener.ID, networkDomain.ID, listener, &listener.State, false, nil)
+
+	return ok("virtualListenerId", listener.ID, "Virtual listener '%s' has been created.", listener.Name)
+}
+
+func (server *Server) editVirtualListener(request *apiRequest) *apiResponse {
+	var configuration compute.EditVirtualListenerConfiguration
+	if errorResponse := request.readBody(&configuration); errorResponse != nil {
+		return errorResponse
+	}
+
+	resource, errorResponse := server.findResourceForUpdate(kindVirtualListener, configuration.ID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	listener := resource.data.(*compute.VirtualListener)
+	if configuration.Description != nil {
+		listener.Description = *configuration.Description
+	}
+	if configuration.Enabled != nil {
+		listener.Enabled = *configuration.Enabled
+	}
+	if configuration.ConnectionLimit != nil {
+		listener.ConnectionLimit = *configuration.ConnectionLimit
+	}
+	if configuration.ConnectionRateLimit != nil {
+		listener.ConnectionRateLimit = *configuration.ConnectionRateLimit
+	}
+	if configuration.SourcePortPreservation != nil {
+		listener.SourcePortPreservation = *configuration.SourcePortPreservation
+	}
+	if errorResponse := server.setVirtualListenerPool(listener, configuration.PoolID); errorResponse != nil {
+		return errorResponse
+	}
+	if configuration.PersistenceProfileID != nil {
+		listener.PersistenceProfile.ID = *configuration.PersistenceProfileID
+	}
+	if configuration.SSLOffloadProfileID != nil {
+		listener.SSLOffloadProfile.ID = *configuration.SSLOffloadProfileID
+	}
+	if configuration.IRuleIDs != nil {
+		listener.IRules = toEntityReferences(*configuration.IRuleIDs)
+	}
+	if configuration.OptimizationProfile != nil {
+		listener.OptimizationProfile = *configuration.OptimizationProfile
+	}
+
+	return ok("", "", "Virtual listener '%s' has been updated.", listener.ID)
+}
+
+func (server *Server) deleteVirtualListener(request *apiRequest) *apiResponse {
+	return server.deleteResource(kindVirtualListener, request, false)
+}
+
+// setVirtualListenerPool updates the VIP pool (if any) used by a virtual listener.
+func (server *Server) setVirtualListenerPool(listener *compute.VirtualListener, poolID *string) *apiResponse {
+	if poolID == nil {
+		return nil
+	}
+
+	resource, errorResponse := server.findResource(kindVIPPool, *poolID)
+	if errorResponse != nil {
+		return errorResponse
+	}
+
+	pool := resource.data.(*compute.VIPPool)
+	listener.Pool = compute.VirtualListenerVIPPoolRef{
+		LoadBalanceMethod: pool.LoadBalanceMethod,
+		ServiceDownAction: pool.ServiceDownAction,
+		HealthMonitors:    pool.HealthMonitors,
+		EntityReference: compute.EntityReference{
+			ID:   pool.ID,
+			Name: pool.Name,
+		},
+	}
+
+	return nil
+}
+
+// toEntityReferences converts a list of Ids to a list of EntityReferences.
+func toEntityReferences(ids []string) []compute.EntityReference {
+	entityReferences := make([]compute.EntityReference, len(ids))
+	for index, id := range ids {
+		entityReferences[index].ID = id
+	}
+
+	return entityReferences
+}